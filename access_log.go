@@ -0,0 +1,50 @@
+package rere
+
+import (
+	"regexp"
+)
+
+//nolint:gochecknoglobals // regular expressions are compiled once
+var (
+	// accessLogQuery matches the query string of a URL or path in an access log line, such as the request line and
+	// referer of Apache and nginx combined logs or the path of an envoy JSON log.
+	accessLogQuery = regexp.MustCompile(`(?:^|[\s"'])(?:[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'?]*|/[^\s"'?]*)\?([^\s"'#]+)`)
+	// accessLogHeader matches the value of authorization and cookie headers logged as JSON keys or key=value pairs,
+	// capturing quoted and unquoted values.
+	accessLogHeader = regexp.MustCompile(
+		`(?i)["']?\b(?:authorization|proxy-authorization|cookie|set-cookie|x-api-key)["']?\s*[=:]\s*` +
+			`(?:"((?:[^"\\]|\\.)*)"|([^\s,}]+))`,
+	)
+)
+
+// AccessLogDetector creates a Detector matching sensitive parts of access log lines in common formats, such as Apache
+// and nginx combined logs and envoy JSON logs. Query strings, authorization headers, and cookies are matched, so
+// paths, status codes, and timing remain useful after redaction.
+//
+// AccessLogDetector can be used with Scrub to sanitize existing log archives.
+func AccessLogDetector() Detector {
+	return DetectorFunc(func(line string) [][]int {
+		var matches [][]int
+
+		for _, match := range accessLogQuery.FindAllStringSubmatchIndex(line, -1) {
+			matches = append(matches, match[2:4])
+		}
+
+		for _, match := range accessLogHeader.FindAllStringSubmatchIndex(line, -1) {
+			// a quoted value captures the first group and an unquoted value captures the second group
+			if match[2] != -1 {
+				matches = append(matches, match[2:4])
+			} else {
+				matches = append(matches, match[4:6])
+			}
+		}
+
+		return matches
+	})
+}
+
+// RedactAccessLog redacts query strings, authorization headers, and cookies in an access log line with
+// AccessLogDetector.
+func RedactAccessLog(line string) string {
+	return RedactText(line, AccessLogDetector())
+}
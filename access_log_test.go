@@ -0,0 +1,59 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactAccessLog(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{
+			name: "combined",
+			input: `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /login?user=frank&token=abc HTTP/1.0" 200 2326 ` +
+				`"https://example.com/start?session=xyz" "Mozilla/4.08"`,
+			output: `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /login?REDACTED HTTP/1.0" 200 2326 ` +
+				`"https://example.com/start?REDACTED" "Mozilla/4.08"`,
+		},
+		{
+			name:   "combined without query strings",
+			input:  `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.1" 200 512 "-" "curl/8.0"`,
+			output: `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.1" 200 512 "-" "curl/8.0"`,
+		},
+		{
+			name:   "nginx key value pairs",
+			input:  `status=200 path="/api?key=secret" authorization="Bearer abc" cookie=session=xyz`,
+			output: `status=200 path="/api?REDACTED" authorization="REDACTED" cookie=REDACTED`,
+		},
+		{
+			name: "envoy json",
+			input: `{"path":"/v1/items?api_key=secret","authorization":"Bearer abc","cookie":"a=b; c=d",` +
+				`"response_code":200,"duration":12}`,
+			output: `{"path":"/v1/items?REDACTED","authorization":"REDACTED","cookie":"REDACTED",` +
+				`"response_code":200,"duration":12}`,
+		},
+		{
+			name:   "envoy json with escaped quotes",
+			input:  `{"authorization":"Basic \"abc\"","method":"GET"}`,
+			output: `{"authorization":"REDACTED","method":"GET"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactAccessLog(testCase.input)).To(gomega.Equal(testCase.output),
+				"RedactAccessLog should redact query strings, authorization headers, and cookies")
+		})
+	}
+}
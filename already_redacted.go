@@ -0,0 +1,101 @@
+package rere
+
+import (
+	"encoding/hex"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const hexDigits = "0123456789abcdef"
+
+//nolint:gochecknoglobals // patterns are compiled once per placeholder and shared by every call
+var alreadyRedactedPatterns sync.Map
+
+// WithSkipAlreadyRedacted keeps string and []byte values that already hold a placeholder as-is instead of redacting
+// them again, since values passing through multiple services are otherwise scrubbed repeatedly and lose the length
+// hints and correlation suffixes added upstream. Report.AlreadyRedacted records the paths of skipped values, so double
+// redaction can be detected.
+//
+// A value holds a placeholder when it is the placeholder from WithPlaceholder in any format produced by the placeholder
+// options, such as "REDACTED", "[REDACTED string]", "<redacted config.APIKey>", "REDACTED[pci-v3](23)", or
+// "REDACTED:2026-10:9f3a", or when it is a placeholder provided to WithFieldPlaceholders or WithPathPlaceholders.
+// Placeholders followed by a WithCollisionGuard nonce are recognized too.
+func WithSkipAlreadyRedacted() Option {
+	return func(o *options) {
+		o.skipAlreadyRedacted = true
+	}
+}
+
+// skipsAlreadyRedacted reports whether text at valuePath already holds a placeholder and is skipped, recording it in
+// the report. See WithSkipAlreadyRedacted.
+func (o *options) skipsAlreadyRedacted(valuePath path, text string) bool {
+	if !o.skipAlreadyRedacted || !o.isPlaceholder(text) {
+		return false
+	}
+
+	if o.report != nil {
+		o.report.AlreadyRedacted = append(o.report.AlreadyRedacted, valuePath.String())
+	}
+
+	return true
+}
+
+// skipsAlreadyRedactedBytes is the same as skipsAlreadyRedacted for a byte slice or byte array value, only reading its
+// bytes when WithSkipAlreadyRedacted is provided.
+func (o *options) skipsAlreadyRedactedBytes(valuePath path, value reflect.Value) bool {
+	return o.skipAlreadyRedacted && o.skipsAlreadyRedacted(valuePath, string(byteValues(value)))
+}
+
+func (o *options) isPlaceholder(text string) bool {
+	if alreadyRedactedPattern(o.placeholderMessage).MatchString(text) {
+		return true
+	}
+
+	// placeholder overrides are used as-is, so they only need to be compared without a nonce
+	if separatorIndex := strings.LastIndex(text, nonceSeparator); separatorIndex != -1 {
+		if nonce := text[separatorIndex+len(nonceSeparator):]; len(nonce) == hex.EncodedLen(nonceLength) &&
+			strings.Trim(nonce, hexDigits) == "" {
+			text = text[:separatorIndex]
+		}
+	}
+
+	for _, override := range o.fieldPlaceholders {
+		if text == override.placeholder {
+			return true
+		}
+	}
+
+	for _, overrides := range o.pathPlaceholders {
+		for _, placeholder := range overrides.placeholders {
+			if text == placeholder {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// alreadyRedactedPattern returns a pattern matching every placeholder built on message. See placeholderFor.
+func alreadyRedactedPattern(message string) *regexp.Regexp {
+	if pattern, found := alreadyRedactedPatterns.Load(message); found {
+		//nolint:forcetypeassert // only patterns are stored
+		return pattern.(*regexp.Regexp)
+	}
+
+	quoted := regexp.QuoteMeta(message)
+
+	pattern := regexp.MustCompile(
+		// the message, kind annotated message, or type name message
+		`^(?:` + quoted + `|\[` + quoted + ` (?:` + stringKind + `|` + bytesKind + `)\]|<` +
+			regexp.QuoteMeta(strings.ToLower(message)) + ` [^>]+>)` +
+			// followed by an optional policy stamp, length hint, correlation suffix, and nonce
+			`(?:\[[^\]]+\])?(?:\(\d+\))?(?::(?:[^:]+:)?[0-9a-f]+)?(?:` + nonceSeparator + `[0-9a-f]+)?$`,
+	)
+
+	alreadyRedactedPatterns.Store(message, pattern)
+
+	return pattern
+}
@@ -0,0 +1,132 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactWithSkipAlreadyRedacted(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		value           string
+		opts            []rere.Option
+		expected        string
+		alreadyRedacted bool
+	}{
+		{
+			name:            "skips the placeholder",
+			value:           "REDACTED",
+			opts:            nil,
+			expected:        "REDACTED",
+			alreadyRedacted: true,
+		},
+		{
+			name:            "skips placeholders with a length hint and correlation suffix",
+			value:           "REDACTED(23):2026-10:9f3a",
+			opts:            []rere.Option{rere.WithLengthHint()},
+			expected:        "REDACTED(23):2026-10:9f3a",
+			alreadyRedacted: true,
+		},
+		{
+			name:            "skips kind annotated placeholders with a policy stamp",
+			value:           "[REDACTED string][pci-v3]",
+			opts:            nil,
+			expected:        "[REDACTED string][pci-v3]",
+			alreadyRedacted: true,
+		},
+		{
+			name:            "skips type name placeholders with a nonce",
+			value:           "<redacted config.APIKey>#3f9a1c2e",
+			opts:            nil,
+			expected:        "<redacted config.APIKey>#3f9a1c2e",
+			alreadyRedacted: true,
+		},
+		{
+			name:            "skips the configured placeholder",
+			value:           "***(7)",
+			opts:            []rere.Option{rere.WithPlaceholder("***"), rere.WithLengthHint()},
+			expected:        "***(7)",
+			alreadyRedacted: true,
+		},
+		{
+			name:            "skips field placeholders",
+			value:           "####",
+			opts:            []rere.Option{rere.WithFieldPlaceholders(map[string]string{"secret": "####"})},
+			expected:        "####",
+			alreadyRedacted: true,
+		},
+		{
+			name:            "skips field placeholders with a nonce",
+			value:           "####" + "#3f9a1c2e",
+			opts:            []rere.Option{rere.WithFieldPlaceholders(map[string]string{"secret": "####"})},
+			expected:        "####" + "#3f9a1c2e",
+			alreadyRedacted: true,
+		},
+		{
+			name:            "redacts values containing a placeholder",
+			value:           "REDACTED but not really",
+			opts:            nil,
+			expected:        redacted,
+			alreadyRedacted: false,
+		},
+		{
+			name:            "redacts the default placeholder when another placeholder is configured",
+			value:           "REDACTED",
+			opts:            []rere.Option{rere.WithPlaceholder("***")},
+			expected:        "***",
+			alreadyRedacted: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			var report rere.Report
+
+			opts := append([]rere.Option{rere.WithSkipAlreadyRedacted(), rere.WithReport(&report)}, testCase.opts...)
+
+			details := rere.RedactWithAllowList(credentialDetails{Name: "db", Secret: testCase.value}, []string{"name"},
+				opts...)
+
+			g.Expect(details.Secret).To(gomega.Equal(testCase.expected), "placeholders should not be redacted again")
+
+			if testCase.alreadyRedacted {
+				g.Expect(report.AlreadyRedacted).To(gomega.Equal([]string{"Secret"}),
+					"the report should record already redacted values")
+				g.Expect(report.Redactions).To(gomega.BeEmpty(), "already redacted values should not be recorded as redactions")
+			} else {
+				g.Expect(report.AlreadyRedacted).To(gomega.BeEmpty(), "the report should not record other values")
+			}
+		})
+	}
+}
+
+func TestRedactWithSkipAlreadyRedactedBytesAndMaps(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	user := rere.RedactWithAllowList(reportUser{Username: "dustin", Password: "REDACTED(7)", Key: []byte("REDACTED")},
+		[]string{"username"}, rere.WithSkipAlreadyRedacted(), rere.WithReport(&report))
+
+	g.Expect(user.Password).To(gomega.Equal("REDACTED(7)"), "string placeholders should be kept")
+	g.Expect(user.Key).To(gomega.Equal([]byte(redacted)), "byte slice placeholders should be kept")
+	g.Expect(report.AlreadyRedacted).To(gomega.Equal([]string{"Password", "Key"}),
+		"the report should record already redacted values")
+
+	headers := rere.RedactStringMap(map[string]string{"Authorization": "REDACTED", "Cookie": "session"},
+		rere.WithSkipAlreadyRedacted(), rere.WithReport(&report))
+
+	g.Expect(headers).To(gomega.Equal(map[string]string{"Authorization": redacted, "Cookie": redacted}),
+		"RedactStringMap should redact values that aren't placeholders")
+	g.Expect(report.AlreadyRedacted).To(gomega.Equal([]string{"Authorization"}),
+		"RedactStringMap should record already redacted values")
+}
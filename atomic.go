@@ -0,0 +1,90 @@
+package rere
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"unsafe"
+)
+
+const (
+	atomicPackage     = "sync/atomic"
+	atomicPointerName = "Pointer["
+)
+
+//nolint:gochecknoglobals // types can't be constants
+var atomicValueType = reflect.TypeFor[atomic.Value]()
+
+// isAtomic reports whether valueType is atomic.Value or an atomic.Pointer, whose values are loaded and stored instead
+// of copying and traversing their internal state.
+func isAtomic(valueType reflect.Type) bool {
+	return valueType == atomicValueType ||
+		(valueType.PkgPath() == atomicPackage && strings.HasPrefix(valueType.Name(), atomicPointerName))
+}
+
+// copyAtomic returns a new atomic.Value or atomic.Pointer storing a deep copy of the value loaded from original.
+func copyAtomic(original reflect.Value, redactOptions *options) reflect.Value {
+	deepCopy := reflect.New(original.Type())
+
+	// atomic values stored directly in a map are not addressable, so they are copied to load them
+	if !original.CanAddr() {
+		addressable := reflect.New(original.Type()).Elem()
+		addressable.Set(original)
+		original = addressable
+	}
+
+	loaded := loadAtomic(original)
+	if loaded.IsNil() {
+		return deepCopy.Elem()
+	}
+
+	storeAtomic(deepCopy.Elem(), deepCopyValue(loaded, redactOptions))
+
+	return deepCopy.Elem()
+}
+
+// redactAtomic loads the value of an atomic.Value or atomic.Pointer in the redacted copy, redacts it, and stores it, so
+// values are redacted the same as values found at the location of the atomic value.
+func (o *options) redactAtomic(valueLocation location, atomicValue reflect.Value) {
+	loaded := loadAtomic(atomicValue)
+	if loaded.IsNil() {
+		return
+	}
+
+	redactedValue := reflect.New(loaded.Type())
+	redactedValue.Elem().Set(loaded)
+
+	redact(valueLocation, redactedValue, o)
+
+	storeAtomic(atomicValue, redactedValue.Elem())
+}
+
+// loadAtomic loads the value of the addressable atomic.Value or atomic.Pointer atomicValue. Values of an atomic.Value
+// are returned as an interface value and values of an atomic.Pointer as a pointer value, which are nil when nothing is
+// stored.
+func loadAtomic(atomicValue reflect.Value) reflect.Value {
+	if atomicValue.Type() == atomicValueType {
+		//nolint:forcetypeassert // the types are checked by the caller
+		loaded := atomicValue.Addr().Interface().(*atomic.Value).Load()
+
+		return reflect.ValueOf(&loaded).Elem()
+	}
+
+	// every atomic.Pointer has the same layout, so they are loaded as an atomic.Pointer[byte] and the loaded pointer is
+	// converted back to a pointer to the type held by the first field of atomic.Pointer
+	loaded := (*atomic.Pointer[byte])(unsafe.Pointer(atomicValue.UnsafeAddr())).Load()
+
+	return reflect.NewAt(atomicValue.Type().Field(0).Type.Elem().Elem(), unsafe.Pointer(loaded))
+}
+
+// storeAtomic stores value in the addressable atomic.Value or atomic.Pointer atomicValue.
+func storeAtomic(atomicValue reflect.Value, value reflect.Value) {
+	if atomicValue.Type() == atomicValueType {
+		//nolint:forcetypeassert // the types are checked by the caller
+		atomicValue.Addr().Interface().(*atomic.Value).Store(value.Interface())
+
+		return
+	}
+
+	(*atomic.Pointer[byte])(unsafe.Pointer(atomicValue.UnsafeAddr())).Store((*byte)(value.UnsafePointer()))
+}
@@ -0,0 +1,89 @@
+package rere_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type hotSwapConfig struct {
+	Name        string
+	Credentials atomic.Pointer[credentialDetails]
+	Token       atomic.Value
+	Settings    atomic.Value
+	Unset       atomic.Pointer[credentialDetails]
+	Empty       atomic.Value
+}
+
+func newHotSwapConfig() *hotSwapConfig {
+	config := &hotSwapConfig{Name: "payments"}
+
+	config.Credentials.Store(&credentialDetails{Name: "api", Secret: "hunter2"})
+	config.Token.Store("token")
+	config.Settings.Store(map[string]string{"endpoint": "https://example.com", "password": "letmein"})
+
+	return config
+}
+
+func TestRedactAtomicValues(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		redact      func(input *hotSwapConfig) *hotSwapConfig
+		credentials *credentialDetails
+		token       any
+		settings    any
+	}{
+		{
+			name: "redacts atomic values with an allow list",
+			redact: func(input *hotSwapConfig) *hotSwapConfig {
+				return rere.RedactWithAllowList(input, []string{"Name", "endpoint"})
+			},
+			credentials: &credentialDetails{Name: "api", Secret: redacted},
+			token:       redacted,
+			settings:    map[string]string{"endpoint": "https://example.com", "password": redacted},
+		},
+		{
+			name: "redacts atomic values with a deny list",
+			redact: func(input *hotSwapConfig) *hotSwapConfig {
+				return rere.RedactWithDenyList(input, []string{"Secret", "Token", "password"})
+			},
+			credentials: &credentialDetails{Name: "api", Secret: redacted},
+			token:       redacted,
+			settings:    map[string]string{"endpoint": "https://example.com", "password": redacted},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			input := newHotSwapConfig()
+
+			redactedConfig := testCase.redact(input)
+
+			g.Expect(redactedConfig.Name).To(gomega.Equal("payments"), "fields should be kept")
+			g.Expect(redactedConfig.Credentials.Load()).To(gomega.Equal(testCase.credentials),
+				"atomic.Pointer values should be redacted")
+			g.Expect(redactedConfig.Credentials.Load()).NotTo(gomega.BeIdenticalTo(input.Credentials.Load()),
+				"atomic.Pointer values should be copied")
+			g.Expect(redactedConfig.Token.Load()).To(gomega.Equal(testCase.token), "atomic.Value values should be redacted")
+			g.Expect(redactedConfig.Settings.Load()).To(gomega.Equal(testCase.settings),
+				"atomic.Value values should be redacted by key")
+			g.Expect(redactedConfig.Unset.Load()).To(gomega.BeNil(), "unset atomic.Pointer values should stay unset")
+			g.Expect(redactedConfig.Empty.Load()).To(gomega.BeNil(), "unset atomic.Value values should stay unset")
+
+			g.Expect(input.Credentials.Load()).To(gomega.Equal(&credentialDetails{Name: "api", Secret: "hunter2"}),
+				"the provided input should not be modified")
+			g.Expect(input.Token.Load()).To(gomega.Equal("token"), "the provided input should not be modified")
+			g.Expect(input.Settings.Load()).To(
+				gomega.Equal(map[string]string{"endpoint": "https://example.com", "password": "letmein"}),
+				"the provided input should not be modified")
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package rere
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditSink receives an AuditEvent for every redaction call made with WithAuditSink.
+//
+// Record may be called concurrently by concurrent redaction calls.
+type AuditSink interface {
+	Record(event AuditEvent)
+}
+
+// AuditEvent is evidence that a redaction call ran.
+type AuditEvent struct {
+	Time time.Time `json:"time"`
+	// Policy is the name provided by WithPolicyName.
+	Policy string `json:"policy"`
+	// PolicyVersion is the version provided by WithPolicyVersion.
+	PolicyVersion string `json:"policyVersion,omitempty"`
+	// Redactions are ordered the same as Report.Redactions.
+	Redactions []AuditRedaction `json:"redactions"`
+}
+
+// AuditRedaction describes a single value that was redacted.
+type AuditRedaction struct {
+	Path string `json:"path"`
+	// OriginalHash is a hex encoded HMAC-SHA256 of the original value keyed with the correlation key, so the original
+	// value is not exposed while equal values can still be matched. See WithCorrelationKey.
+	OriginalHash string `json:"originalHash"`
+	// KeyID is the key ID of the primary key of the keyring provided to WithCorrelationKeyring, so hashes can be
+	// matched after keys are rotated.
+	KeyID string `json:"keyId,omitempty"`
+}
+
+// WithAuditSink records an AuditEvent into sink after each redaction call, even when nothing was redacted.
+func WithAuditSink(sink AuditSink) Option {
+	return func(o *options) {
+		o.auditSink = sink
+	}
+}
+
+func (o *options) recordAuditEvent() {
+	if o.auditSink == nil {
+		return
+	}
+
+	redactions := o.auditRecord
+	if redactions == nil {
+		redactions = []AuditRedaction{}
+	}
+
+	o.auditSink.Record(AuditEvent{
+		Time:          time.Now(),
+		Policy:        o.policyName,
+		PolicyVersion: o.policyVersion,
+		Redactions:    redactions,
+	})
+}
+
+// JSONAuditSink is an AuditSink writing each AuditEvent as a line of JSON, such as to an audit log file.
+type JSONAuditSink struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	err    error
+}
+
+// NewJSONAuditSink creates a JSONAuditSink writing to writer.
+func NewJSONAuditSink(writer io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{
+		mutex:  sync.Mutex{},
+		writer: writer,
+		err:    nil,
+	}
+}
+
+// Record writes event as a line of JSON. Once writing fails, events are no longer written and Err returns the error.
+func (s *JSONAuditSink) Record(event AuditEvent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		s.err = fmt.Errorf("failed to marshal audit event: %w", err)
+
+		return
+	}
+
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		s.err = fmt.Errorf("failed to write audit event: %w", err)
+	}
+}
+
+// Err returns the first error encountered while recording events.
+func (s *JSONAuditSink) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.err
+}
@@ -0,0 +1,77 @@
+package rere_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+var errWriteFailed = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWriteFailed
+}
+
+func TestRedactWithAuditSink(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var buffer bytes.Buffer
+
+	sink := rere.NewJSONAuditSink(&buffer)
+	key := []byte("audit key")
+
+	input := reportUser{
+		Username: "alice",
+		Password: "hunter2",
+		Key:      nil,
+	}
+
+	rere.RedactWithAllowList(input, []string{"username"},
+		rere.WithAuditSink(sink), rere.WithPolicyName("users"), rere.WithPolicyVersion("v2"), rere.WithCorrelationKey(key))
+	rere.RedactWithDenyList(input, nil, rere.WithAuditSink(sink))
+
+	g.Expect(sink.Err()).ToNot(gomega.HaveOccurred(), "JSONAuditSink should write events")
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("hunter2"))
+
+	lines := bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n"))
+	g.Expect(lines).To(gomega.HaveLen(2), "JSONAuditSink should write one line per redaction call")
+
+	var first, second rere.AuditEvent
+
+	g.Expect(json.Unmarshal(lines[0], &first)).To(gomega.Succeed(), "JSONAuditSink should write JSON")
+	g.Expect(json.Unmarshal(lines[1], &second)).To(gomega.Succeed(), "JSONAuditSink should write JSON")
+
+	g.Expect(first.Time).ToNot(gomega.BeZero(), "events should have a time")
+	g.Expect(first.Policy).To(gomega.Equal("users"), "events should include the policy name")
+	g.Expect(first.PolicyVersion).To(gomega.Equal("v2"), "events should include the policy version")
+	g.Expect(first.Redactions).To(gomega.Equal([]rere.AuditRedaction{
+		{Path: "Password", OriginalHash: hex.EncodeToString(mac.Sum(nil)), KeyID: ""},
+	}), "events should include redacted paths and hashes of originals")
+
+	g.Expect(second.Policy).To(gomega.BeEmpty(), "events should have an empty policy name by default")
+	g.Expect(second.PolicyVersion).To(gomega.BeEmpty(), "events should have an empty policy version by default")
+	g.Expect(second.Redactions).To(gomega.BeEmpty(), "events should be recorded when nothing is redacted")
+}
+
+func TestJSONAuditSinkWriteError(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	sink := rere.NewJSONAuditSink(failingWriter{})
+
+	rere.RedactWithAllowList("password", nil, rere.WithAuditSink(sink))
+
+	g.Expect(sink.Err()).To(gomega.MatchError(errWriteFailed), "Err should return write errors")
+}
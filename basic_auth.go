@@ -0,0 +1,40 @@
+package rere
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+const basicScheme = "Basic"
+
+// RedactBasicAuth decodes the credentials of a Basic Authorization header value and renders them with the password
+// redacted, such as "Basic alice:REDACTED", so the username remains available to trace misconfigured clients. If
+// maskUsername is true, the username is masked except for its first character, such as "Basic a****:REDACTED".
+//
+// Values that are not valid Basic credentials are entirely replaced with "REDACTED", keeping the scheme when present.
+func RedactBasicAuth(header string, maskUsername bool) string {
+	scheme, credentials, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found {
+		return redactedMessage
+	}
+
+	if !strings.EqualFold(scheme, basicScheme) {
+		return scheme + " " + redactedMessage
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(credentials))
+	if err != nil {
+		return scheme + " " + redactedMessage
+	}
+
+	username, _, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return scheme + " " + redactedMessage
+	}
+
+	if maskUsername {
+		username = maskRunes(username, 1, 0, func(rune) bool { return true })
+	}
+
+	return scheme + " " + username + ":" + redactedMessage
+}
@@ -0,0 +1,46 @@
+package rere_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	credentials := base64.StdEncoding.EncodeToString([]byte("alice:hunter2"))
+
+	testCases := []struct {
+		name         string
+		input        string
+		maskUsername bool
+		output       string
+	}{
+		{name: "keeps username", input: "Basic " + credentials, maskUsername: false, output: "Basic alice:REDACTED"},
+		{name: "masks username", input: "Basic " + credentials, maskUsername: true, output: "Basic a****:REDACTED"},
+		{name: "matches scheme case insensitively", input: "basic " + credentials, maskUsername: false, output: "basic alice:REDACTED"},
+		{name: "redacts other schemes", input: "Bearer token", maskUsername: false, output: "Bearer REDACTED"},
+		{name: "redacts invalid base64", input: "Basic !!!", maskUsername: false, output: "Basic REDACTED"},
+		{
+			name:         "redacts credentials without a password separator",
+			input:        "Basic " + base64.StdEncoding.EncodeToString([]byte("alice")),
+			maskUsername: false,
+			output:       "Basic REDACTED",
+		},
+		{name: "redacts values without a scheme", input: credentials, maskUsername: false, output: redacted},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactBasicAuth(testCase.input, testCase.maskUsername)).To(gomega.Equal(testCase.output),
+				"RedactBasicAuth should redact the password")
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package rere
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// BinaryBytes configures how byte slices that look like binary data are handled by WithBinaryBytes.
+type BinaryBytes int
+
+const (
+	// KeepBinary leaves byte slices that look like binary data as-is, such as images and protobuf payloads.
+	KeepBinary BinaryBytes = iota + 1
+	// NoteBinary replaces byte slices that look like binary data with a note of their length, such as
+	// []byte("<binary 512 bytes>").
+	NoteBinary
+)
+
+// minimumPrintableRatio is the ratio of printable runes a byte slice needs to look like text.
+const minimumPrintableRatio = 0.9
+
+// WithBinaryBytes only redacts byte slices that look like text, being valid UTF-8 with mostly printable characters,
+// with a placeholder. Byte slices that look like binary data are kept or noted based on binaryBytes, since replacing
+// binary payloads with a text placeholder can corrupt downstream tooling.
+func WithBinaryBytes(binaryBytes BinaryBytes) Option {
+	return func(o *options) {
+		o.binaryBytes = binaryBytes
+	}
+}
+
+// shouldRedactBytes reports whether a byte slice or byte array value that should be redacted is redacted, which is
+// false when Bytes are not provided to WithKinds and for binary data when configured with KeepBinary.
+func (o *options) shouldRedactBytes(value []byte) bool {
+	return o.redactsKind(Bytes) && (o.binaryBytes != KeepBinary || looksLikeText(value))
+}
+
+func looksLikeText(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+
+	printable := 0
+	total := 0
+
+	for _, character := range string(value) {
+		total++
+
+		if unicode.IsPrint(character) || unicode.IsSpace(character) {
+			printable++
+		}
+	}
+
+	return float64(printable) >= minimumPrintableRatio*float64(total)
+}
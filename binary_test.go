@@ -0,0 +1,52 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactWithBinaryBytes(t *testing.T) {
+	t.Parallel()
+
+	input := structWithByteSlice{
+		Password: []byte("hunter2"),
+		password: []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d},
+	}
+
+	testCases := []struct {
+		name        string
+		binaryBytes rere.BinaryBytes
+		output      structWithByteSlice
+	}{
+		{
+			name:        "keeps binary byte slices",
+			binaryBytes: rere.KeepBinary,
+			output: structWithByteSlice{
+				Password: []byte(redacted),
+				password: []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d},
+			},
+		},
+		{
+			name:        "notes binary byte slices",
+			binaryBytes: rere.NoteBinary,
+			output: structWithByteSlice{
+				Password: []byte(redacted),
+				password: []byte("<binary 12 bytes>"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			redacted := rere.RedactWithAllowList(input, nil, rere.WithBinaryBytes(testCase.binaryBytes))
+
+			g.Expect(redacted).To(gomega.Equal(testCase.output), "WithBinaryBytes should only redact text byte slices")
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package rere
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// nameFolding configures how field and key names are compared with entries case insensitively.
+type nameFolding struct {
+	// caseSensitive compares names without folding them when set by WithCaseSensitiveNames.
+	caseSensitive bool
+	// locale folds names with the case mapping rules of a language when provided by WithLocaleCaseFolding.
+	locale *localeFolder
+	// normalization normalizes names before folding them when normalize is set by WithNameNormalization.
+	normalization norm.Form
+	normalize     bool
+}
+
+// localeFolder folds names with the case mapping rules of a language. Casers are stateful, so they are pooled instead
+// of shared between goroutines.
+type localeFolder struct {
+	casers sync.Pool
+}
+
+type localeCasers struct {
+	lower cases.Caser
+	fold  cases.Caser
+}
+
+// WithLocaleCaseFolding matches field and key names against the entries of lists, WithFieldPlaceholders, and
+// WithTransform with the case mapping rules of tag and full Unicode case folding instead of strings.EqualFold. For
+// example, language.Turkish matches "KİMLİK" with "kimlik" and "ID" with "ıd" instead of "id", and every language
+// matches "STRASSE" with "Straße".
+//
+// Paths provided to WithRedactPaths, WithKeepPaths, and WithPathPlaceholders are still matched with strings.EqualFold.
+func WithLocaleCaseFolding(tag language.Tag) Option {
+	folder := &localeFolder{
+		casers: sync.Pool{
+			New: func() any {
+				return &localeCasers{lower: cases.Lower(tag), fold: cases.Fold()}
+			},
+		},
+	}
+
+	return func(o *options) {
+		o.nameFolding.locale = folder
+	}
+}
+
+// WithNameNormalization normalizes field and key names and the entries of lists, WithFieldPlaceholders, and
+// WithTransform to form before matching them, such as norm.NFC or norm.NFKC, so names from sources with inconsistent
+// normalization match. For example, norm.NFC matches "café" spelled with a precomposed "é" with "café" spelled with
+// "e" followed by a combining acute accent. WithNameNormalization can be combined with WithLocaleCaseFolding.
+//
+// Paths provided to WithRedactPaths, WithKeepPaths, and WithPathPlaceholders are not normalized.
+func WithNameNormalization(form norm.Form) Option {
+	return func(o *options) {
+		o.nameFolding.normalization = form
+		o.nameFolding.normalize = true
+	}
+}
+
+// WithCaseSensitiveNames matches field and key names against the entries of lists, WithFieldPlaceholders, and
+// WithTransform case sensitively, for codebases where names such as "ID" and "id" are different fields with different
+// sensitivity. Globs such as "*_token" match case sensitively too, while regular expression entries keep matching as
+// written. WithCaseSensitiveNames takes priority over WithLocaleCaseFolding and can be combined with
+// WithNameNormalization.
+//
+// Type names of type-scoped entries and paths provided to WithRedactPaths, WithKeepPaths, and WithPathPlaceholders are
+// still matched with strings.EqualFold.
+func WithCaseSensitiveNames() Option {
+	return func(o *options) {
+		o.nameFolding.caseSensitive = true
+	}
+}
+
+// fold returns name normalized and case folded, so two names are equal case insensitively exactly when their folded
+// names are equal. Names matched case sensitively are only normalized.
+func (f nameFolding) fold(name string) string {
+	if f.normalize {
+		name = f.normalization.String(name)
+	}
+
+	if f.caseSensitive {
+		return name
+	}
+
+	if f.locale != nil {
+		return f.locale.fold(name)
+	}
+
+	return foldName(name)
+}
+
+// equal reports whether the field or key names first and second are equal, case insensitively unless caseSensitive is
+// set.
+func (f nameFolding) equal(first, second string) bool {
+	switch {
+	case f.caseSensitive && !f.normalize:
+		return first == second
+	case f.locale == nil && !f.normalize:
+		return strings.EqualFold(first, second)
+	}
+
+	return first == second || f.fold(first) == f.fold(second)
+}
+
+// fold returns name lowered with the rules of the folder's language and case folded.
+func (f *localeFolder) fold(name string) string {
+	casers, _ := f.casers.Get().(*localeCasers)
+	defer f.casers.Put(casers)
+
+	return casers.fold.String(casers.lower.String(name))
+}
+
+// equalNames reports whether the field or key names first and second are equal case insensitively.
+func (o *options) equalNames(first, second string) bool {
+	return o.nameFolding.equal(first, second)
+}
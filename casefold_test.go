@@ -0,0 +1,223 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestWithLocaleCaseFolding(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]string{
+		"KİMLİK":  "12345678901",
+		"ID":      "42",
+		"STRASSE": "Hauptstraße 1",
+		"secret":  "hunter2",
+	}
+
+	testCases := []struct {
+		name      string
+		allowList []string
+		opts      []rere.Option
+		output    map[string]string
+	}{
+		{
+			name:      "matches names with strings.EqualFold by default",
+			allowList: []string{"kimlik", "id", "straße"},
+			opts:      nil,
+			output:    map[string]string{"KİMLİK": redacted, "ID": "42", "STRASSE": redacted, "secret": redacted},
+		},
+		{
+			name:      "matches names with Turkish case mapping",
+			allowList: []string{"kimlik", "id", "straße"},
+			opts:      []rere.Option{rere.WithLocaleCaseFolding(language.Turkish)},
+			output:    map[string]string{"KİMLİK": "12345678901", "ID": redacted, "STRASSE": "Hauptstraße 1", "secret": redacted},
+		},
+		{
+			name:      "matches dotless i with Turkish case mapping",
+			allowList: []string{"ıd"},
+			opts:      []rere.Option{rere.WithLocaleCaseFolding(language.Turkish)},
+			output:    map[string]string{"KİMLİK": redacted, "ID": "42", "STRASSE": redacted, "secret": redacted},
+		},
+		{
+			name:      "matches names with full case folding",
+			allowList: []string{"straße", "id"},
+			opts:      []rere.Option{rere.WithLocaleCaseFolding(language.German)},
+			output:    map[string]string{"KİMLİK": redacted, "ID": "42", "STRASSE": "Hauptstraße 1", "secret": redacted},
+		},
+		{
+			name:      "matches names in large lists with Turkish case mapping",
+			allowList: append(generatedEntries("field%d", 100), "kimlik"),
+			opts:      []rere.Option{rere.WithLocaleCaseFolding(language.Turkish)},
+			output:    map[string]string{"KİMLİK": "12345678901", "ID": redacted, "STRASSE": redacted, "secret": redacted},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactWithAllowList(input, testCase.allowList, testCase.opts...)).To(gomega.Equal(testCase.output),
+				"RedactWithAllowList should match names with the configured case folding")
+
+			policy := rere.NewAllowListPolicy(testCase.allowList, testCase.opts...)
+
+			g.Expect(policy.Redact(input)).To(gomega.Equal(testCase.output),
+				"Policy should match names with the configured case folding")
+			g.Expect(policy.Redact(input)).To(gomega.Equal(testCase.output),
+				"Policy should reuse its name index with the configured case folding")
+		})
+	}
+}
+
+func TestWithNameNormalization(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]string{
+		"café":  "espresso",
+		"ＴＯＫＥＮ":  "abc123",
+		"secret": "hunter2",
+	}
+
+	testCases := []struct {
+		name      string
+		allowList []string
+		opts      []rere.Option
+		output    map[string]string
+	}{
+		{
+			name:      "doesn't normalize names by default",
+			allowList: []string{"café", "token"},
+			opts:      nil,
+			output:    map[string]string{"café": redacted, "ＴＯＫＥＮ": redacted, "secret": redacted},
+		},
+		{
+			name:      "matches canonically equivalent names with NFC",
+			allowList: []string{"CAFÉ", "token"},
+			opts:      []rere.Option{rere.WithNameNormalization(norm.NFC)},
+			output:    map[string]string{"café": "espresso", "ＴＯＫＥＮ": redacted, "secret": redacted},
+		},
+		{
+			name:      "matches compatibility equivalent names with NFKC",
+			allowList: []string{"café", "token"},
+			opts:      []rere.Option{rere.WithNameNormalization(norm.NFKC)},
+			output:    map[string]string{"café": "espresso", "ＴＯＫＥＮ": "abc123", "secret": redacted},
+		},
+		{
+			name:      "matches normalized names with locale case folding",
+			allowList: []string{"CAFÉ"},
+			opts: []rere.Option{
+				rere.WithNameNormalization(norm.NFC),
+				rere.WithLocaleCaseFolding(language.French),
+			},
+			output: map[string]string{"café": "espresso", "ＴＯＫＥＮ": redacted, "secret": redacted},
+		},
+		{
+			name:      "matches normalized names in large lists",
+			allowList: append(generatedEntries("field%d", 100), "café"),
+			opts:      []rere.Option{rere.WithNameNormalization(norm.NFD)},
+			output:    map[string]string{"café": "espresso", "ＴＯＫＥＮ": redacted, "secret": redacted},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactWithAllowList(input, testCase.allowList, testCase.opts...)).To(gomega.Equal(testCase.output),
+				"RedactWithAllowList should match normalized names")
+			g.Expect(rere.NewAllowListPolicy(testCase.allowList, testCase.opts...).Redact(input)).To(
+				gomega.Equal(testCase.output), "Policy should match normalized names")
+		})
+	}
+}
+
+func TestWithCaseSensitiveNames(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]string{
+		"ID":          "42",
+		"id":          "internal-42",
+		"api_token":   "hunter2",
+		"API_TOKEN":   "hunter3",
+		"Description": "public",
+	}
+
+	testCases := []struct {
+		name      string
+		allowList []string
+		opts      []rere.Option
+		output    map[string]string
+	}{
+		{
+			name:      "matches names case insensitively by default",
+			allowList: []string{"ID", "api_*"},
+			opts:      nil,
+			output: map[string]string{
+				"ID": "42", "id": "internal-42", "api_token": "hunter2", "API_TOKEN": "hunter3", "Description": redacted,
+			},
+		},
+		{
+			name:      "matches names and globs case sensitively",
+			allowList: []string{"ID", "api_*"},
+			opts:      []rere.Option{rere.WithCaseSensitiveNames()},
+			output: map[string]string{
+				"ID": "42", "id": redacted, "api_token": "hunter2", "API_TOKEN": redacted, "Description": redacted,
+			},
+		},
+		{
+			name:      "keeps matching regular expression entries as written",
+			allowList: []string{"/(?i)api_token/"},
+			opts:      []rere.Option{rere.WithCaseSensitiveNames()},
+			output: map[string]string{
+				"ID": redacted, "id": redacted, "api_token": "hunter2", "API_TOKEN": "hunter3", "Description": redacted,
+			},
+		},
+		{
+			name:      "takes priority over locale case folding",
+			allowList: []string{"id"},
+			opts:      []rere.Option{rere.WithLocaleCaseFolding(language.English), rere.WithCaseSensitiveNames()},
+			output: map[string]string{
+				"ID": redacted, "id": "internal-42", "api_token": redacted, "API_TOKEN": redacted, "Description": redacted,
+			},
+		},
+		{
+			name:      "matches names in large lists case sensitively",
+			allowList: append(generatedEntries("field%d", 100), "description"),
+			opts:      []rere.Option{rere.WithCaseSensitiveNames()},
+			output: map[string]string{
+				"ID": redacted, "id": redacted, "api_token": redacted, "API_TOKEN": redacted, "Description": redacted,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactWithAllowList(input, testCase.allowList, testCase.opts...)).To(gomega.Equal(testCase.output),
+				"RedactWithAllowList should match names with the configured case sensitivity")
+
+			policy := rere.NewAllowListPolicy(testCase.allowList, testCase.opts...)
+
+			g.Expect(policy.Redact(input)).To(gomega.Equal(testCase.output),
+				"Policy should match names with the configured case sensitivity")
+		})
+	}
+}
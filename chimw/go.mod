@@ -0,0 +1,19 @@
+module github.com/dustinspecker/rere/chimw
+
+go 1.23.0
+
+require (
+	github.com/dustinspecker/rere v0.0.0-20261018013946-6fdd0a4fe494
+	github.com/go-chi/chi/v5 v5.3.2
+	github.com/go-chi/httplog/v3 v3.3.0
+	github.com/onsi/gomega v1.33.1
+)
+
+require (
+	github.com/fsnotify/fsnotify v1.10.1 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	golang.org/x/net v0.24.0 // indirect
+	golang.org/x/sys v0.19.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,149 @@
+// Package chimw redacts chi request logs with rere.
+package chimw
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/dustinspecker/rere"
+	"github.com/go-chi/httplog/v3"
+)
+
+// Handler is a slog.Handler redacting attributes with a rere.Redactor and scrubbing messages and kept string
+// attributes with detectors before passing records to the next handler. Handler can be used with chi's httplog and any
+// other middleware.Logger replacement logging through log/slog.
+type Handler struct {
+	next      slog.Handler
+	redactor  rere.Redactor
+	detectors []rere.Detector
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// NewHandler creates a Handler passing redacted records to next.
+//
+// String attributes and attributes holding other values, such as structs logged with slog.Any, are redacted by
+// redactor as map keys named after their attribute key, and groups are redacted as nested maps. Numbers, booleans,
+// durations, and times are kept as-is. Messages and string attributes kept by redactor are scrubbed with detectors,
+// such as rere.QueryStringDetector for URLs.
+func NewHandler(next slog.Handler, redactor rere.Redactor, detectors ...rere.Detector) *Handler {
+	return &Handler{
+		next:      next,
+		redactor:  redactor,
+		detectors: detectors,
+	}
+}
+
+// RequestLogger creates chi's httplog request logger middleware logging through a Handler wrapping the handler of
+// logger. Messages and URLs are scrubbed with rere.QueryStringDetector, so attribute keys of the httplog schema, such
+// as "url.full" and "http.request.method" for httplog.SchemaECS, can be kept by an allow list without leaking
+// sensitive query parameters.
+func RequestLogger(
+	logger *slog.Logger, redactor rere.Redactor, options *httplog.Options,
+) func(http.Handler) http.Handler {
+	return httplog.RequestLogger(slog.New(NewHandler(logger.Handler(), redactor, rere.QueryStringDetector())), options)
+}
+
+// Enabled reports whether the next handler handles records at level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle redacts record and passes it to the next handler.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, rere.RedactText(record.Message, h.detectors...), record.PC)
+
+	attributes := make([]slog.Attr, 0, record.NumAttrs())
+
+	record.Attrs(func(attribute slog.Attr) bool {
+		attributes = append(attributes, attribute)
+
+		return true
+	})
+
+	redacted.AddAttrs(h.redactAttrs(attributes)...)
+
+	//nolint:wrapcheck // errors of the next handler are returned as-is
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs returns a Handler passing records to the next handler with redacted attributes.
+func (h *Handler) WithAttrs(attributes []slog.Attr) slog.Handler {
+	return NewHandler(h.next.WithAttrs(h.redactAttrs(attributes)), h.redactor, h.detectors...)
+}
+
+// WithGroup returns a Handler passing records to the next handler with group name. Attribute keys in the group are
+// redacted by their own key, without the group name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return NewHandler(h.next.WithGroup(name), h.redactor, h.detectors...)
+}
+
+func (h *Handler) redactAttrs(attributes []slog.Attr) []slog.Attr {
+	if len(attributes) == 0 {
+		return attributes
+	}
+
+	//nolint:forcetypeassert // rere.Redactor returns values of the same dynamic type
+	redacted := h.redactor.Redact(fromAttrs(attributes)).(map[string]any)
+
+	return h.toAttrs(attributes, redacted)
+}
+
+// fromAttrs converts attributes to a map redacted by rere. Values not redacted by rere, such as numbers, are left out.
+func fromAttrs(attributes []slog.Attr) map[string]any {
+	converted := make(map[string]any, len(attributes))
+
+	for _, attribute := range attributes {
+		value := attribute.Value.Resolve()
+
+		//nolint:exhaustive // every other kind is kept as-is
+		switch value.Kind() {
+		case slog.KindString:
+			converted[attribute.Key] = value.String()
+		case slog.KindGroup:
+			converted[attribute.Key] = fromAttrs(value.Group())
+		case slog.KindAny:
+			converted[attribute.Key] = value.Any()
+		}
+	}
+
+	return converted
+}
+
+// toAttrs converts redacted, a redacted copy of the map returned by fromAttrs for original, back to attributes. The
+// order of attributes in original is kept.
+func (h *Handler) toAttrs(original []slog.Attr, redacted map[string]any) []slog.Attr {
+	converted := make([]slog.Attr, 0, len(original))
+
+	for _, attribute := range original {
+		value := attribute.Value.Resolve()
+
+		redactedValue, found := redacted[attribute.Key]
+		if !found {
+			converted = append(converted, slog.Attr{Key: attribute.Key, Value: value})
+
+			continue
+		}
+
+		switch redactedValue := redactedValue.(type) {
+		case string:
+			converted = append(converted, slog.String(attribute.Key, rere.RedactText(redactedValue, h.detectors...)))
+		case map[string]any:
+			if value.Kind() == slog.KindGroup {
+				converted = append(converted, slog.Attr{
+					Key:   attribute.Key,
+					Value: slog.GroupValue(h.toAttrs(value.Group(), redactedValue)...),
+				})
+
+				break
+			}
+
+			converted = append(converted, slog.Any(attribute.Key, redactedValue))
+		default:
+			converted = append(converted, slog.Any(attribute.Key, redactedValue))
+		}
+	}
+
+	return converted
+}
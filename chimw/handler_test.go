@@ -0,0 +1,92 @@
+package chimw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/dustinspecker/rere/chimw"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog/v3"
+	"github.com/onsi/gomega"
+)
+
+type credentials struct {
+	Username string
+	Password string
+}
+
+func TestRequestLogger(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var output bytes.Buffer
+
+	policy := rere.NewAllowListPolicy([]string{"url.full", "url.path", "http.request.method", "accept"})
+
+	router := chi.NewRouter()
+	router.Use(chimw.RequestLogger(slog.New(slog.NewJSONHandler(&output, nil)), policy, &httplog.Options{
+		Level:             slog.LevelInfo,
+		Schema:            httplog.SchemaECS,
+		LogRequestHeaders: []string{"Accept", "Authorization"},
+	}))
+	router.Get("/users", func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusNoContent)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/users?page=2&api_key=secret", nil)
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Authorization", "Bearer secret")
+
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	var logged map[string]any
+
+	g.Expect(json.Unmarshal(output.Bytes(), &logged)).To(gomega.Succeed(), "RequestLogger should log JSON")
+	g.Expect(logged).To(gomega.HaveKeyWithValue("msg", gomega.ContainSubstring("/users?page=2&api_key=REDACTED")),
+		"RequestLogger should scrub query parameters in messages")
+	g.Expect(logged).To(gomega.HaveKeyWithValue("url.full", "http://example.com/users?page=2&api_key=REDACTED"),
+		"RequestLogger should scrub query parameters in kept URLs")
+	g.Expect(logged).To(gomega.HaveKeyWithValue("http.request.method", http.MethodGet),
+		"RequestLogger should keep attributes in the allow list")
+	g.Expect(logged).To(gomega.HaveKeyWithValue("url.domain", "REDACTED"),
+		"RequestLogger should redact attributes not in the allow list")
+	g.Expect(logged).To(gomega.HaveKeyWithValue("http.request.headers", map[string]any{
+		"Accept":        "application/json",
+		"Authorization": "REDACTED",
+	}), "RequestLogger should redact grouped attributes by key")
+	g.Expect(logged).To(gomega.HaveKeyWithValue("http.response.status_code", float64(http.StatusNoContent)),
+		"RequestLogger should keep numbers")
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var output bytes.Buffer
+
+	logger := slog.New(chimw.NewHandler(slog.NewJSONHandler(&output, nil), rere.NewDenyListPolicy([]string{"password",
+		"token"}))).With(slog.String("token", "secret")).WithGroup("request")
+
+	logger.InfoContext(context.Background(), "login",
+		slog.Any("credentials", credentials{Username: "alice", Password: "secret"}),
+		slog.Int("attempt", 1),
+		slog.Any("error", errors.New("failed")),
+	)
+
+	var logged map[string]any
+
+	g.Expect(json.Unmarshal(output.Bytes(), &logged)).To(gomega.Succeed(), "Handler should log JSON")
+	g.Expect(logged).To(gomega.HaveKeyWithValue("token", "REDACTED"), "Handler should redact attributes added with With")
+	g.Expect(logged).To(gomega.HaveKeyWithValue("request", map[string]any{
+		"credentials": map[string]any{"Username": "alice", "Password": "REDACTED"},
+		"attempt":     float64(1),
+		"error":       "failed",
+	}), "Handler should redact attributes in groups")
+}
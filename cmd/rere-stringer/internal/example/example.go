@@ -0,0 +1,25 @@
+// Package example holds types annotated for rere-stringer, so tests can check the generated methods are up to date and
+// redact.
+package example
+
+//go:generate go run github.com/dustinspecker/rere/cmd/rere-stringer
+
+// User is printed with Username, Role, and the ID of its sessions while every other string field is redacted.
+//
+//rere:stringer allow=Username,Role,ID
+type User struct {
+	Username string
+	Password string
+	Role     string `rere:"redact"`
+	Session  Session
+	Previous *Session
+}
+
+// Session is printed with Expiry while every other string field is redacted.
+//
+//rere:stringer
+type Session struct {
+	ID     string
+	Token  string
+	Expiry string `rere:"keep"`
+}
@@ -0,0 +1,389 @@
+// Command rere-stringer generates String and LogValue methods printing the redacted representation of struct types, so
+// even direct fmt.Println(user) and slog calls are safe for generated types.
+//
+// Annotate a struct type with a rere:stringer comment and run rere-stringer from go generate:
+//
+//	//go:generate go run github.com/dustinspecker/rere/cmd/rere-stringer
+//
+//	//rere:stringer allow=Username,Email
+//	type User struct {
+//		Username string
+//		Email    string
+//		Password string
+//	}
+//
+// The generated methods redact the value with rere.RedactWithAllowList and the names after allow=, so every string and
+// []byte field is redacted unless it is in the allow list or tagged with `rere:"keep"`. Annotated types nested in an
+// annotated value are redacted once, with the allow list of the outermost value. rere-stringer writes the
+// methods of every annotated type in the package directory to rere_stringer.go. The output path can be changed with
+// -output.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	annotation      = "//rere:stringer"
+	allowListPrefix = "allow="
+	defaultOutput   = "rere_stringer.go"
+)
+
+var (
+	errNoAnnotatedTypes   = errors.New("no types are annotated with " + annotation)
+	errNotStruct          = errors.New("annotated type is not a struct type")
+	errGenericType        = errors.New("annotated type has type parameters")
+	errInvalidAnnotation  = errors.New("annotation is invalid")
+	errMultiplePackages   = errors.New("directory holds multiple packages")
+	errNoPackageGoFiles   = errors.New("directory holds no Go files")
+	errUnexpectedArgument = errors.New("expected at most one directory argument")
+)
+
+// annotatedType is a struct type annotated with a rere:stringer comment.
+type annotatedType struct {
+	name      string
+	allowList []string
+}
+
+func main() {
+	output := flag.String("output", defaultOutput, "file name to write generated methods to, relative to the directory")
+	flag.Parse()
+
+	if err := run(flag.Args(), *output); err != nil {
+		fmt.Fprintln(os.Stderr, "rere-stringer:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, output string) error {
+	directory := "."
+
+	switch len(args) {
+	case 0:
+	case 1:
+		directory = args[0]
+	default:
+		return errUnexpectedArgument
+	}
+
+	outputPath := filepath.Join(directory, output)
+
+	packageName, types, err := parseDirectory(directory, outputPath)
+	if err != nil {
+		return err
+	}
+
+	source, err := generate(packageName, types)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec,mnd // generated source files are world readable like any other source file
+	if err := os.WriteFile(outputPath, source, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	return nil
+}
+
+// parseDirectory returns the package name and annotated types of the non-test Go files in directory, skipping the
+// previously generated outputPath.
+func parseDirectory(directory, outputPath string) (string, []annotatedType, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	fileSet := token.NewFileSet()
+	packageName := ""
+
+	var types []annotatedType
+
+	for _, entry := range entries {
+		filePath := filepath.Join(directory, entry.Name())
+
+		if entry.IsDir() || filepath.Ext(filePath) != ".go" || strings.HasSuffix(filePath, "_test.go") ||
+			filepath.Clean(filePath) == filepath.Clean(outputPath) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fileSet, filePath, nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse file: %w", err)
+		}
+
+		if packageName != "" && packageName != file.Name.Name {
+			return "", nil, fmt.Errorf("%w: %s and %s", errMultiplePackages, packageName, file.Name.Name)
+		}
+
+		packageName = file.Name.Name
+
+		fileTypes, err := findAnnotatedTypes(file)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+
+		types = append(types, fileTypes...)
+	}
+
+	if packageName == "" {
+		return "", nil, errNoPackageGoFiles
+	}
+
+	return packageName, types, nil
+}
+
+// findAnnotatedTypes returns the types of file annotated with a rere:stringer comment.
+func findAnnotatedTypes(file *ast.File) ([]annotatedType, error) {
+	var types []annotatedType
+
+	for _, declaration := range file.Decls {
+		genDecl, ok := declaration.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			//nolint:forcetypeassert // type declarations only hold type specs
+			typeSpec := spec.(*ast.TypeSpec)
+
+			// a single type declaration documents its type with the declaration's comment
+			doc := typeSpec.Doc
+			if doc == nil && len(genDecl.Specs) == 1 {
+				doc = genDecl.Doc
+			}
+
+			allowList, annotated, err := parseAnnotation(doc)
+			if err != nil {
+				return nil, fmt.Errorf("type %s: %w", typeSpec.Name.Name, err)
+			}
+
+			if !annotated {
+				continue
+			}
+
+			if _, isStruct := typeSpec.Type.(*ast.StructType); !isStruct {
+				return nil, fmt.Errorf("%w: %s", errNotStruct, typeSpec.Name.Name)
+			}
+
+			if typeSpec.TypeParams != nil {
+				return nil, fmt.Errorf("%w: %s", errGenericType, typeSpec.Name.Name)
+			}
+
+			types = append(types, annotatedType{name: typeSpec.Name.Name, allowList: allowList})
+		}
+	}
+
+	return types, nil
+}
+
+// parseAnnotation returns the allow list of a rere:stringer comment in doc and whether doc holds one.
+func parseAnnotation(doc *ast.CommentGroup) ([]string, bool, error) {
+	if doc == nil {
+		return nil, false, nil
+	}
+
+	for _, comment := range doc.List {
+		arguments, found := strings.CutPrefix(comment.Text, annotation)
+		if !found || (arguments != "" && !strings.HasPrefix(arguments, " ")) {
+			continue
+		}
+
+		arguments = strings.TrimSpace(arguments)
+		if arguments == "" {
+			return nil, true, nil
+		}
+
+		names, found := strings.CutPrefix(arguments, allowListPrefix)
+		if !found || names == "" {
+			return nil, false, fmt.Errorf("%w: %q", errInvalidAnnotation, comment.Text)
+		}
+
+		return strings.Split(names, ","), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// generate returns the formatted source of String and LogValue methods for types in packageName.
+func generate(packageName string, types []annotatedType) ([]byte, error) {
+	if len(types) == 0 {
+		return nil, errNoAnnotatedTypes
+	}
+
+	var source bytes.Buffer
+
+	fmt.Fprintf(&source, "// Code generated by rere-stringer; DO NOT EDIT.\n\npackage %s\n\n", packageName)
+	source.WriteString("import (\n\"fmt\"\n\"log/slog\"\n\"reflect\"\n\"sort\"\n\"strings\"\n\n" +
+		"\"github.com/dustinspecker/rere\"\n)\n")
+
+	for _, annotated := range types {
+		receiver := receiverName(annotated.name)
+		allowList := "nil"
+
+		if len(annotated.allowList) != 0 {
+			quoted := make([]string, 0, len(annotated.allowList))
+			for _, name := range annotated.allowList {
+				quoted = append(quoted, strconv.Quote(strings.TrimSpace(name)))
+			}
+
+			allowList = "[]string{" + strings.Join(quoted, ", ") + "}"
+		}
+
+		// the redacted value is formatted field by field and converted to a type without methods for slog, so neither
+		// calls String or LogValue of the value or of annotated values nested in it, which would redact them again
+		fmt.Fprintf(&source, `
+// String returns the redacted representation of %[2]s, so printing it doesn't leak sensitive fields.
+func (%[2]s %[1]s) String() string {
+	return rereStringerFormat(reflect.ValueOf(rere.RedactWithAllowList(%[2]s, %[3]s)))
+}
+
+// LogValue returns the redacted representation of %[2]s, so logging it with log/slog doesn't leak sensitive fields.
+func (%[2]s %[1]s) LogValue() slog.Value {
+	type plain %[1]s
+
+	return slog.AnyValue(plain(rere.RedactWithAllowList(%[2]s, %[3]s)))
+}
+`, annotated.name, receiver, allowList)
+	}
+
+	source.WriteString(`
+// rereStringerTypes are the types with generated String methods. Values of these types nested in a redacted value are
+// formatted field by field instead of with their String method, so they aren't redacted again.
+var rereStringerTypes = map[reflect.Type]bool{
+`)
+
+	for _, annotated := range types {
+		fmt.Fprintf(&source, "reflect.TypeOf(%s{}): true,\n", annotated.name)
+	}
+
+	source.WriteString("}\n")
+	source.WriteString(formatHelpers)
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated source: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// formatHelpers is the source of the functions generated String methods format redacted values with.
+const formatHelpers = `
+// rereStringerFormat formats value like the %+v verb of fmt, except values of rereStringerTypes are formatted field by
+// field. Map entries are sorted by their formatted representation.
+func rereStringerFormat(value reflect.Value) string {
+	if !value.IsValid() {
+		return "<nil>"
+	}
+
+	if !rereStringerHolds(value.Type(), map[reflect.Type]bool{}) || rereStringerHasMethods(value) {
+		return fmt.Sprintf("%+v", value)
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return "<nil>"
+		}
+
+		return "&" + rereStringerFormat(value.Elem())
+	case reflect.Interface:
+		if value.IsNil() {
+			return "<nil>"
+		}
+
+		return rereStringerFormat(value.Elem())
+	case reflect.Struct:
+		fields := make([]string, 0, value.NumField())
+		for index := 0; index < value.NumField(); index++ {
+			fields = append(fields, value.Type().Field(index).Name+":"+rereStringerFormat(value.Field(index)))
+		}
+
+		return "{" + strings.Join(fields, " ") + "}"
+	case reflect.Slice, reflect.Array:
+		elements := make([]string, 0, value.Len())
+		for index := 0; index < value.Len(); index++ {
+			elements = append(elements, rereStringerFormat(value.Index(index)))
+		}
+
+		return "[" + strings.Join(elements, " ") + "]"
+	case reflect.Map:
+		entries := make([]string, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			entries = append(entries, rereStringerFormat(key)+":"+rereStringerFormat(value.MapIndex(key)))
+		}
+
+		sort.Strings(entries)
+
+		return "map[" + strings.Join(entries, " ") + "]"
+	default:
+		return fmt.Sprintf("%+v", value)
+	}
+}
+
+// rereStringerHolds reports whether values of valueType can hold values of rereStringerTypes. seen holds the types
+// already visited, so recursive types terminate.
+func rereStringerHolds(valueType reflect.Type, seen map[reflect.Type]bool) bool {
+	if rereStringerTypes[valueType] || valueType.Kind() == reflect.Interface {
+		return true
+	}
+
+	if seen[valueType] {
+		return false
+	}
+
+	seen[valueType] = true
+
+	switch valueType.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return rereStringerHolds(valueType.Elem(), seen)
+	case reflect.Map:
+		return rereStringerHolds(valueType.Key(), seen) || rereStringerHolds(valueType.Elem(), seen)
+	case reflect.Struct:
+		for index := 0; index < valueType.NumField(); index++ {
+			if rereStringerHolds(valueType.Field(index).Type, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// rereStringerHasMethods reports whether fmt formats value with its own methods, such as String, other than the
+// generated ones.
+func rereStringerHasMethods(value reflect.Value) bool {
+	if !value.CanInterface() || value.Kind() == reflect.Interface || rereStringerTypes[value.Type()] ||
+		(value.Kind() == reflect.Pointer && rereStringerTypes[value.Type().Elem()]) {
+		return false
+	}
+
+	switch value.Interface().(type) {
+	case fmt.Formatter, fmt.Stringer, error:
+		return true
+	default:
+		return false
+	}
+}
+`
+
+// receiverName returns the lowercased first letter of typeName, such as "u" for User.
+func receiverName(typeName string) string {
+	first, _ := utf8.DecodeRuneInString(typeName)
+
+	return string(unicode.ToLower(first))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dustinspecker/rere/cmd/rere-stringer/internal/example"
+	"github.com/onsi/gomega"
+)
+
+func TestGeneratedExampleIsUpToDate(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	directory := filepath.Join("internal", "example")
+
+	packageName, types, err := parseDirectory(directory, filepath.Join(directory, defaultOutput))
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "parseDirectory should parse the example package")
+
+	source, err := generate(packageName, types)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "generate should generate methods for the example package")
+
+	existing, err := os.ReadFile(filepath.Join(directory, defaultOutput))
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "the example package should hold generated methods")
+
+	g.Expect(string(source)).To(gomega.Equal(string(existing)), "run go generate ./... to update the example package")
+}
+
+func TestGeneratedMethodsRedact(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	user := example.User{
+		Username: "dustin",
+		Password: "hunter2",
+		Role:     "admin",
+		Session:  example.Session{ID: "s-2", Token: "abc123", Expiry: "2026-10-18"},
+		Previous: &example.Session{ID: "s-1", Token: "def456", Expiry: "2026-10-17"},
+	}
+
+	g.Expect(fmt.Sprint(user)).To(gomega.Equal(
+		"{Username:dustin Password:REDACTED Role:REDACTED Session:{ID:s-2 Token:REDACTED Expiry:2026-10-18} "+
+			"Previous:&{ID:s-1 Token:REDACTED Expiry:2026-10-17}}",
+	), "String should print nested sessions redacted with the allow list of User only")
+	g.Expect(fmt.Sprint(user.Session)).To(gomega.Equal("{ID:REDACTED Token:REDACTED Expiry:2026-10-18}"),
+		"String should print sessions redacted with the allow list of Session")
+
+	var output bytes.Buffer
+
+	slog.New(slog.NewJSONHandler(&output, nil)).Info("login", "user", user)
+
+	g.Expect(output.String()).To(gomega.ContainSubstring(
+		`"user":{"Username":"dustin","Password":"REDACTED","Role":"REDACTED",`+
+			`"Session":{"ID":"s-2","Token":"REDACTED","Expiry":"2026-10-18"},`+
+			`"Previous":{"ID":"s-1","Token":"REDACTED","Expiry":"2026-10-17"}}`,
+	), "LogValue should log the redacted representation")
+
+	g.Expect(user.Password).To(gomega.Equal("hunter2"), "generated methods should not modify the value")
+}
+
+func TestRunRejectsInvalidAnnotations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		source string
+		err    error
+	}{
+		{
+			name:   "no annotated types",
+			source: "package invalid\n\ntype User struct{}\n",
+			err:    errNoAnnotatedTypes,
+		},
+		{
+			name:   "annotated type is not a struct",
+			source: "package invalid\n\n//rere:stringer\ntype Token string\n",
+			err:    errNotStruct,
+		},
+		{
+			name:   "annotated type has type parameters",
+			source: "package invalid\n\n//rere:stringer\ntype Box[T any] struct{ Value T }\n",
+			err:    errGenericType,
+		},
+		{
+			name:   "annotation has unknown arguments",
+			source: "package invalid\n\n//rere:stringer deny=Password\ntype User struct{}\n",
+			err:    errInvalidAnnotation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			directory := t.TempDir()
+
+			err := os.WriteFile(filepath.Join(directory, "invalid.go"), []byte(testCase.source), 0o600)
+			g.Expect(err).NotTo(gomega.HaveOccurred(), "writing the source file should succeed")
+
+			err = run([]string{directory}, defaultOutput)
+			g.Expect(err).To(gomega.MatchError(testCase.err), "run should reject the package")
+
+			g.Expect(filepath.Join(directory, defaultOutput)).NotTo(gomega.BeAnExistingFile(),
+				"run should not write generated methods")
+		})
+	}
+}
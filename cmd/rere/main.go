@@ -0,0 +1,315 @@
+// Command rere scrubs secrets from artifacts, such as Go templates, SQL migrations, and logs, before they're attached
+// to tickets or shared outside of a team.
+//
+// rere scan walks a directory and applies text detectors to every file matching the -include globs, reporting each
+// finding as path:line:column: detector without printing the secret itself:
+//
+//	rere scan -include '*.tmpl,*.sql' -detectors secrets,entropy ./migrations
+//
+// rere scan exits with status 1 when it reports findings, so it can be used as a check. With -write, rere scan instead
+// rewrites matching files in place, replacing every finding with "REDACTED".
+//
+// Globs without a slash match file names, such as *.sql, while globs with a slash match paths relative to the
+// directory, such as migrations/*.sql. Directories named .git are skipped.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dustinspecker/rere"
+)
+
+const (
+	scanCommand      = "scan"
+	defaultIncludes  = "*.tmpl,*.gotmpl,*.sql"
+	defaultDetectors = "secrets"
+	// entropyMinLength and entropyMinBits are the EntropyDetector thresholds used by the entropy detector.
+	entropyMinLength = 20
+	entropyMinBits   = 4
+)
+
+var (
+	errFindings        = errors.New("found secrets")
+	errInvalidGlob     = errors.New("include glob is invalid")
+	errMissingCommand  = errors.New("expected a command, such as " + scanCommand)
+	errNoDetectors     = errors.New("expected at least one detector")
+	errNoIncludes      = errors.New("expected at least one include glob")
+	errUnexpectedArg   = errors.New("expected at most one directory argument")
+	errUnknownCommand  = errors.New("unknown command")
+	errUnknownDetector = errors.New("unknown detector")
+)
+
+// detectors are the detectors selectable with -detectors by name.
+//
+//nolint:gochecknoglobals // detectors are looked up by name for every scan
+var detectors = map[string]func() rere.Detector{
+	"access-log": rere.AccessLogDetector,
+	"entropy": func() rere.Detector {
+		return rere.EntropyDetector(entropyMinLength, entropyMinBits)
+	},
+	"query-string": func() rere.Detector {
+		return rere.QueryStringDetector()
+	},
+	"secrets":     rere.SecretFormatDetector,
+	"stack-trace": rere.GoStackTraceDetector,
+}
+
+// namedDetector is a detector selected with -detectors.
+type namedDetector struct {
+	name     string
+	detector rere.Detector
+}
+
+// finding is a match of a detector in a scanned file.
+type finding struct {
+	offset   int
+	detector string
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, errFindings):
+		os.Exit(1)
+	default:
+		fmt.Fprintln(os.Stderr, "rere:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errMissingCommand
+	}
+
+	if args[0] != scanCommand {
+		return fmt.Errorf("%w: %s", errUnknownCommand, args[0])
+	}
+
+	flags := flag.NewFlagSet(scanCommand, flag.ContinueOnError)
+	includes := flags.String("include", defaultIncludes, "comma-separated globs of files to scan")
+	detectorNames := flags.String("detectors", defaultDetectors,
+		"comma-separated detectors to apply: "+strings.Join(slices.Sorted(maps.Keys(detectors)), ", "))
+	write := flags.Bool("write", false, "rewrite files in place, replacing findings with REDACTED, instead of reporting")
+
+	if err := flags.Parse(args[1:]); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	directory := "."
+
+	switch flags.NArg() {
+	case 0:
+	case 1:
+		directory = flags.Arg(0)
+	default:
+		return errUnexpectedArg
+	}
+
+	globs, err := parseGlobs(*includes)
+	if err != nil {
+		return err
+	}
+
+	selected, err := parseDetectors(*detectorNames)
+	if err != nil {
+		return err
+	}
+
+	return scan(directory, globs, selected, *write, stdout)
+}
+
+// parseGlobs returns the globs of the comma-separated includes.
+func parseGlobs(includes string) ([]string, error) {
+	var globs []string
+
+	for _, glob := range strings.Split(includes, ",") {
+		glob = strings.TrimSpace(glob)
+		if glob == "" {
+			continue
+		}
+
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q", errInvalidGlob, glob)
+		}
+
+		globs = append(globs, glob)
+	}
+
+	if len(globs) == 0 {
+		return nil, errNoIncludes
+	}
+
+	return globs, nil
+}
+
+// parseDetectors returns the detectors of the comma-separated names.
+func parseDetectors(names string) ([]namedDetector, error) {
+	var selected []namedDetector
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		newDetector, found := detectors[name]
+		if !found {
+			return nil, fmt.Errorf("%w: %s", errUnknownDetector, name)
+		}
+
+		selected = append(selected, namedDetector{name: name, detector: newDetector()})
+	}
+
+	if len(selected) == 0 {
+		return nil, errNoDetectors
+	}
+
+	return selected, nil
+}
+
+// scan applies selected to every file in directory matching globs, reporting findings to stdout or rewriting files
+// when write is true. scan returns errFindings when it reports findings.
+func scan(directory string, globs []string, selected []namedDetector, write bool, stdout io.Writer) error {
+	found := false
+
+	err := filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", filePath, err)
+		}
+
+		if !entry.Type().IsRegular() || !matchesGlobs(filepath.ToSlash(relativePath), globs) {
+			return nil
+		}
+
+		fileFound, err := scanFile(filePath, selected, write, stdout)
+		found = found || fileFound
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", directory, err)
+	}
+
+	if found && !write {
+		return errFindings
+	}
+
+	return nil
+}
+
+// matchesGlobs reports whether the slash-separated relativePath matches any of globs. Globs without a slash match the
+// file name, and globs with a slash match the whole path.
+func matchesGlobs(relativePath string, globs []string) bool {
+	for _, glob := range globs {
+		name := relativePath
+		if !strings.Contains(glob, "/") {
+			name = path.Base(relativePath)
+		}
+
+		// globs are validated by parseGlobs
+		if matched, _ := path.Match(glob, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scanFile applies selected to the file at filePath, reporting its findings to stdout or rewriting it when write is
+// true, and reports whether it holds findings.
+func scanFile(filePath string, selected []namedDetector, write bool, stdout io.Writer) (bool, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	text := string(content)
+	findings := findSecrets(text, selected)
+
+	if len(findings) == 0 {
+		return false, nil
+	}
+
+	if !write {
+		for _, finding := range findings {
+			line, column := position(content, finding.offset)
+			fmt.Fprintf(stdout, "%s:%d:%d: %s\n", filePath, line, column, finding.detector)
+		}
+
+		return true, nil
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", filePath, err)
+	}
+
+	textDetectors := make([]rere.Detector, 0, len(selected))
+	for _, named := range selected {
+		textDetectors = append(textDetectors, named.detector)
+	}
+
+	if err := os.WriteFile(filePath, []byte(rere.RedactText(text, textDetectors...)), info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+
+	fmt.Fprintf(stdout, "%s: findings redacted: %d\n", filePath, len(findings))
+
+	return true, nil
+}
+
+// findSecrets returns the non-empty matches of selected in text sorted by offset.
+func findSecrets(text string, selected []namedDetector) []finding {
+	var findings []finding
+
+	for _, named := range selected {
+		for _, match := range named.detector.Detect(text) {
+			if match[0] < match[1] {
+				findings = append(findings, finding{offset: match[0], detector: named.name})
+			}
+		}
+	}
+
+	slices.SortStableFunc(findings, func(first, second finding) int {
+		return first.offset - second.offset
+	})
+
+	return findings
+}
+
+// position returns the 1-based line and byte column of offset in content.
+func position(content []byte, offset int) (int, int) {
+	before := content[:offset]
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+
+	return bytes.Count(before, []byte("\n")) + 1, offset - lineStart + 1
+}
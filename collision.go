@@ -0,0 +1,142 @@
+package rere
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"reflect"
+	"strings"
+)
+
+const (
+	nonceSeparator = "#"
+	nonceLength    = 4
+)
+
+// WithCollisionGuard appends a random nonce to every placeholder of a call, such as "REDACTED#3f9a1c2e", when a string
+// or byte slice in the provided value already contains a placeholder, such as a user whose name is "REDACTED". This
+// lets downstream consumers reliably tell redacted values from real data. The placeholder from WithPlaceholder and
+// placeholders provided to WithFieldPlaceholders and WithPathPlaceholders are checked case insensitively. The nonce is
+// chosen so it doesn't appear in the provided value either, and WithReport records the placeholders including the
+// nonce.
+//
+// Values are scanned before they are redacted, so WithCollisionGuard doubles the cost of redaction.
+func WithCollisionGuard() Option {
+	return func(o *options) {
+		o.collisionGuard = true
+	}
+}
+
+// guardCollisions chooses the nonce appended to placeholders when a string or byte slice in value contains a
+// placeholder. See WithCollisionGuard.
+func (o *options) guardCollisions(value reflect.Value) {
+	o.placeholderNonce = ""
+
+	if !o.collisionGuard || !containsText(value, o.placeholderMarkers()) {
+		return
+	}
+
+	for {
+		nonce := nonceSeparator + newNonce()
+
+		if !containsText(value, []string{nonce}) {
+			o.placeholderNonce = nonce
+
+			return
+		}
+	}
+}
+
+// placeholderMarkers returns the lowercased text of every placeholder a call can use, ignoring parts such as length
+// hints and correlation suffixes, since every placeholder contains its marker.
+func (o *options) placeholderMarkers() []string {
+	markers := []string{strings.ToLower(o.placeholderMessage)}
+
+	for _, override := range o.fieldPlaceholders {
+		if override.placeholder != "" {
+			markers = append(markers, strings.ToLower(override.placeholder))
+		}
+	}
+
+	for _, overrides := range o.pathPlaceholders {
+		for _, placeholder := range overrides.placeholders {
+			if placeholder != "" {
+				markers = append(markers, strings.ToLower(placeholder))
+			}
+		}
+	}
+
+	return markers
+}
+
+func newNonce() string {
+	nonce := make([]byte, nonceLength)
+
+	// crypto/rand.Read never returns an error on supported platforms
+	_, _ = rand.Read(nonce)
+
+	return hex.EncodeToString(nonce)
+}
+
+// containsText reports whether a string or byte slice in value, or a map key, contains any of the lowercased markers.
+//
+//nolint:exhaustive // other kinds can't hold text
+func containsText(value reflect.Value, markers []string) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return containsMarker(value.String(), markers)
+	case reflect.Array, reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return containsMarker(string(byteValues(value)), markers)
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			if containsText(value.Index(i), markers) {
+				return true
+			}
+		}
+	case reflect.Interface, reflect.Pointer:
+		return !value.IsNil() && containsText(value.Elem(), markers)
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			if containsText(iter.Key(), markers) || containsText(iter.Value(), markers) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for fieldIndex := 0; fieldIndex < value.NumField(); fieldIndex++ {
+			if containsText(value.Field(fieldIndex), markers) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// byteValues returns the bytes of a byte slice or byte array value. Byte arrays held by maps and interfaces aren't
+// addressable, so their bytes are read one at a time.
+func byteValues(value reflect.Value) []byte {
+	if value.Kind() == reflect.Slice || value.CanAddr() {
+		return value.Bytes()
+	}
+
+	text := make([]byte, value.Len())
+	for i := range text {
+		text[i] = byte(value.Index(i).Uint())
+	}
+
+	return text
+}
+
+func containsMarker(text string, markers []string) bool {
+	lowered := strings.ToLower(text)
+
+	for _, marker := range markers {
+		if strings.Contains(lowered, marker) {
+			return true
+		}
+	}
+
+	return false
+}
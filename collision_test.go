@@ -0,0 +1,80 @@
+package rere_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactWithCollisionGuard(t *testing.T) {
+	t.Parallel()
+
+	nonced := regexp.MustCompile(`^REDACTED#[0-9a-f]{8}$`)
+
+	t.Run("appends a nonce when the value contains a placeholder", func(t *testing.T) {
+		t.Parallel()
+		g := gomega.NewWithT(t)
+
+		user := reportUser{Username: "redacted", Password: "hunter2", Key: []byte("secret")}
+
+		redactedUser := rere.RedactWithAllowList(user, []string{"username"}, rere.WithCollisionGuard())
+
+		g.Expect(redactedUser.Username).To(gomega.Equal("redacted"), "allowed values should be kept")
+		g.Expect(redactedUser.Password).To(gomega.MatchRegexp(nonced.String()), "placeholders should have a nonce")
+		g.Expect(string(redactedUser.Key)).To(gomega.Equal(redactedUser.Password),
+			"every placeholder of a call should have the same nonce")
+	})
+
+	t.Run("appends a nonce when a byte array in a map contains a placeholder", func(t *testing.T) {
+		t.Parallel()
+		g := gomega.NewWithT(t)
+
+		values := map[string]any{"id": [8]byte([]byte(redacted)), "token": "abc123"}
+
+		redactedValues := rere.RedactWithAllowList(values, []string{"id"}, rere.WithCollisionGuard())
+
+		g.Expect(redactedValues["token"]).To(gomega.MatchRegexp(nonced.String()), "placeholders should have a nonce")
+	})
+
+	t.Run("appends a nonce when the value contains a field placeholder", func(t *testing.T) {
+		t.Parallel()
+		g := gomega.NewWithT(t)
+
+		values := map[string]string{"password": "********", "token": "abc123"}
+
+		redactedValues := rere.RedactStringMap(values, rere.WithCollisionGuard(),
+			rere.WithFieldPlaceholders(map[string]string{"password": "********"}))
+
+		g.Expect(redactedValues["password"]).To(gomega.MatchRegexp(`^\*{8}#[0-9a-f]{8}$`),
+			"field placeholders should have a nonce")
+		g.Expect(redactedValues["token"]).To(gomega.MatchRegexp(nonced.String()), "placeholders should have a nonce")
+	})
+
+	t.Run("keeps placeholders when the value doesn't contain a placeholder", func(t *testing.T) {
+		t.Parallel()
+		g := gomega.NewWithT(t)
+
+		user := reportUser{Username: "dustin", Password: "hunter2", Key: nil}
+
+		redactedUser := rere.RedactWithAllowList(user, []string{"username"}, rere.WithCollisionGuard())
+
+		g.Expect(redactedUser.Password).To(gomega.Equal(redacted), "placeholders should not have a nonce")
+	})
+
+	t.Run("records placeholders with the nonce", func(t *testing.T) {
+		t.Parallel()
+		g := gomega.NewWithT(t)
+
+		var report rere.Report
+
+		redactedValues := rere.RedactStringSlice([]string{"REDACTED", "hunter2"}, rere.WithCollisionGuard(),
+			rere.WithReport(&report))
+
+		g.Expect(redactedValues[1]).To(gomega.MatchRegexp(nonced.String()), "placeholders should have a nonce")
+		g.Expect(report.Redactions).To(gomega.HaveLen(2), "every redaction should be recorded")
+		g.Expect(report.Redactions[1].Value).To(gomega.Equal(redactedValues[1]),
+			"the report should record the placeholder with the nonce")
+	})
+}
@@ -0,0 +1,118 @@
+package rere
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// copyValue creates a deep copy of value, so the original value is not modified when redacting.
+func copyValue[T any](value T, redactOptions *options) T {
+	var deepCopy T
+
+	reflect.ValueOf(&deepCopy).Elem().Set(deepCopyValue(reflect.ValueOf(&value).Elem(), redactOptions))
+
+	return deepCopy
+}
+
+// deepCopyValue recursively copies original. Func and chan values cannot be meaningfully copied, so they are carried
+// over by reference unless redactOptions requests they are set to nil.
+//
+//nolint:exhaustive // every other kind is copied by value in the default case
+func deepCopyValue(original reflect.Value, redactOptions *options) reflect.Value {
+	switch original.Kind() {
+	case reflect.Array:
+		deepCopy := reflect.New(original.Type()).Elem()
+		for i := 0; i < original.Len(); i++ {
+			deepCopy.Index(i).Set(deepCopyValue(original.Index(i), redactOptions))
+		}
+
+		return deepCopy
+	case reflect.Chan, reflect.Func:
+		if redactOptions.nilFuncsAndChans {
+			return reflect.Zero(original.Type())
+		}
+
+		return copyByValue(original)
+	case reflect.Interface:
+		if original.IsNil() {
+			return copyByValue(original)
+		}
+
+		deepCopy := reflect.New(original.Type()).Elem()
+		deepCopy.Set(deepCopyValue(original.Elem(), redactOptions))
+
+		return deepCopy
+	case reflect.Map:
+		if original.IsNil() {
+			return copyByValue(original)
+		}
+
+		deepCopy := reflect.MakeMapWithSize(original.Type(), original.Len())
+
+		iter := original.MapRange()
+		for iter.Next() {
+			deepCopy.SetMapIndex(iter.Key(), deepCopyValue(iter.Value(), redactOptions))
+		}
+
+		return deepCopy
+	case reflect.Pointer:
+		if original.IsNil() {
+			return copyByValue(original)
+		}
+
+		deepCopy := reflect.New(original.Type().Elem())
+		deepCopy.Elem().Set(deepCopyValue(original.Elem(), redactOptions))
+
+		return deepCopy
+	case reflect.Slice:
+		if original.IsNil() {
+			return copyByValue(original)
+		}
+
+		// the capacity of the copy is its length, so bytes of the original beyond its length, such as secrets left in
+		// the backing array of a re-sliced []byte, are never carried over
+		deepCopy := reflect.MakeSlice(original.Type(), original.Len(), original.Len())
+		for i := 0; i < original.Len(); i++ {
+			deepCopy.Index(i).Set(deepCopyValue(original.Index(i), redactOptions))
+		}
+
+		return deepCopy
+	case reflect.Struct:
+		if original.Type() == syncMapType {
+			return copySyncMap(original, redactOptions)
+		}
+
+		if isAtomic(original.Type()) {
+			return copyAtomic(original, redactOptions)
+		}
+
+		deepCopy := reflect.New(original.Type()).Elem()
+		deepCopy.Set(original)
+
+		for fieldIndex := 0; fieldIndex < deepCopy.NumField(); fieldIndex++ {
+			field := deepCopy.Field(fieldIndex)
+
+			// use reflect.NewAt to handle copying unexported fields
+			field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+
+			// fields of addressable originals are copied from the original, so values like sync.Map are read through a
+			// pointer to the original instead of through the copy made above
+			source := field
+			if original.CanAddr() {
+				originalField := original.Field(fieldIndex)
+				source = reflect.NewAt(originalField.Type(), unsafe.Pointer(originalField.UnsafeAddr())).Elem()
+			}
+
+			field.Set(deepCopyValue(source, redactOptions))
+		}
+
+		return deepCopy
+	default:
+		return copyByValue(original)
+	}
+}
+
+// copyByValue returns original as-is, since callers copy the returned value with reflect.Value.Set.
+func copyByValue(original reflect.Value) reflect.Value {
+	return original
+}
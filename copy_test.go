@@ -0,0 +1,146 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type structWithFuncAndChan struct {
+	Password    string
+	Callback    func() string
+	callback    func() string
+	Events      chan string
+	events      chan string
+	Receive     <-chan string
+	NilCallback func() string
+	NilEvents   chan string
+}
+
+func TestRedactCarriesFuncAndChanByReference(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	events := make(chan string, 1)
+	input := structWithFuncAndChan{
+		Password:    "password",
+		Callback:    func() string { return "callback" },
+		callback:    func() string { return "callback" },
+		Events:      events,
+		events:      events,
+		Receive:     events,
+		NilCallback: nil,
+		NilEvents:   nil,
+	}
+
+	redactedInput := rere.RedactWithAllowList(input, nil)
+
+	g.Expect(redactedInput.Password).To(gomega.Equal(redacted), "RedactWithAllowList should redact string fields")
+	g.Expect(redactedInput.Callback()).To(gomega.Equal("callback"), "RedactWithAllowList should keep func fields")
+	g.Expect(redactedInput.callback()).To(gomega.Equal("callback"), "RedactWithAllowList should keep unexported func fields")
+	g.Expect(redactedInput.Events).To(gomega.BeIdenticalTo(events), "RedactWithAllowList should keep chan fields by reference")
+	g.Expect(redactedInput.events).To(gomega.BeIdenticalTo(events), "RedactWithAllowList should keep unexported chan fields")
+	g.Expect(redactedInput.Receive).To(gomega.BeIdenticalTo(input.Receive), "RedactWithAllowList should keep receive-only chan fields")
+	g.Expect(redactedInput.NilCallback).To(gomega.BeNil(), "RedactWithAllowList should keep nil func fields as nil")
+	g.Expect(redactedInput.NilEvents).To(gomega.BeNil(), "RedactWithAllowList should keep nil chan fields as nil")
+	g.Expect(input.Password).To(gomega.Equal("password"), "RedactWithAllowList should not modify the provided input")
+}
+
+func TestRedactWithNilFuncsAndChans(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	events := make(chan string, 1)
+	input := &structWithFuncAndChan{
+		Password:    "password",
+		Callback:    func() string { return "callback" },
+		callback:    func() string { return "callback" },
+		Events:      events,
+		events:      events,
+		Receive:     events,
+		NilCallback: nil,
+		NilEvents:   nil,
+	}
+
+	redactedInput := rere.RedactWithDenyList(input, []string{"password"}, rere.WithNilFuncsAndChans())
+
+	g.Expect(redactedInput.Password).To(gomega.Equal(redacted), "RedactWithDenyList should redact denied fields")
+	g.Expect(redactedInput.Callback).To(gomega.BeNil(), "RedactWithDenyList should nil func fields")
+	g.Expect(redactedInput.callback).To(gomega.BeNil(), "RedactWithDenyList should nil unexported func fields")
+	g.Expect(redactedInput.Events).To(gomega.BeNil(), "RedactWithDenyList should nil chan fields")
+	g.Expect(redactedInput.events).To(gomega.BeNil(), "RedactWithDenyList should nil unexported chan fields")
+	g.Expect(redactedInput.Receive).To(gomega.BeNil(), "RedactWithDenyList should nil receive-only chan fields")
+	g.Expect(input.Callback).ToNot(gomega.BeNil(), "RedactWithDenyList should not modify the provided input")
+	g.Expect(input.Events).To(gomega.BeIdenticalTo(events), "RedactWithDenyList should not modify the provided input")
+}
+
+func TestRedactDeepCopiesInterfaceValues(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := map[string]any{
+		"nested": map[string]any{
+			"password": "password",
+		},
+	}
+
+	redactedInput := rere.RedactWithDenyList(input, []string{"password"})
+
+	g.Expect(redactedInput).To(gomega.Equal(map[string]any{
+		"nested": map[string]any{
+			"password": redacted,
+		},
+	}), "RedactWithDenyList should redact nested maps held by interfaces")
+	g.Expect(input).To(gomega.Equal(map[string]any{
+		"nested": map[string]any{
+			"password": "password",
+		},
+	}), "RedactWithDenyList should not modify maps held by interfaces in the provided input")
+}
+
+type structWithByteSlices struct {
+	Token   []byte
+	Public  []byte
+	Payload map[string][]byte
+	Values  []any
+}
+
+func TestRedactDoesNotCarryOverByteSliceCapacity(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	// each value is re-sliced from a backing array still holding a secret beyond its length
+	newValue := func(prefix string) []byte {
+		return []byte(prefix + ":hunter2")[:len(prefix)]
+	}
+
+	input := structWithByteSlices{
+		Token:   newValue("token"),
+		Public:  newValue("public"),
+		Payload: map[string][]byte{"public": newValue("public"), "token": newValue("token")},
+		Values:  []any{newValue("public")},
+	}
+
+	redactedInput := rere.RedactWithAllowList(input, []string{"Public", "Values"})
+
+	expectNoCapacity := func(value []byte, description string) {
+		g.Expect(cap(value)).To(gomega.Equal(len(value)), description+" should not have capacity beyond its length")
+		g.Expect(string(value[:cap(value)])).NotTo(gomega.ContainSubstring("hunter2"),
+			description+" should not expose bytes beyond its length")
+	}
+
+	g.Expect(redactedInput.Token).To(gomega.Equal([]byte(redacted)), "RedactWithAllowList should redact byte slices")
+	g.Expect(redactedInput.Public).To(gomega.Equal([]byte("public")), "RedactWithAllowList should keep allowed byte slices")
+	g.Expect(string(input.Public[:cap(input.Public)])).To(gomega.ContainSubstring("hunter2"),
+		"the provided input should hold a secret beyond its length")
+
+	expectNoCapacity(redactedInput.Token, "a redacted byte slice")
+	expectNoCapacity(redactedInput.Public, "a kept byte slice")
+	expectNoCapacity(redactedInput.Payload["public"], "a kept byte slice in a map")
+	expectNoCapacity(redactedInput.Payload["token"], "a redacted byte slice in a map")
+
+	value, isBytes := redactedInput.Values[0].([]byte)
+	g.Expect(isBytes).To(gomega.BeTrue(), "RedactWithAllowList should keep the dynamic type of interface values")
+	expectNoCapacity(value, "a kept byte slice in an interface")
+}
@@ -0,0 +1,48 @@
+package rere
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var errUncoveredFields = errors.New("fields are not covered by the policy")
+
+// CheckPolicyCoverage returns an error listing the paths of string and []byte fields of T that no rule of opts covers,
+// such as "Credentials.PrivateKey". A field is covered when a rule decides whether it is redacted: a rere struct tag,
+// a list entry from WithAllowList or WithDenyList, a path, a type, or a package. Uncovered fields fall back to the
+// default of the list mode, so a new field silently inherits a decision nobody made.
+//
+// CheckPolicyCoverage is intended for a unit test failing whenever T gains a field, forcing a conscious decision to
+// allow or redact it:
+//
+//	func TestUserPolicyCoverage(t *testing.T) {
+//		if err := rere.CheckPolicyCoverage[User](userPolicyOptions...); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+//
+// Fields are found the same as SuggestAllowList, and slice and array elements are checked at any index, such as
+// "Users[*].Password", so a path only covering a single index doesn't cover them.
+func CheckPolicyCoverage[T any](opts ...Option) error {
+	redactOptions := newOptions(allow, nil, opts)
+
+	var uncovered []string
+
+	walkType(rootLocation, reflect.TypeFor[T](), redactOptions, nil, func(leafLocation location) {
+		if leafLocation.name == "" {
+			return
+		}
+
+		if _, source := redactOptions.decide(leafLocation); source == 0 {
+			uncovered = append(uncovered, leafLocation.path.String())
+		}
+	})
+
+	if len(uncovered) != 0 {
+		return fmt.Errorf("%w: %s", errUncoveredFields, strings.Join(uncovered, ", "))
+	}
+
+	return nil
+}
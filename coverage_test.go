@@ -0,0 +1,69 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type coveredUser struct {
+	Username    string
+	Password    string `rere:"redact"`
+	Devices     []coveredDevice
+	Credentials credentialDetails
+}
+
+type coveredDevice struct {
+	Name  string
+	Token []byte
+}
+
+func TestCheckPolicyCoverage(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		opts []rere.Option
+		err  string
+	}{
+		{
+			name: "lists fields not covered by any rule",
+			opts: []rere.Option{rere.WithAllowList("username")},
+			err: "fields are not covered by the policy: Devices[*].Name, Devices[*].Token, Credentials.Name, " +
+				"Credentials.Secret",
+		},
+		{
+			name: "covers fields with lists, paths, tags, and types",
+			opts: []rere.Option{
+				rere.WithAllowList("username", "name"),
+				rere.WithRedactPaths("Devices[*].Token"),
+				rere.WithRedactTypes("rere_test.credentialDetails"),
+			},
+			err: "",
+		},
+		{
+			name: "does not cover elements with paths of a single index",
+			opts: []rere.Option{rere.WithDenyList("username", "name", "secret"), rere.WithRedactPaths("Devices[0]")},
+			err:  "fields are not covered by the policy: Devices[*].Token",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			err := rere.CheckPolicyCoverage[coveredUser](testCase.opts...)
+
+			if testCase.err == "" {
+				g.Expect(err).NotTo(gomega.HaveOccurred(), "CheckPolicyCoverage should accept covered fields")
+
+				return
+			}
+
+			g.Expect(err).To(gomega.MatchError(testCase.err), "CheckPolicyCoverage should report uncovered fields")
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package cuepolicy compiles redaction policies defined in CUE into a rere.Redactor, so policies and data schema
+// validation live in one artifact.
+package cuepolicy
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/dustinspecker/rere"
+)
+
+const (
+	// attributeName is the name of CUE attributes annotating the sensitivity of fields, such as @rere(redact).
+	attributeName = "rere"
+	// policyField is the top-level field holding the options of a policy, in the same form as rere.PolicyFile.
+	policyField = "policy"
+
+	keep   = "keep"
+	redact = "redact"
+)
+
+var (
+	errInvalidAnnotation     = errors.New(`annotation must be @rere(keep) or @rere(redact)`)
+	errConflictingAnnotation = errors.New("field is annotated with both @rere(keep) and @rere(redact)")
+	errUnknownDefinition     = errors.New("definition not found")
+)
+
+// Policy is a rere.Policy compiled from CUE, which can also validate values against the definitions of the CUE source.
+type Policy struct {
+	*rere.Policy
+
+	schema cue.Value
+}
+
+// Compile compiles CUE source defining data schemas with sensitivity annotations into a Policy. Fields annotated with
+// @rere(keep) are added to the allow list and fields annotated with @rere(redact) are added to the deny list, such as:
+//
+//	policy: {
+//		name: "users"
+//		mode: "allow"
+//	}
+//
+//	#User: {
+//		name:     string @rere(keep)
+//		password: string @rere(redact)
+//	}
+//
+// The optional top-level policy field holds the options of the policy in the same form as rere.PolicyFile, and its
+// mode defaults to "allow". Only annotations matching the mode add names to the list, since values are redacted by
+// default in allow mode and kept by default in deny mode. Annotations of every field are collected, including fields
+// of definitions, optional fields, list elements, and pattern constraints. Field names are matched case insensitively,
+// so the CUE field password matches the Go field Password.
+//
+// opts are applied after the options described by the policy field.
+func Compile(source []byte, opts ...rere.Option) (*Policy, error) {
+	schema := cuecontext.New().CompileBytes(source)
+	if err := schema.Err(); err != nil {
+		return nil, fmt.Errorf("failed to compile policy: %w", err)
+	}
+
+	policyFile := rere.PolicyFile{
+		Name:           "",
+		Version:        "",
+		Mode:           "allow",
+		List:           nil,
+		RedactPaths:    nil,
+		KeepPaths:      nil,
+		RedactTypes:    nil,
+		RedactPackages: nil,
+	}
+
+	if options := schema.LookupPath(cue.MakePath(cue.Str(policyField))); options.Exists() {
+		if err := options.Decode(&policyFile); err != nil {
+			return nil, fmt.Errorf("failed to decode policy options: %w", err)
+		}
+	}
+
+	annotations := map[string]string{}
+	if err := collectAnnotations(schema, true, annotations); err != nil {
+		return nil, err
+	}
+
+	annotated := keep
+	if policyFile.Mode == "deny" {
+		annotated = redact
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(annotations)) {
+		if annotations[name] == annotated {
+			policyFile.List = append(policyFile.List, name)
+		}
+	}
+
+	policy, err := policyFile.Policy(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy: %w", err)
+	}
+
+	return &Policy{
+		Policy: policy,
+		schema: schema,
+	}, nil
+}
+
+// Validate validates value against the CUE definition, such as "#User". value is encoded the same as encoding/json,
+// so struct fields are matched by their json tags or names.
+func (p *Policy) Validate(definition string, value any) error {
+	schema := p.schema.LookupPath(cue.ParsePath(definition))
+	if !schema.Exists() {
+		return fmt.Errorf("%w: %s", errUnknownDefinition, definition)
+	}
+
+	if err := schema.Unify(schema.Context().Encode(value)).Validate(cue.Concrete(true)); err != nil {
+		return fmt.Errorf("failed to validate value against %s: %w", definition, err)
+	}
+
+	return nil
+}
+
+// collectAnnotations records the annotation of every field nested inside of value into annotations, keyed by field
+// name. The policy field is skipped at the top level.
+func collectAnnotations(value cue.Value, topLevel bool, annotations map[string]string) error {
+	if value.IncompleteKind()&cue.StructKind == 0 {
+		return nil
+	}
+
+	fields, err := value.Fields(cue.Definitions(true), cue.Optional(true))
+	if err != nil {
+		return fmt.Errorf("failed to read fields: %w", err)
+	}
+
+	for fields.Next() {
+		selector := fields.Selector()
+
+		if topLevel && selector.String() == policyField {
+			continue
+		}
+
+		if !selector.IsDefinition() {
+			if err := recordAnnotation(selector.Unquoted(), fields.Value(), annotations); err != nil {
+				return err
+			}
+		}
+
+		if err := collectNestedAnnotations(fields.Value(), annotations); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectNestedAnnotations records the annotations of fields of value, its list elements, and its pattern
+// constraints.
+func collectNestedAnnotations(value cue.Value, annotations map[string]string) error {
+	if err := collectAnnotations(value, false, annotations); err != nil {
+		return err
+	}
+
+	for _, selector := range []cue.Selector{cue.AnyIndex, cue.AnyString} {
+		if element := value.LookupPath(cue.MakePath(selector)); element.Exists() {
+			if err := collectNestedAnnotations(element, annotations); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func recordAnnotation(name string, value cue.Value, annotations map[string]string) error {
+	attribute := value.Attribute(attributeName)
+	if attribute.Err() != nil {
+		return nil //nolint:nilerr // fields without an attribute are not annotated
+	}
+
+	annotation := strings.TrimSpace(attribute.Contents())
+	if annotation != keep && annotation != redact {
+		return fmt.Errorf("%w: field %s is annotated with @rere(%s)", errInvalidAnnotation, name, annotation)
+	}
+
+	key := strings.ToLower(name)
+
+	for existingName, existingAnnotation := range annotations {
+		if strings.ToLower(existingName) == key && existingAnnotation != annotation {
+			return fmt.Errorf("%w: %s", errConflictingAnnotation, name)
+		}
+	}
+
+	annotations[name] = annotation
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package cuepolicy_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere/cuepolicy"
+	"github.com/onsi/gomega"
+)
+
+const redacted = "REDACTED"
+
+const usersPolicy = `
+policy: {
+	name: "users"
+	redactPaths: ["Profile.bio"]
+}
+
+#User: {
+	name:     string @rere(keep)
+	email:    string
+	password: string @rere(redact)
+	profile?: #Profile
+	cards: [...{last4: string @rere(keep)}]
+	labels: [string]: {value: string @rere(keep)}
+}
+
+#Profile: {
+	bio:      string @rere(keep)
+	timezone: string @rere(keep)
+}
+`
+
+type label struct {
+	Value string `json:"value"`
+}
+
+type card struct {
+	Last4  string `json:"last4"`
+	Number string `json:"-"`
+}
+
+type profile struct {
+	Bio      string `json:"bio"`
+	Timezone string `json:"timezone"`
+}
+
+type user struct {
+	Name     string           `json:"name"`
+	Email    string           `json:"email"`
+	Password string           `json:"password"`
+	Profile  profile          `json:"profile"`
+	Cards    []card           `json:"cards"`
+	Labels   map[string]label `json:"labels"`
+}
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	input := user{
+		Name:     "alice",
+		Email:    "alice@example.com",
+		Password: "hunter2",
+		Profile:  profile{Bio: "hello", Timezone: "UTC"},
+		Cards:    []card{{Last4: "1111", Number: "4111111111111111"}},
+		Labels:   map[string]label{"team": {Value: "payments"}},
+	}
+
+	testCases := []struct {
+		name   string
+		source string
+		output user
+	}{
+		{
+			name:   "keeps fields annotated with keep in allow mode",
+			source: usersPolicy,
+			output: user{
+				Name:     "alice",
+				Email:    redacted,
+				Password: redacted,
+				Profile:  profile{Bio: redacted, Timezone: "UTC"},
+				Cards:    []card{{Last4: "1111", Number: redacted}},
+				Labels:   map[string]label{"team": {Value: "payments"}},
+			},
+		},
+		{
+			name:   "redacts fields annotated with redact in deny mode",
+			source: usersPolicy + `policy: mode: "deny"`,
+			output: user{
+				Name:     "alice",
+				Email:    "alice@example.com",
+				Password: redacted,
+				Profile:  profile{Bio: redacted, Timezone: "UTC"},
+				Cards:    []card{{Last4: "1111", Number: "4111111111111111"}},
+				Labels:   map[string]label{"team": {Value: "payments"}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			policy, err := cuepolicy.Compile([]byte(testCase.source))
+
+			g.Expect(err).NotTo(gomega.HaveOccurred(), "Compile should compile the policy")
+			g.Expect(policy.Redact(input)).To(gomega.Equal(testCase.output), "Compile should create the described policy")
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		source string
+		err    string
+	}{
+		{
+			name:   "rejects invalid CUE",
+			source: `#User: {`,
+			err:    "failed to compile policy",
+		},
+		{
+			name:   "rejects unknown annotations",
+			source: `#User: {name: string @rere(hide)}`,
+			err:    "field name is annotated with @rere(hide)",
+		},
+		{
+			name:   "rejects conflicting annotations",
+			source: `#User: {name: string @rere(keep)}, #Team: {Name: string @rere(redact)}`,
+			err:    "field is annotated with both @rere(keep) and @rere(redact)",
+		},
+		{
+			name:   "rejects invalid modes",
+			source: `policy: mode: "redact"`,
+			err:    `mode must be "allow" or "deny"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			_, err := cuepolicy.Compile([]byte(testCase.source))
+
+			g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(testCase.err)), "Compile should return an error")
+		})
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	policy, err := cuepolicy.Compile([]byte(usersPolicy))
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "Compile should compile the policy")
+
+	valid := user{
+		Name:     "alice",
+		Email:    "alice@example.com",
+		Password: "hunter2",
+		Profile:  profile{Bio: "hello", Timezone: "UTC"},
+		Cards:    []card{},
+		Labels:   map[string]label{},
+	}
+
+	g.Expect(policy.Validate("#User", valid)).To(gomega.Succeed(), "Validate should accept values matching the schema")
+	g.Expect(policy.Validate("#User", map[string]any{"name": 1})).To(gomega.HaveOccurred(),
+		"Validate should reject values not matching the schema")
+	g.Expect(policy.Validate("#Team", valid)).To(gomega.MatchError(gomega.ContainSubstring("definition not found")),
+		"Validate should reject unknown definitions")
+}
@@ -0,0 +1,57 @@
+package rere
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+)
+
+// StateHandler creates an http.Handler serving a redacted JSON view of the value returned by snapshot for diagnostics
+// endpoints, such as /debug/state. snapshot is called for every request, so the served state is always current, and
+// the returned value is not modified.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided.
+func StateHandler(snapshot func() any, opts ...Option) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		serveRedactedJSON(writer, snapshot(), opts)
+	})
+}
+
+// ExpvarHandler creates an http.Handler serving a redacted JSON view of every published expvar variable, as a
+// replacement for expvar.Handler. Variables are redacted as a map keyed by variable name, so WithAllowList entries such
+// as "memstats" keep whole variables, and the fields of JSON objects published by variables are redacted by their key
+// names.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided.
+func ExpvarHandler(opts ...Option) http.Handler {
+	return StateHandler(expvarSnapshot, opts...)
+}
+
+func expvarSnapshot() any {
+	snapshot := map[string]any{}
+
+	expvar.Do(func(variable expvar.KeyValue) {
+		var value any
+
+		// a variable publishing invalid JSON is served as its raw text, so it is still redacted as a string
+		if err := json.Unmarshal([]byte(variable.Value.String()), &value); err != nil {
+			value = variable.Value.String()
+		}
+
+		snapshot[variable.Key] = value
+	})
+
+	return snapshot
+}
+
+func serveRedactedJSON(writer http.ResponseWriter, value any, opts []Option) {
+	body, err := MarshalJSON(value, opts...)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = writer.Write(body)
+}
@@ -0,0 +1,72 @@
+package rere_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type appState struct {
+	Version  string
+	Database credentialDetails
+}
+
+func TestStateHandler(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	state := appState{
+		Version:  "1.2.3",
+		Database: credentialDetails{Name: "postgres", Secret: "hunter2"},
+	}
+
+	handler := rere.StateHandler(func() any {
+		return state
+	}, rere.WithAllowList("version", "name"))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/state", nil))
+
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK), "StateHandler should respond with OK")
+	g.Expect(recorder.Header().Get("Content-Type")).To(gomega.HavePrefix("application/json"),
+		"StateHandler should respond with JSON")
+	g.Expect(recorder.Body.String()).To(gomega.MatchJSON(
+		`{"Version":"1.2.3","Database":{"Name":"postgres","Secret":"REDACTED"}}`,
+	), "StateHandler should serve the redacted snapshot")
+	g.Expect(state.Database.Secret).To(gomega.Equal("hunter2"), "StateHandler should not modify the snapshot")
+}
+
+func TestExpvarHandler(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	config := expvar.NewMap("rere_test_config")
+	config.Set("region", stringVar("us-east-1"))
+	config.Set("token", stringVar("secret"))
+
+	recorder := httptest.NewRecorder()
+	rere.ExpvarHandler(rere.WithAllowList("region")).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+
+	var served map[string]any
+
+	g.Expect(json.Unmarshal(recorder.Body.Bytes(), &served)).To(gomega.Succeed(), "ExpvarHandler should serve JSON")
+	g.Expect(served).To(gomega.HaveKeyWithValue("rere_test_config", map[string]any{
+		"region": "us-east-1",
+		"token":  redacted,
+	}), "ExpvarHandler should redact variables by key name")
+	g.Expect(served).To(gomega.HaveKeyWithValue("cmdline", gomega.HaveEach(redacted)),
+		"ExpvarHandler should redact variables not in the allow list")
+}
+
+func stringVar(value string) *expvar.String {
+	variable := new(expvar.String)
+	variable.Set(value)
+
+	return variable
+}
@@ -0,0 +1,96 @@
+package rere
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Detector finds sensitive text, such as credentials, inside of a larger text.
+type Detector interface {
+	// Detect returns the start and end byte offsets of every sensitive match in text, in the same form as
+	// regexp.Regexp.FindAllStringIndex.
+	Detect(text string) [][]int
+}
+
+// DetectorFunc is a function implementing Detector.
+type DetectorFunc func(text string) [][]int
+
+// Detect calls f(text).
+func (f DetectorFunc) Detect(text string) [][]int {
+	return f(text)
+}
+
+// RegexpDetector creates a Detector matching pattern.
+func RegexpDetector(pattern *regexp.Regexp) Detector {
+	return DetectorFunc(func(text string) [][]int {
+		return pattern.FindAllStringIndex(text, -1)
+	})
+}
+
+// RedactText replaces every match found by detectors in text with "REDACTED". Overlapping matches are redacted
+// with a single "REDACTED".
+func RedactText(text string, detectors ...Detector) string {
+	return replaceMatches(text, detect(text, detectors), redactedMessage)
+}
+
+// defaultTextDetectors returns the detectors scrubbing text when no detectors are provided, so helpers scrubbing
+// free-form text don't leak credentials by default. They match credentials with well-known formats, sensitive query
+// parameters, and passwords of URLs.
+func defaultTextDetectors() []Detector {
+	return []Detector{SecretFormatDetector(), QueryStringDetector(), URLCredentialsDetector()}
+}
+
+// replaceMatches replaces every match of the sorted and merged matches in text with placeholder.
+func replaceMatches(text string, matches [][]int, placeholder string) string {
+	if len(matches) == 0 {
+		return text
+	}
+
+	var builder strings.Builder
+
+	previousEnd := 0
+
+	for _, match := range matches {
+		builder.WriteString(text[previousEnd:match[0]])
+		builder.WriteString(placeholder)
+
+		previousEnd = match[1]
+	}
+
+	builder.WriteString(text[previousEnd:])
+
+	return builder.String()
+}
+
+// detect returns the sorted and merged matches of every detector in text.
+func detect(text string, detectors []Detector) [][]int {
+	var matches [][]int
+
+	for _, detector := range detectors {
+		for _, match := range detector.Detect(text) {
+			// ignore empty matches since there is nothing to redact
+			if match[0] < match[1] {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	slices.SortFunc(matches, func(first, second []int) int {
+		return first[0] - second[0]
+	})
+
+	merged := make([][]int, 0, len(matches))
+
+	for _, match := range matches {
+		if len(merged) != 0 && match[0] <= merged[len(merged)-1][1] {
+			merged[len(merged)-1][1] = max(merged[len(merged)-1][1], match[1])
+
+			continue
+		}
+
+		merged = append(merged, []int{match[0], match[1]})
+	}
+
+	return merged
+}
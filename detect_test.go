@@ -0,0 +1,67 @@
+package rere_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactText(t *testing.T) {
+	t.Parallel()
+
+	githubToken := rere.RegexpDetector(regexp.MustCompile(`ghp_[A-Za-z0-9]+`))
+	password := rere.RegexpDetector(regexp.MustCompile(`password=\S+`))
+
+	testCases := []struct {
+		name      string
+		input     string
+		detectors []rere.Detector
+		output    string
+	}{
+		{
+			name:      "leaves text without matches as-is",
+			input:     "nothing to see here",
+			detectors: []rere.Detector{githubToken},
+			output:    "nothing to see here",
+		},
+		{
+			name:      "redacts every match",
+			input:     "token ghp_abc and ghp_def",
+			detectors: []rere.Detector{githubToken},
+			output:    "token REDACTED and REDACTED",
+		},
+		{
+			name:      "redacts matches of every detector",
+			input:     "token ghp_abc password=hunter2",
+			detectors: []rere.Detector{githubToken, password},
+			output:    "token REDACTED REDACTED",
+		},
+		{
+			name:      "merges overlapping matches",
+			input:     "password=ghp_abc end",
+			detectors: []rere.Detector{githubToken, password},
+			output:    "REDACTED end",
+		},
+		{
+			name:  "supports detector funcs",
+			input: "abcdef",
+			detectors: []rere.Detector{rere.DetectorFunc(func(string) [][]int {
+				return [][]int{{1, 3}, {4, 4}}
+			})},
+			output: "aREDACTEDdef",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactText(testCase.input, testCase.detectors...)).To(gomega.Equal(testCase.output),
+				"RedactText should redact detected text")
+		})
+	}
+}
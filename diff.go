@@ -0,0 +1,54 @@
+package rere
+
+import "slices"
+
+// PolicyDiff describes the values whose redaction outcome changes between two policies. See DiffPolicies.
+type PolicyDiff struct {
+	// Kept are the paths of values redacted by the old policy and kept by the new policy, which are values the new
+	// policy leaks.
+	Kept []string
+	// Redacted are the paths of values kept by the old policy and redacted by the new policy.
+	Redacted []string
+}
+
+// DiffPolicies redacts value with oldOpts and newOpts and returns the paths of values whose redaction outcome changes,
+// such as when reviewing a change to an allow list. Paths use the same form as Redaction.Path and are listed in
+// traversal order.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided, the same as MarshalJSON.
+// Changes to placeholders are not differences, since the value is redacted by both policies.
+func DiffPolicies(value any, oldOpts, newOpts []Option) PolicyDiff {
+	oldPaths := redactedPaths(value, oldOpts)
+	newPaths := redactedPaths(value, newOpts)
+
+	return PolicyDiff{
+		Kept:     missingPaths(oldPaths, newPaths),
+		Redacted: missingPaths(newPaths, oldPaths),
+	}
+}
+
+func redactedPaths(value any, opts []Option) []string {
+	var report Report
+
+	redactValue(value, newOptions(allow, nil, append(slices.Clone(opts), WithReport(&report))))
+
+	paths := make([]string, 0, len(report.Redactions))
+	for _, redaction := range report.Redactions {
+		paths = append(paths, redaction.Path)
+	}
+
+	return paths
+}
+
+// missingPaths returns the paths in paths that are not in otherPaths.
+func missingPaths(paths, otherPaths []string) []string {
+	missing := []string{}
+
+	for _, valuePath := range paths {
+		if !slices.Contains(otherPaths, valuePath) {
+			missing = append(missing, valuePath)
+		}
+	}
+
+	return missing
+}
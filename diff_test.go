@@ -0,0 +1,65 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestDiffPolicies(t *testing.T) {
+	t.Parallel()
+
+	input := map[string][]statsUser{
+		"Users": {
+			{Name: "alice", Email: "alice@example.com", Password: "password", Age: 42},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		oldOpts []rere.Option
+		newOpts []rere.Option
+		diff    rere.PolicyDiff
+	}{
+		{
+			name:    "reports values the new policy keeps",
+			oldOpts: []rere.Option{rere.WithAllowList("name")},
+			newOpts: []rere.Option{rere.WithAllowList("name", "email", "password")},
+			diff: rere.PolicyDiff{
+				Kept:     []string{"Users[0].Email"},
+				Redacted: []string{},
+			},
+		},
+		{
+			name:    "reports values the new policy redacts",
+			oldOpts: []rere.Option{rere.WithDenyList("password")},
+			newOpts: []rere.Option{rere.WithDenyList("email", "age")},
+			diff: rere.PolicyDiff{
+				Kept:     []string{},
+				Redacted: []string{"Users[0].Email", "Users[0].Age"},
+			},
+		},
+		{
+			name:    "ignores placeholder changes",
+			oldOpts: nil,
+			newOpts: []rere.Option{rere.WithLengthHint()},
+			diff: rere.PolicyDiff{
+				Kept:     []string{},
+				Redacted: []string{},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.DiffPolicies(input, testCase.oldOpts, testCase.newOpts)).To(gomega.Equal(testCase.diff),
+				"DiffPolicies should report paths whose redaction outcome changes")
+		})
+	}
+}
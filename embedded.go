@@ -0,0 +1,256 @@
+package rere
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// embeddedFormat decodes text found at valueLocation, redacts the decoded document, and returns it encoded again.
+// embeddedFormat returns false when text is not in its format.
+type embeddedFormat func(redactOptions *options, valueLocation location, text string) (string, bool)
+
+// WithEmbeddedJSON redacts strings containing a JSON object or array by their keys instead of replacing the whole
+// string, such as a "Payload" field holding a JSON document. Only strings that would otherwise be redacted are decoded.
+// Values in the document are redacted the same as values in a map[string]any at the string's location, such as
+// "Payload.password", except values no rule applies to are redacted in deny mode too, since the string they were
+// found in was going to be redacted.
+//
+// The redacted document is encoded compactly, so whitespace is not preserved and object keys are sorted. Numbers are
+// kept as written, so integers too large for a float64, such as IDs, stay intact.
+func WithEmbeddedJSON() Option {
+	return func(o *options) {
+		o.embeddedFormats = append(o.embeddedFormats, redactEmbeddedJSON)
+	}
+}
+
+// WithEmbeddedXML redacts strings containing an XML fragment by element and attribute names instead of replacing the
+// whole string, such as a "Body" field holding a SOAP envelope. Only strings that would otherwise be redacted are
+// decoded. Text and attribute values are redacted the same as values of map keys named after their element or
+// attribute, such as "Body.Envelope.password", except values no rule applies to are redacted in deny mode too.
+// Everything but redacted values is kept as-is.
+func WithEmbeddedXML() Option {
+	return func(o *options) {
+		o.embeddedFormats = append(o.embeddedFormats, redactEmbeddedXML)
+	}
+}
+
+// WithEmbeddedForms redacts strings containing form or query encoded parameters, such as "user=alice&token=abc", by
+// parameter name instead of replacing the whole string. Only strings that would otherwise be redacted are decoded.
+// Parameter values are redacted the same as values of map keys named after the parameter, such as "Body.token",
+// except values no rule applies to are redacted in deny mode too. Everything but redacted values is kept as-is.
+//
+// To avoid mistaking base64 padding for a parameter, strings with a single parameter must have a non-empty value.
+func WithEmbeddedForms() Option {
+	return func(o *options) {
+		o.embeddedFormats = append(o.embeddedFormats, redactEmbeddedForm)
+	}
+}
+
+// redactEmbedded redacts text found at valueLocation with the first embedded format text is in.
+func (o *options) redactEmbedded(valueLocation location, text string) (string, bool) {
+	for _, format := range o.embeddedFormats {
+		if redacted, ok := format(o, valueLocation, text); ok {
+			return redacted, true
+		}
+	}
+
+	return "", false
+}
+
+func redactEmbeddedJSON(redactOptions *options, valueLocation location, text string) (string, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+
+	document, err := decodeJSON([]byte(trimmed))
+	if err != nil {
+		return "", false
+	}
+
+	valueLocation.inEmbedded = true
+
+	redact(valueLocation, reflect.ValueOf(&document), redactOptions)
+
+	var encoded bytes.Buffer
+
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(document); err != nil {
+		return "", false
+	}
+
+	return strings.TrimSuffix(encoded.String(), "\n"), true
+}
+
+func redactEmbeddedXML(redactOptions *options, valueLocation location, text string) (string, bool) {
+	if !looksLikeXML(text) {
+		return "", false
+	}
+
+	valueLocation.inEmbedded = true
+
+	var builder strings.Builder
+
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	// elements holds the location of every open element, starting with the location of text itself
+	elements := []location{valueLocation}
+	// names holds the name of every open element, since RawToken does not check end elements match
+	names := []xml.Name{}
+	foundElement := false
+
+	for start := int64(0); ; {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return "", false
+		}
+
+		end := decoder.InputOffset()
+		raw := text[start:end]
+		start = end
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			elementLocation := elements[len(elements)-1].stringKey(token.Name.Local)
+			raw = redactXMLAttributes(redactOptions, elementLocation, token, raw)
+			elements = append(elements, elementLocation)
+			names = append(names, token.Name)
+			foundElement = true
+		case xml.EndElement:
+			if len(names) == 0 || names[len(names)-1] != token.Name {
+				return "", false
+			}
+
+			elements = elements[:len(elements)-1]
+			names = names[:len(names)-1]
+		case xml.CharData:
+			if len(elements) > 1 && strings.TrimSpace(string(token)) != "" {
+				redacted := redactOptions.redactString(elements[len(elements)-1], string(token), reflect.TypeOf(text))
+				if redacted != string(token) {
+					raw = escapeXML(redacted)
+				}
+			}
+		}
+
+		builder.WriteString(raw)
+	}
+
+	if !foundElement || len(elements) != 1 {
+		return "", false
+	}
+
+	return builder.String(), true
+}
+
+func looksLikeXML(text string) bool {
+	trimmed := strings.TrimSpace(text)
+
+	return strings.HasPrefix(trimmed, "<") && strings.HasSuffix(trimmed, ">")
+}
+
+// redactXMLAttributes redacts the attribute values of startElement, returning raw as-is when nothing is redacted.
+func redactXMLAttributes(
+	redactOptions *options, elementLocation location, startElement xml.StartElement, raw string,
+) string {
+	redactedAny := false
+	attributes := make([]xml.Attr, 0, len(startElement.Attr))
+
+	for _, attribute := range startElement.Attr {
+		// keep namespace declarations
+		if attribute.Name.Space != "xmlns" && attribute.Name.Local != "xmlns" {
+			redacted := redactOptions.redactString(elementLocation.stringKey(attribute.Name.Local), attribute.Value,
+				reflect.TypeOf(attribute.Value))
+			redactedAny = redactedAny || redacted != attribute.Value
+			attribute.Value = redacted
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	if !redactedAny {
+		return raw
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("<" + qualifiedXMLName(startElement.Name))
+
+	for _, attribute := range attributes {
+		builder.WriteString(" " + qualifiedXMLName(attribute.Name) + `="` + escapeXML(attribute.Value) + `"`)
+	}
+
+	if strings.HasSuffix(raw, "/>") {
+		builder.WriteString("/>")
+	} else {
+		builder.WriteString(">")
+	}
+
+	return builder.String()
+}
+
+func qualifiedXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
+
+func escapeXML(text string) string {
+	var builder strings.Builder
+
+	// strings.Builder.Write never returns an error
+	_ = xml.EscapeText(&builder, []byte(text))
+
+	return builder.String()
+}
+
+func redactEmbeddedForm(redactOptions *options, valueLocation location, text string) (string, bool) {
+	if strings.ContainsAny(text, " \t\r\n") {
+		return "", false
+	}
+
+	valueLocation.inEmbedded = true
+
+	parameters := strings.Split(text, "&")
+
+	for i, parameter := range parameters {
+		rawName, rawValue, found := strings.Cut(parameter, "=")
+		if !found || !isFormName(rawName) || strings.Contains(rawValue, "=") || (len(parameters) == 1 && rawValue == "") {
+			return "", false
+		}
+
+		name, err := url.QueryUnescape(rawName)
+		if err != nil {
+			return "", false
+		}
+
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			return "", false
+		}
+
+		redacted := redactOptions.redactString(valueLocation.stringKey(name), value, reflect.TypeOf(text))
+		if redacted != value {
+			parameters[i] = rawName + "=" + url.QueryEscape(redacted)
+		}
+	}
+
+	return strings.Join(parameters, "&"), true
+}
+
+// isFormName reports whether rawName looks like a form parameter name rather than part of a JSON or XML document.
+func isFormName(rawName string) bool {
+	return rawName != "" && !strings.ContainsAny(rawName, `{}<>"'`)
+}
@@ -0,0 +1,257 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type webhookDelivery struct {
+	ID      string
+	Payload string
+}
+
+func TestRedactWithEmbeddedJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		payload string
+		redact  func(input webhookDelivery) webhookDelivery
+		output  string
+	}{
+		{
+			name:    "redacts JSON objects by key in allow mode",
+			payload: `{"user": "alice", "password": "hunter2", "attempts": 3, "tags": ["a", "b"], "note": null}`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithAllowList(input, []string{"id", "user"}, rere.WithEmbeddedJSON())
+			},
+			output: `{"attempts":3,"note":null,"password":"REDACTED","tags":["REDACTED","REDACTED"],"user":"alice"}`,
+		},
+		{
+			name:    "redacts JSON arrays",
+			payload: ` [{"user": "alice", "token": "<token>"}] `,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithAllowList(input, []string{"id", "user"}, rere.WithEmbeddedJSON())
+			},
+			output: `[{"token":"REDACTED","user":"alice"}]`,
+		},
+		{
+			name:    "redacts every value no rule applies to in deny mode",
+			payload: `{"user": "alice", "password": "hunter2"}`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithDenyList(input, []string{"payload"}, rere.WithEmbeddedJSON())
+			},
+			output: `{"password":"REDACTED","user":"REDACTED"}`,
+		},
+		{
+			name:    "keeps strings that are not redacted as-is",
+			payload: `{"user": "alice", "password": "hunter2"}`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithAllowList(input, []string{"id", "payload"}, rere.WithEmbeddedJSON())
+			},
+			output: `{"user": "alice", "password": "hunter2"}`,
+		},
+		{
+			name:    "redacts strings that are not JSON wholesale",
+			payload: `{not json`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithAllowList(input, []string{"id"}, rere.WithEmbeddedJSON())
+			},
+			output: redacted,
+		},
+		{
+			name:    "keeps integers too large for a float64 as-is",
+			payload: `{"id": 12345678901234567891, "user": "alice", "password": "hunter2"}`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithAllowList(input, []string{"id", "user"}, rere.WithEmbeddedJSON())
+			},
+			output: `{"id":12345678901234567891,"password":"REDACTED","user":"alice"}`,
+		},
+		{
+			name:    "zeros numbers explicitly redacted by name",
+			payload: `{"user": "alice", "pin": 1234}`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithDenyList(input, []string{"payload", "pin"}, rere.WithEmbeddedJSON())
+			},
+			output: `{"pin":0,"user":"REDACTED"}`,
+		},
+		{
+			name:    "redacts nested embedded JSON",
+			payload: `{"body": "{\"password\": \"hunter2\", \"user\": \"alice\"}"}`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithAllowList(input, []string{"id", "user"}, rere.WithEmbeddedJSON())
+			},
+			output: `{"body":"{\"password\":\"REDACTED\",\"user\":\"alice\"}"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			output := testCase.redact(webhookDelivery{ID: "1", Payload: testCase.payload})
+
+			g.Expect(output).To(gomega.Equal(webhookDelivery{ID: "1", Payload: testCase.output}),
+				"embedded JSON should be redacted by key")
+		})
+	}
+}
+
+func TestRedactWithEmbeddedJSONReport(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	rere.RedactWithAllowList(webhookDelivery{ID: "1", Payload: `{"user": "alice", "password": "hunter2"}`},
+		[]string{"id", "user"}, rere.WithEmbeddedJSON(), rere.WithReport(&report))
+
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "Payload.password", Pointer: "/Payload/password", Value: redacted},
+	}), "embedded JSON redactions should be recorded at their path inside of the string")
+}
+
+func TestRedactNilInterfaceValues(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := map[string]any{"password": "hunter2", "empty": nil}
+
+	g.Expect(rere.RedactWithAllowList(input, nil)).To(gomega.Equal(map[string]any{"password": redacted, "empty": nil}),
+		"RedactWithAllowList should keep nil interface values as nil")
+}
+
+func TestRedactWithEmbeddedXML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		payload string
+		redact  func(input webhookDelivery) webhookDelivery
+		output  string
+	}{
+		{
+			name: "redacts text and attributes by name in allow mode",
+			payload: `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">
+  <soap:Body>
+    <login user="alice" password="a&amp;b"><token>abc</token><note/></login>
+    <!-- comment -->
+  </soap:Body>
+</soap:Envelope>`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithAllowList(input, []string{"id", "user"}, rere.WithEmbeddedXML())
+			},
+			output: `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">
+  <soap:Body>
+    <login user="alice" password="REDACTED"><token>REDACTED</token><note/></login>
+    <!-- comment -->
+  </soap:Body>
+</soap:Envelope>`,
+		},
+		{
+			name:    "redacts every value no rule applies to in deny mode",
+			payload: `<user name="alice"><password><![CDATA[hunter2]]></password></user>`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithDenyList(input, []string{"payload"}, rere.WithEmbeddedXML(),
+					rere.WithKindAnnotatedPlaceholders())
+			},
+			output: `<user name="[REDACTED string]"><password>[REDACTED string]</password></user>`,
+		},
+		{
+			name:    "redacts strings that are not XML wholesale",
+			payload: `<user>alice</account>`,
+			redact: func(input webhookDelivery) webhookDelivery {
+				return rere.RedactWithAllowList(input, []string{"id"}, rere.WithEmbeddedXML())
+			},
+			output: redacted,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			output := testCase.redact(webhookDelivery{ID: "1", Payload: testCase.payload})
+
+			g.Expect(output).To(gomega.Equal(webhookDelivery{ID: "1", Payload: testCase.output}),
+				"embedded XML should be redacted by name")
+		})
+	}
+}
+
+func TestRedactWithEmbeddedForms(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		payload string
+		output  string
+	}{
+		{
+			name:    "redacts parameters by name",
+			payload: "user=alice&password=p%40ss+word&tags[]=a&empty=",
+			output:  "user=alice&password=REDACTED&tags[]=REDACTED&empty=",
+		},
+		{
+			name:    "redacts a single parameter",
+			payload: "token=abc",
+			output:  "token=REDACTED",
+		},
+		{
+			name:    "redacts base64 values wholesale",
+			payload: "dXNlcjpwYXNz=",
+			output:  redacted,
+		},
+		{
+			name:    "redacts text that is not form encoded wholesale",
+			payload: "user=alice and password=hunter2",
+			output:  redacted,
+		},
+		{
+			name:    "redacts JSON documents wholesale",
+			payload: `{"token":"a=b"}`,
+			output:  redacted,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			output := rere.RedactWithAllowList(webhookDelivery{ID: "1", Payload: testCase.payload}, []string{"id", "user"},
+				rere.WithEmbeddedForms())
+
+			g.Expect(output).To(gomega.Equal(webhookDelivery{ID: "1", Payload: testCase.output}),
+				"embedded forms should be redacted by parameter name")
+		})
+	}
+}
+
+func TestRedactWithEmbeddedFormsReport(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	output := rere.RedactStringMap(map[string]string{"body": "user=alice&token=abc"},
+		rere.WithAllowList("user"), rere.WithEmbeddedForms(), rere.WithReport(&report))
+
+	g.Expect(output).To(gomega.Equal(map[string]string{"body": "user=alice&token=REDACTED"}),
+		"RedactStringMap should redact embedded forms")
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "body.token", Pointer: "/body/token", Value: redacted},
+	}), "embedded form redactions should be recorded at their path inside of the string")
+}
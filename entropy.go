@@ -0,0 +1,58 @@
+package rere
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// maxTokenRepeat is the largest repetition count accepted by regexp.
+const maxTokenRepeat = 1000
+
+// EntropyDetector creates a Detector matching random-looking tokens of at least minLength characters whose Shannon
+// entropy is at least minEntropy bits per character, such as unnamed API keys and passwords pasted into free-form
+// fields. Tokens are runs of letters, digits, and the characters "+", "/", "_", and "-", which covers hex, base64,
+// and base64url encodings without their padding.
+//
+// Long random hex strings approach 4 bits per character and long random base64 strings approach 6, while English words
+// and identifiers usually stay below 3.5. A token of n characters can't exceed log2(n) bits per character, so short
+// minimum lengths need lower thresholds. EntropyDetector(20, 4) is a reasonable starting point for base64 secrets, and
+// lower thresholds catch more secrets at the cost of more false positives. Provide it to WithValueDetectors to redact
+// values holding such tokens, or use it with RedactText and other functions accepting detectors.
+func EntropyDetector(minLength int, minEntropy float64) Detector {
+	// regular expressions can't repeat more than 1000 times, so longer lengths are checked after matching
+	token := regexp.MustCompile(`[A-Za-z0-9+/_-]{` + strconv.Itoa(min(max(minLength, 1), maxTokenRepeat)) + `,}`)
+
+	return DetectorFunc(func(text string) [][]int {
+		var matches [][]int
+
+		for _, match := range token.FindAllStringIndex(text, -1) {
+			if match[1]-match[0] >= minLength && shannonEntropy(text[match[0]:match[1]]) >= minEntropy {
+				matches = append(matches, match)
+			}
+		}
+
+		return matches
+	})
+}
+
+// shannonEntropy returns the Shannon entropy of the bytes of text in bits per byte.
+func shannonEntropy(text string) float64 {
+	var counts [256]int
+	for i := 0; i < len(text); i++ {
+		counts[text[i]]++
+	}
+
+	entropy := 0.0
+
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		probability := float64(count) / float64(len(text))
+		entropy -= probability * math.Log2(probability)
+	}
+
+	return entropy
+}
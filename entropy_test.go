@@ -0,0 +1,77 @@
+package rere_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestEntropyDetector(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		text     string
+		detector rere.Detector
+		output   string
+	}{
+		{
+			name:     "matches random base64 tokens",
+			text:     "note: use key q8Zr1vX3pL0wNc7TbY2kHs9Ej5Ud4Gm6 for staging",
+			detector: rere.EntropyDetector(20, 4),
+			output:   "note: use key REDACTED for staging",
+		},
+		{
+			name:     "matches random hex tokens with a lower threshold",
+			text:     "secret=3f9a1c2e7b4d8e0f6a5c9b1d2e3f4a7c",
+			detector: rere.EntropyDetector(32, 3.5),
+			output:   "secret=REDACTED",
+		},
+		{
+			name:     "keeps words and identifiers",
+			text:     "internationalization of the user_profile_settings_controller failed",
+			detector: rere.EntropyDetector(20, 4),
+			output:   "internationalization of the user_profile_settings_controller failed",
+		},
+		{
+			name:     "keeps random tokens shorter than the minimum length",
+			text:     "code q8Zr1vX3pL0w",
+			detector: rere.EntropyDetector(20, 3),
+			output:   "code q8Zr1vX3pL0w",
+		},
+		{
+			name:     "supports minimum lengths longer than regular expression repetitions",
+			text:     "blob " + strings.Repeat("q8Zr1vX3pL0wNc7TbY2kHs9Ej5Ud4Gm6", 40),
+			detector: rere.EntropyDetector(1200, 4),
+			output:   "blob REDACTED",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactText(testCase.text, testCase.detector)).To(gomega.Equal(testCase.output),
+				"EntropyDetector should match long random-looking tokens")
+		})
+	}
+}
+
+func TestRedactWithEntropyValueDetector(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := map[string]string{
+		"comment": "temporary token q8Zr1vX3pL0wNc7TbY2kHs9Ej5Ud4Gm6, remove later",
+		"status":  "deployment completed successfully",
+	}
+
+	g.Expect(rere.RedactWithDenyList(input, nil, rere.WithValueDetectors(rere.EntropyDetector(20, 4)))).To(
+		gomega.Equal(map[string]string{"comment": redacted, "status": "deployment completed successfully"}),
+		"WithValueDetectors should redact values holding high-entropy tokens")
+}
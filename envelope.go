@@ -0,0 +1,159 @@
+package rere
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// envelopeField is the key of the object holding an Envelope in JSON, such as {"$rere": {...}}.
+	envelopeField     = "$rere"
+	envelopeAlgorithm = "aes-gcm"
+	envelopeTokenID   = "rere-envelope"
+	envelopeIDLength  = 16
+)
+
+// Envelope is the encrypted form of a redacted value produced by WithEncryptionEnvelope, marshaled to JSON as
+// {"$rere": {"alg": "aes-gcm", "kid": "k1", "data": "..."}}.
+type Envelope struct {
+	// Algorithm is the algorithm Data is encrypted with, which is always "aes-gcm".
+	Algorithm string `json:"alg"`
+	// KeyID is the ID of the Keyring key Data is encrypted with.
+	KeyID string `json:"kid"`
+	// Data is the base64 encoded nonce followed by the AES-GCM sealed original value.
+	Data string `json:"data"`
+	// Kind is "bytes" when the original value was a byte slice, which is marshaled to JSON as base64, and empty for
+	// strings.
+	Kind string `json:"kind,omitempty"`
+}
+
+// WithEncryptionEnvelope makes MarshalJSON and JSON replace redacted string and []byte values with an Envelope holding
+// the original value encrypted with the primary key of keyring, such as
+// {"Password": {"$rere": {"alg": "aes-gcm", "kid": "k1", "data": "..."}}}, instead of a placeholder. This lets
+// downstream decryption services recover values from events carrying PII, such as on an event bus, while every other
+// consumer only sees ciphertext. Keys must be 16, 24, or 32 bytes long to select AES-128, AES-192, or AES-256.
+//
+// Byte arrays and values replaced by WithFieldPlaceholders or WithPathPlaceholders are still replaced with
+// placeholders. Other functions, such as Redact, ignore WithEncryptionEnvelope since Go values can't hold an Envelope
+// in place of a string.
+func WithEncryptionEnvelope(keyring *Keyring) Option {
+	return func(o *options) {
+		o.envelopeKeyring = keyring
+	}
+}
+
+// envelopeState holds the original values replaced with envelope tokens during a single MarshalJSON call.
+type envelopeState struct {
+	// id is random, so tokens can't collide with values of the provided value
+	id        string
+	originals []envelopedValue
+}
+
+type envelopedValue struct {
+	original []byte
+	kind     string
+}
+
+// startEnvelopes makes redacted values be replaced with envelope tokens, which sealEnvelopes replaces with an Envelope
+// after marshaling, when WithEncryptionEnvelope is provided.
+func (o *options) startEnvelopes() {
+	if o.envelopeKeyring == nil {
+		return
+	}
+
+	id := make([]byte, envelopeIDLength)
+
+	// crypto/rand.Read never returns an error on supported platforms
+	_, _ = rand.Read(id)
+
+	o.envelopes = &envelopeState{
+		id:        base64.RawURLEncoding.EncodeToString(id),
+		originals: nil,
+	}
+}
+
+// envelopeToken returns a token replacing original until sealEnvelopes replaces it with an Envelope and whether
+// original is replaced with an Envelope.
+func (o *options) envelopeToken(original []byte, originalType reflect.Type) (string, bool) {
+	// byte arrays are marshaled as arrays of numbers, so a token can't be found in them after marshaling
+	if o.envelopes == nil || originalType.Kind() == reflect.Array {
+		return "", false
+	}
+
+	kind := ""
+	if originalType.Kind() == reflect.Slice {
+		kind = bytesKind
+	}
+
+	o.envelopes.originals = append(o.envelopes.originals, envelopedValue{original: original, kind: kind})
+
+	return o.envelopes.token(len(o.envelopes.originals) - 1), true
+}
+
+func (e *envelopeState) token(index int) string {
+	return envelopeTokenID + ":" + e.id + ":" + strconv.Itoa(index)
+}
+
+// sealEnvelopes replaces the envelope tokens in encoded, marshaled from strings and byte slices, with an Envelope of
+// their original value.
+func (o *options) sealEnvelopes(encoded []byte) ([]byte, error) {
+	if o.envelopes == nil || len(o.envelopes.originals) == 0 {
+		return encoded, nil
+	}
+
+	keyID := o.envelopeKeyring.primaryKeyID
+
+	aead, err := newEnvelopeCipher(o.envelopeKeyring.keys[keyID])
+	if err != nil {
+		return nil, err
+	}
+
+	replacements := make([]string, 0, 4*len(o.envelopes.originals)) //nolint:mnd // two pairs per original
+
+	for index, enveloped := range o.envelopes.originals {
+		nonce := make([]byte, aead.NonceSize())
+
+		// crypto/rand.Read never returns an error on supported platforms
+		_, _ = rand.Read(nonce)
+
+		envelope, err := json.Marshal(map[string]Envelope{envelopeField: {
+			Algorithm: envelopeAlgorithm,
+			KeyID:     keyID,
+			Data:      base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, enveloped.original, nil)),
+			Kind:      enveloped.kind,
+		}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal envelope: %w", err)
+		}
+
+		// strings are marshaled as-is and byte slices are marshaled as base64
+		token := o.envelopes.token(index)
+		replacements = append(replacements,
+			`"`+token+`"`, string(envelope),
+			`"`+base64.StdEncoding.EncodeToString([]byte(token))+`"`, string(envelope),
+		)
+	}
+
+	return []byte(strings.NewReplacer(replacements...).Replace(string(encoded))), nil
+}
+
+func newEnvelopeCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create envelope cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create envelope cipher: %w", err)
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,84 @@
+package rere_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type envelopedEvent struct {
+	Envelope rere.Envelope `json:"$rere"`
+}
+
+func openEnvelope(g *gomega.WithT, key []byte, envelope rere.Envelope) string {
+	block, err := aes.NewCipher(key)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "creating the cipher should succeed")
+
+	aead, err := cipher.NewGCM(block)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "creating the AEAD should succeed")
+
+	sealed, err := base64.StdEncoding.DecodeString(envelope.Data)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "envelope data should be base64")
+
+	original, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "envelope data should decrypt")
+
+	return string(original)
+}
+
+func TestMarshalJSONWithEncryptionEnvelope(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	keyring, err := rere.NewKeyring("k1", map[string][]byte{"k1": key})
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewKeyring should create a keyring")
+
+	user := reportUser{Username: "dustin", Password: "hunter2", Key: []byte("secret")}
+
+	encoded, err := rere.MarshalJSON(user, rere.WithAllowList("username"), rere.WithEncryptionEnvelope(keyring))
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "MarshalJSON should marshal envelopes")
+
+	var decoded struct {
+		Username string
+		Password envelopedEvent
+		Key      envelopedEvent
+	}
+
+	g.Expect(json.Unmarshal(encoded, &decoded)).To(gomega.Succeed(), "MarshalJSON should marshal valid JSON")
+	g.Expect(decoded.Username).To(gomega.Equal("dustin"), "allowed values should be kept")
+
+	for field, envelope := range map[string]rere.Envelope{"Password": decoded.Password.Envelope, "Key": decoded.Key.Envelope} {
+		g.Expect(envelope.Algorithm).To(gomega.Equal("aes-gcm"), field+" should be encrypted with AES-GCM")
+		g.Expect(envelope.KeyID).To(gomega.Equal("k1"), field+" should be encrypted with the primary key")
+	}
+
+	g.Expect(openEnvelope(g, key, decoded.Password.Envelope)).To(gomega.Equal("hunter2"),
+		"string envelopes should hold the original value")
+	g.Expect(openEnvelope(g, key, decoded.Key.Envelope)).To(gomega.Equal("secret"),
+		"byte slice envelopes should hold the original value")
+	g.Expect(decoded.Password.Envelope.Data).NotTo(gomega.Equal(decoded.Key.Envelope.Data),
+		"every envelope should have its own nonce")
+
+	g.Expect(rere.Redact(user, rere.WithAllowList("username"), rere.WithEncryptionEnvelope(keyring)).Password).To(
+		gomega.Equal(redacted), "Redact should ignore WithEncryptionEnvelope")
+}
+
+func TestMarshalJSONWithEncryptionEnvelopeInvalidKey(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	keyring, err := rere.NewKeyring("k1", map[string][]byte{"k1": []byte("short")})
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewKeyring should create a keyring")
+
+	_, err = rere.MarshalJSON(map[string]string{"token": "abc123"}, rere.WithEncryptionEnvelope(keyring))
+
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to create envelope cipher")),
+		"MarshalJSON should reject keys of invalid lengths")
+}
@@ -85,3 +85,26 @@ func ExampleRedactWithDenyList() {
 	// redacted pointer value: {Username:dustin Password:REDACTED Key:[82 69 68 65 67 84 69 68] IsAdmin:true Groups:[users]}
 	// original value left unchanged: {Username:dustin Password:super secret Key:[97 110 111 116 104 101 114 32 115 101 99 114 101 116] IsAdmin:true Groups:[users]}
 }
+
+func ExampleRedact() {
+	type user struct {
+		Username string
+		Password string
+		Email    string
+	}
+
+	testUser := user{
+		Username: "dustin",
+		Password: "super secret",
+		Email:    "dustin@example.com",
+	}
+
+	// Redact is configured entirely through options and redacts every string and []byte by default
+	fmt.Printf("%+v\n", rere.Redact(testUser))
+	fmt.Printf("%+v\n", rere.Redact(testUser, rere.WithAllowList("username"), rere.WithPlaceholder("***")))
+	fmt.Printf("%+v\n", rere.Redact(testUser, rere.WithDenyList("password"), rere.WithLengthHint()))
+
+	// Output: {Username:REDACTED Password:REDACTED Email:REDACTED}
+	// {Username:dustin Password:*** Email:***}
+	// {Username:dustin Password:REDACTED(12) Email:dustin@example.com}
+}
@@ -0,0 +1,214 @@
+package rere
+
+import (
+	"reflect"
+	"slices"
+)
+
+// RedactStringMap redacts the values of a map[string]string, such as headers or labels, without reflecting over the
+// map. Keys are field and key names, so WithAllowList and WithDenyList apply to them. The provided map is not
+// modified.
+//
+// Every non-empty value is redacted unless WithAllowList or WithDenyList is provided.
+func RedactStringMap[M ~map[string]string](values M, opts ...Option) M {
+	redactOptions := newOptions(allow, nil, opts)
+	defer redactOptions.recordAuditEvent()
+
+	redactOptions.acquireScratch()
+	defer redactOptions.releaseScratch()
+
+	if values == nil {
+		return nil
+	}
+
+	redactOptions.guardCollisions(reflect.ValueOf(values))
+
+	mapType := reflect.TypeOf(values)
+	mapLocation := redactOptions.root().enterType(mapType, redactOptions)
+
+	redactedValues := make(M, len(values))
+
+	for _, key := range stringKeys(values, redactOptions) {
+		redactedValues[key] = redactOptions.redactString(mapLocation.stringKey(key), values[key], mapType.Elem())
+	}
+
+	return redactedValues
+}
+
+// RedactStringSlice redacts the elements of a []string without reflecting over the slice. Elements have no field or
+// key name, so every non-empty element is redacted in allow mode and kept in deny mode, the same as providing a
+// []string to RedactWithAllowList or RedactWithDenyList. The provided slice is not modified.
+//
+// Every non-empty element is redacted unless WithDenyList is provided.
+func RedactStringSlice[S ~[]string](values S, opts ...Option) S {
+	redactOptions := newOptions(allow, nil, opts)
+	defer redactOptions.recordAuditEvent()
+
+	redactOptions.acquireScratch()
+	defer redactOptions.releaseScratch()
+
+	if values == nil {
+		return nil
+	}
+
+	redactOptions.guardCollisions(reflect.ValueOf(values))
+
+	sliceType := reflect.TypeOf(values)
+
+	return redactStrings(redactOptions.root().enterType(sliceType, redactOptions), values, sliceType.Elem(), redactOptions)
+}
+
+// RedactStringSliceMap redacts the values of a map[string][]string, such as http.Header or url.Values, without
+// reflecting over the map. Keys are field and key names, so WithAllowList and WithDenyList apply to them. The provided
+// map is not modified.
+//
+// Every non-empty value is redacted unless WithAllowList or WithDenyList is provided.
+func RedactStringSliceMap[M ~map[string][]string](values M, opts ...Option) M {
+	redactOptions := newOptions(allow, nil, opts)
+	defer redactOptions.recordAuditEvent()
+
+	redactOptions.acquireScratch()
+	defer redactOptions.releaseScratch()
+
+	if values == nil {
+		return nil
+	}
+
+	redactOptions.guardCollisions(reflect.ValueOf(values))
+
+	mapType := reflect.TypeOf(values)
+	mapLocation := redactOptions.root().enterType(mapType, redactOptions)
+
+	redactedValues := make(M, len(values))
+
+	for _, key := range stringKeys(values, redactOptions) {
+		keyLocation := mapLocation.stringKey(key).enterType(mapType.Elem(), redactOptions)
+
+		redactedValues[key] = redactStrings(keyLocation, values[key], mapType.Elem().Elem(), redactOptions)
+	}
+
+	return redactedValues
+}
+
+// RedactBytesMap redacts the values of a map[string][]byte, such as the data of a Kubernetes Secret, without reflecting
+// over the map. Keys are field and key names, so WithAllowList and WithDenyList apply to them. The provided map is not
+// modified, and values kept as-is are copied, so the returned map never shares byte slices with the provided map.
+//
+// Every non-empty value is redacted unless WithAllowList or WithDenyList is provided.
+func RedactBytesMap[M ~map[string][]byte](values M, opts ...Option) M {
+	redactOptions := newOptions(allow, nil, opts)
+	defer redactOptions.recordAuditEvent()
+
+	redactOptions.acquireScratch()
+	defer redactOptions.releaseScratch()
+
+	if values == nil {
+		return nil
+	}
+
+	redactOptions.guardCollisions(reflect.ValueOf(values))
+
+	mapType := reflect.TypeOf(values)
+	mapLocation := redactOptions.root().enterType(mapType, redactOptions)
+
+	redactedValues := make(M, len(values))
+
+	for _, key := range stringKeys(values, redactOptions) {
+		redactedValues[key] = redactOptions.redactBytes(mapLocation.stringKey(key), values[key], mapType.Elem())
+	}
+
+	return redactedValues
+}
+
+func redactStrings[S ~[]string](sliceLocation location, values S, elemType reflect.Type, redactOptions *options) S {
+	if values == nil {
+		return nil
+	}
+
+	redactedValues := make(S, len(values))
+
+	for i, value := range values {
+		redactedValues[i] = redactOptions.redactString(sliceLocation.index(i), value, elemType)
+	}
+
+	return redactedValues
+}
+
+// stringKeys returns the keys of values, sorted when redactions are recorded. See mapKeys.
+func stringKeys[M ~map[string]V, V any](values M, redactOptions *options) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	if redactOptions.recording() {
+		slices.Sort(keys)
+	}
+
+	return keys
+}
+
+// redactString redacts a string value of valueType found at valueLocation the same as redact, without requiring a
+// reflect.Value.
+func (o *options) redactString(valueLocation location, value string, valueType reflect.Type) string {
+	// only redact non-empty string values
+	if value == "" || o.skipsAlreadyRedacted(valueLocation.path, value) {
+		return value
+	}
+
+	valueLocation = valueLocation.enterType(valueType, o)
+
+	if !o.redactsKind(Strings) || !o.shouldRedactValue(valueLocation, value) {
+		if scrubbed, found := o.scrubKnownSecrets(value); found {
+			o.recordRedaction(valueLocation.path, []byte(value), scrubbed)
+
+			value = scrubbed
+		}
+
+		return o.transformString(valueLocation, value)
+	}
+
+	if embedded, ok := o.redactEmbedded(valueLocation, value); ok {
+		return embedded
+	}
+
+	original := []byte(value)
+
+	placeholder := o.placeholderFor(valueLocation, stringKind, original, valueType)
+	o.recordRedaction(valueLocation.path, original, placeholder)
+
+	return placeholder
+}
+
+// redactBytes redacts a byte slice value of valueType found at valueLocation the same as redact, without requiring a
+// reflect.Value. The returned byte slice never shares its backing array with value, but might share it with other
+// placeholders. See placeholderBytes.
+func (o *options) redactBytes(valueLocation location, value []byte, valueType reflect.Type) []byte {
+	// only redact non-empty byte slice values
+	if len(value) == 0 || (o.skipAlreadyRedacted && o.skipsAlreadyRedacted(valueLocation.path, string(value))) {
+		return slices.Clip(slices.Clone(value))
+	}
+
+	valueLocation = valueLocation.enterType(valueType, o)
+
+	if !o.shouldRedactBytesValue(valueLocation, value) || !o.shouldRedactBytes(value) {
+		if len(o.knownSecrets) == 0 {
+			return slices.Clip(slices.Clone(value))
+		}
+
+		scrubbed, found := o.scrubKnownSecrets(string(value))
+		if !found {
+			return slices.Clip(slices.Clone(value))
+		}
+
+		scrubbedBytes := slices.Clip([]byte(scrubbed))
+		o.recordRedaction(valueLocation.path, value, scrubbedBytes)
+
+		return scrubbedBytes
+	}
+
+	placeholder := o.placeholderBytes(o.bytesPlaceholderFor(valueLocation, value, valueType))
+	o.recordRedaction(valueLocation.path, value, placeholder)
+
+	return placeholder
+}
@@ -0,0 +1,289 @@
+package rere_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type labels map[string]string
+
+func TestRedactStringMap(t *testing.T) {
+	t.Parallel()
+
+	input := labels{
+		"app":     "billing",
+		"owner":   "alice@example.com",
+		"empty":   "",
+		"Version": "v1.2.3",
+	}
+
+	testCases := []struct {
+		name   string
+		opts   []rere.Option
+		output labels
+	}{
+		{
+			name: "redacts every value by default",
+			opts: nil,
+			output: labels{
+				"app":     redacted,
+				"owner":   redacted,
+				"empty":   "",
+				"Version": redacted,
+			},
+		},
+		{
+			name: "keeps keys in the allow list",
+			opts: []rere.Option{rere.WithAllowList("app", "version")},
+			output: labels{
+				"app":     "billing",
+				"owner":   redacted,
+				"empty":   "",
+				"Version": "v1.2.3",
+			},
+		},
+		{
+			name: "only redacts keys in the deny list",
+			opts: []rere.Option{rere.WithDenyList("owner")},
+			output: labels{
+				"app":     "billing",
+				"owner":   redacted,
+				"empty":   "",
+				"Version": "v1.2.3",
+			},
+		},
+		{
+			name: "supports placeholder options and transforms",
+			opts: []rere.Option{
+				rere.WithDenyList("owner"),
+				rere.WithTypeNamePlaceholders(),
+				rere.WithTransform(rere.Truncate(3), "app"),
+			},
+			output: labels{
+				"app":     "bil",
+				"owner":   "<redacted string>",
+				"empty":   "",
+				"Version": "v1.2.3",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			output := rere.RedactStringMap(input, testCase.opts...)
+
+			g.Expect(output).To(gomega.Equal(testCase.output), "RedactStringMap should redact map values")
+			g.Expect(output).To(gomega.Equal(rere.RedactWithAllowList(input, nil, testCase.opts...)),
+				"RedactStringMap should match RedactWithAllowList")
+			g.Expect(input["owner"]).To(gomega.Equal("alice@example.com"), "RedactStringMap should not modify the provided map")
+		})
+	}
+}
+
+func TestRedactStringMapReport(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	output := rere.RedactStringMap(map[string]string{"b": "b", "a": "a", "keep": "keep"},
+		rere.WithAllowList("keep"), rere.WithReport(&report))
+
+	g.Expect(output).To(gomega.Equal(map[string]string{"a": redacted, "b": redacted, "keep": "keep"}),
+		"RedactStringMap should redact map values")
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "a", Pointer: "/a", Value: redacted},
+		{Path: "b", Pointer: "/b", Value: redacted},
+	}), "RedactStringMap should record redactions sorted by key")
+	g.Expect(rere.RedactStringMap[map[string]string](nil)).To(gomega.BeNil(), "RedactStringMap should keep nil maps as nil")
+}
+
+func TestRedactStringSlice(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := []string{"alice", "", "bob"}
+
+	g.Expect(rere.RedactStringSlice(input)).To(gomega.Equal([]string{redacted, "", redacted}),
+		"RedactStringSlice should redact every non-empty element by default")
+	g.Expect(rere.RedactStringSlice(input, rere.WithDenyList("alice"))).To(gomega.Equal(input),
+		"RedactStringSlice should keep elements with a deny list")
+	g.Expect(input).To(gomega.Equal([]string{"alice", "", "bob"}), "RedactStringSlice should not modify the provided slice")
+	g.Expect(rere.RedactStringSlice[[]string](nil)).To(gomega.BeNil(), "RedactStringSlice should keep nil slices as nil")
+}
+
+func TestRedactStringSliceMap(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := http.Header{
+		"Authorization": {"Bearer token"},
+		"Accept":        {"text/plain", "application/json"},
+		"Empty":         nil,
+	}
+
+	var report rere.Report
+
+	output := rere.RedactStringSliceMap(input, rere.WithAllowList("accept"), rere.WithReport(&report))
+
+	g.Expect(output).To(gomega.Equal(http.Header{
+		"Authorization": {redacted},
+		"Accept":        {"text/plain", "application/json"},
+		"Empty":         nil,
+	}), "RedactStringSliceMap should redact map values")
+	g.Expect(output).To(gomega.Equal(rere.RedactWithAllowList(input, []string{"accept"})),
+		"RedactStringSliceMap should match RedactWithAllowList")
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "Authorization[0]", Pointer: "/Authorization/0", Value: redacted},
+	}), "RedactStringSliceMap should record redactions")
+	g.Expect(input.Get("Authorization")).To(gomega.Equal("Bearer token"),
+		"RedactStringSliceMap should not modify the provided map")
+}
+
+type secretData map[string][]byte
+
+func TestRedactBytesMap(t *testing.T) {
+	t.Parallel()
+
+	input := secretData{
+		"username": []byte("admin"),
+		"password": []byte("hunter2"),
+		"tls.key":  {0xff, 0xfe, 0x00, 0x01},
+		"empty":    {},
+	}
+
+	testCases := []struct {
+		name   string
+		opts   []rere.Option
+		output secretData
+	}{
+		{
+			name: "redacts every value by default",
+			opts: nil,
+			output: secretData{
+				"username": []byte(redacted),
+				"password": []byte(redacted),
+				"tls.key":  []byte(redacted),
+				"empty":    {},
+			},
+		},
+		{
+			name: "redacts values by key with an allow list",
+			opts: []rere.Option{rere.WithAllowList("username")},
+			output: secretData{
+				"username": []byte("admin"),
+				"password": []byte(redacted),
+				"tls.key":  []byte(redacted),
+				"empty":    {},
+			},
+		},
+		{
+			name: "redacts values by key with a deny list",
+			opts: []rere.Option{rere.WithDenyList("password")},
+			output: secretData{
+				"username": []byte("admin"),
+				"password": []byte(redacted),
+				"tls.key":  {0xff, 0xfe, 0x00, 0x01},
+				"empty":    {},
+			},
+		},
+		{
+			name: "applies byte slice options",
+			opts: []rere.Option{rere.WithBytePrefix(2), rere.WithBinaryBytes(rere.KeepBinary)},
+			output: secretData{
+				"username": []byte("ad" + redacted),
+				"password": []byte("hu" + redacted),
+				"tls.key":  {0xff, 0xfe, 0x00, 0x01},
+				"empty":    {},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			output := rere.RedactBytesMap(input, testCase.opts...)
+
+			g.Expect(output).To(gomega.Equal(testCase.output), "RedactBytesMap should redact map values")
+			g.Expect(output).To(gomega.Equal(rere.Redact(input, testCase.opts...)), "RedactBytesMap should match Redact")
+
+			output["username"][0] = 'X'
+			g.Expect(input["username"]).To(gomega.Equal([]byte("admin")),
+				"RedactBytesMap should not share byte slices with the provided map")
+
+			for key, value := range output {
+				g.Expect(cap(value)).To(gomega.Equal(len(value)), "RedactBytesMap should not carry capacity over for "+key)
+			}
+		})
+	}
+}
+
+func TestRedactBytesMapReport(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	g.Expect(rere.RedactBytesMap(map[string][]byte(nil))).To(gomega.BeNil(), "RedactBytesMap should keep nil maps")
+
+	rere.RedactBytesMap(map[string][]byte{"token": []byte("abc"), "id": []byte("1")},
+		rere.WithDenyList("token"), rere.WithReport(&report))
+
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "token", Pointer: "/token", Value: []byte(redacted)},
+	}), "RedactBytesMap should record redactions")
+}
+
+func BenchmarkRedactBytesMap(b *testing.B) {
+	input := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("hunter2"),
+		"ca.crt":   []byte("-----BEGIN CERTIFICATE-----"),
+	}
+	allowList := []string{"username", "ca.crt"}
+
+	b.Run("RedactBytesMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rere.RedactBytesMap(input, rere.WithAllowList(allowList...))
+		}
+	})
+
+	b.Run("RedactWithAllowList", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rere.RedactWithAllowList(input, allowList)
+		}
+	})
+}
+
+func BenchmarkRedactStringMap(b *testing.B) {
+	input := map[string]string{
+		"Accept":        "application/json",
+		"Authorization": "Bearer token",
+		"User-Agent":    "rere",
+	}
+	allowList := []string{"accept", "user-agent"}
+
+	b.Run("RedactStringMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rere.RedactStringMap(input, rere.WithAllowList(allowList...))
+		}
+	})
+
+	b.Run("RedactWithAllowList", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rere.RedactWithAllowList(input, allowList)
+		}
+	})
+}
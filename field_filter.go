@@ -0,0 +1,23 @@
+package rere
+
+import (
+	"reflect"
+)
+
+// WithFieldFilter only visits struct fields for which filter returns true, so callers can skip fields by tag, type, or
+// name before any rule is consulted, such as every field tagged `json:"-"`. Skipped fields and every value nested
+// inside of them are carried over to the redacted copy as-is without being redacted, and CheckPolicyCoverage doesn't
+// report them.
+//
+// Skipping a field bypasses every rule, including `rere:"redact"` tags, so filters should only skip fields known to be
+// safe or dropped elsewhere, such as fields never serialized.
+func WithFieldFilter(filter func(structField reflect.StructField) bool) Option {
+	return func(o *options) {
+		o.fieldFilter = filter
+	}
+}
+
+// visitsField reports whether structField is visited. See WithFieldFilter and WithJSONSemantics.
+func (o *options) visitsField(structField reflect.StructField) bool {
+	return !o.skipsJSONField(structField) && (o.fieldFilter == nil || o.fieldFilter(structField))
+}
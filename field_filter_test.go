@@ -0,0 +1,62 @@
+package rere_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type sessionState struct {
+	User    string
+	Token   string             `json:"-"`
+	Cache   *credentialDetails `json:"-"`
+	Details credentialDetails
+}
+
+type flatSessionState struct {
+	User  string
+	Token string `json:"-"`
+}
+
+func skipUnserializedFields(structField reflect.StructField) bool {
+	return structField.Tag.Get("json") != "-"
+}
+
+func TestRedactWithFieldFilter(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := sessionState{
+		User:    "alice",
+		Token:   "hunter2",
+		Cache:   &credentialDetails{Name: "cached", Secret: "hunter3"},
+		Details: credentialDetails{Name: "primary", Secret: "hunter4"},
+	}
+
+	redactedInput := rere.RedactWithAllowList(input, []string{"User", "Name"}, rere.WithFieldFilter(skipUnserializedFields))
+
+	g.Expect(redactedInput).To(gomega.Equal(sessionState{
+		User:    "alice",
+		Token:   "hunter2",
+		Cache:   &credentialDetails{Name: "cached", Secret: "hunter3"},
+		Details: credentialDetails{Name: "primary", Secret: redacted},
+	}), "WithFieldFilter should skip filtered fields and every value nested inside of them")
+	g.Expect(redactedInput.Cache).NotTo(gomega.BeIdenticalTo(input.Cache), "skipped fields should still be deep copied")
+
+	g.Expect(rere.RedactWithAllowList(flatSessionState{User: "alice", Token: "hunter2"}, nil,
+		rere.WithFieldFilter(skipUnserializedFields))).To(gomega.Equal(flatSessionState{User: redacted, Token: "hunter2"}),
+		"WithFieldFilter should skip filtered fields of flat structs")
+}
+
+func TestCheckPolicyCoverageWithFieldFilter(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(rere.CheckPolicyCoverage[sessionState](rere.WithAllowList("User", "Name", "Secret"))).To(
+		gomega.MatchError(gomega.ContainSubstring("Token")), "CheckPolicyCoverage should report unfiltered fields")
+	g.Expect(rere.CheckPolicyCoverage[sessionState](rere.WithAllowList("User", "Name", "Secret"),
+		rere.WithFieldFilter(skipUnserializedFields))).To(gomega.Succeed(),
+		"CheckPolicyCoverage should not report filtered fields")
+}
@@ -0,0 +1,158 @@
+package rere
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+//nolint:gochecknoglobals // plans are built once per struct type and shared by every call
+var flatPlans sync.Map
+
+//nolint:gochecknoglobals // types can't be constants
+var stringType = reflect.TypeFor[string]()
+
+// flatField is a field of a flat struct.
+type flatField struct {
+	structField reflect.StructField
+	// isString is true for string fields, which are redacted through their string directly instead of a reflect.Value.
+	// Named string types are redacted through a reflect.Value, so reports record values of their type.
+	isString bool
+}
+
+// flatPlan holds the fields of a flat struct, which only holds strings, booleans, and numbers. Flat structs hold no
+// pointers, so copying one by value is a deep copy, and no value is nested inside of their fields, so they're
+// redacted without recursing.
+type flatPlan struct {
+	fields []flatField
+}
+
+// flatPlanFor returns the plan of valueType, or nil when valueType is not a flat struct.
+func flatPlanFor(valueType reflect.Type) *flatPlan {
+	if plan, found := flatPlans.Load(valueType); found {
+		//nolint:forcetypeassert // only plans are stored
+		return plan.(*flatPlan)
+	}
+
+	plan := newFlatPlan(valueType)
+
+	flatPlans.Store(valueType, plan)
+
+	return plan
+}
+
+func newFlatPlan(valueType reflect.Type) *flatPlan {
+	if valueType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	plan := &flatPlan{
+		fields: make([]flatField, 0, valueType.NumField()),
+	}
+
+	for fieldIndex := 0; fieldIndex < valueType.NumField(); fieldIndex++ {
+		structField := valueType.Field(fieldIndex)
+
+		//nolint:exhaustive // every other kind holds pointers or nested values
+		switch structField.Type.Kind() {
+		case reflect.String,
+			reflect.Bool,
+			reflect.Complex64,
+			reflect.Complex128,
+			reflect.Float32,
+			reflect.Float64,
+			reflect.Int,
+			reflect.Int8,
+			reflect.Int16,
+			reflect.Int32,
+			reflect.Int64,
+			reflect.Uint,
+			reflect.Uint8,
+			reflect.Uint16,
+			reflect.Uint32,
+			reflect.Uint64,
+			reflect.Uintptr:
+			plan.fields = append(plan.fields, flatField{
+				structField: structField,
+				isString:    structField.Type == stringType,
+			})
+		default:
+			return nil
+		}
+	}
+
+	return plan
+}
+
+// redactsFlat reports whether values of valueType are redacted through redactFlat. Stringer boundaries are checked for
+// every value, so flat structs are traversed instead when one is provided.
+func (o *options) redactsFlat(valueType reflect.Type) bool {
+	return o.stringerBoundary == 0 && flatPlanFor(valueType) != nil
+}
+
+// redactFlat redacts the flat struct of valueType stored at structPointer the same as redact, without recursing or
+// converting its string fields to a reflect.Value.
+func (o *options) redactFlat(valueLocation location, structPointer unsafe.Pointer, valueType reflect.Type) {
+	valueLocation = valueLocation.enterType(valueType, o)
+
+	for _, field := range flatPlanFor(valueType).fields {
+		if !o.visitsField(field.structField) {
+			continue
+		}
+
+		fieldPointer := unsafe.Add(structPointer, field.structField.Offset)
+		fieldLocation := valueLocation.field(valueType, field.structField)
+
+		if field.isString {
+			value := (*string)(fieldPointer)
+			*value = o.redactString(fieldLocation, *value, stringType)
+
+			continue
+		}
+
+		redact(fieldLocation, reflect.NewAt(field.structField.Type, fieldPointer), o)
+	}
+}
+
+// redactFlatDynamic redacts value the same as redactValue when it is a pointer to a flat struct, or an interface value
+// holding a flat struct or a pointer to one, such as the values redacted by Policy.Redact, and reports whether it did.
+// The flat struct is copied by value into a new struct, which is redacted in place without traversing it.
+func redactFlatDynamic[T any](value T, redactOptions *options) (T, bool) {
+	if kind := reflect.TypeFor[T]().Kind(); kind != reflect.Pointer && kind != reflect.Interface {
+		return value, false
+	}
+
+	// value is converted to an interface value instead of taking its address, so it doesn't escape to the heap when
+	// it isn't redacted here
+	reflectedValue := reflect.ValueOf(any(value))
+	if !reflectedValue.IsValid() {
+		// nil interfaces are left to redactValue, which copies them as-is
+		return value, false
+	}
+
+	dynamicType := reflectedValue.Type()
+
+	isPointer := reflectedValue.Kind() == reflect.Pointer
+	if isPointer {
+		reflectedValue = reflectedValue.Elem()
+	}
+
+	// nil pointers are left to redactValue the same as nil interfaces
+	if !reflectedValue.IsValid() || !redactOptions.redactsFlat(reflectedValue.Type()) {
+		return value, false
+	}
+
+	deepCopy := reflect.New(reflectedValue.Type())
+	deepCopy.Elem().Set(reflectedValue)
+
+	redactOptions.guardCollisions(deepCopy)
+	redactOptions.redactFlat(redactOptions.root(), deepCopy.UnsafePointer(), reflectedValue.Type())
+	redactOptions.recordAuditEvent()
+
+	if !isPointer {
+		deepCopy = deepCopy.Elem()
+	}
+
+	//nolint:forcetypeassert // the copy has the dynamic type of value
+	return deepCopy.Convert(dynamicType).Interface().(T), true
+}
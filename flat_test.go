@@ -0,0 +1,185 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type requestLog struct {
+	Method    string
+	Path      string
+	Token     string `rere:"redact"`
+	APIKey    apiKey
+	Status    int
+	PIN       int64
+	LatencyMS float64
+	Cached    bool
+	session   string
+}
+
+func TestRedactFlatStructs(t *testing.T) {
+	t.Parallel()
+
+	input := requestLog{
+		Method:    "GET",
+		Path:      "/users/42",
+		Token:     "hunter2",
+		APIKey:    "key-123",
+		Status:    200,
+		PIN:       1234,
+		LatencyMS: 12.5,
+		Cached:    true,
+		session:   "abc",
+	}
+
+	testCases := []struct {
+		name   string
+		opts   []rere.Option
+		output requestLog
+	}{
+		{
+			name: "redacts every string by default",
+			opts: nil,
+			output: requestLog{
+				Method:    redacted,
+				Path:      redacted,
+				Token:     redacted,
+				APIKey:    redacted,
+				Status:    200,
+				PIN:       1234,
+				LatencyMS: 12.5,
+				Cached:    true,
+				session:   redacted,
+			},
+		},
+		{
+			name: "keeps fields in the allow list",
+			opts: []rere.Option{rere.WithAllowList("Method", "path", "Token")},
+			output: requestLog{
+				Method:    "GET",
+				Path:      "/users/42",
+				Token:     redacted,
+				APIKey:    redacted,
+				Status:    200,
+				PIN:       1234,
+				LatencyMS: 12.5,
+				Cached:    true,
+				session:   redacted,
+			},
+		},
+		{
+			name: "zeroes numbers in the deny list",
+			opts: []rere.Option{rere.WithDenyList("PIN", "session")},
+			output: requestLog{
+				Method:    "GET",
+				Path:      "/users/42",
+				Token:     redacted,
+				APIKey:    "key-123",
+				Status:    200,
+				PIN:       0,
+				LatencyMS: 12.5,
+				Cached:    true,
+				session:   redacted,
+			},
+		},
+		{
+			name: "applies path rules, placeholder options, and transforms",
+			opts: []rere.Option{
+				rere.WithDenyList(),
+				rere.WithRedactPaths("APIKey", "Status"),
+				rere.WithLengthHint(),
+				rere.WithTransform(rere.Truncate(3), "Path"),
+			},
+			output: requestLog{
+				Method:    "GET",
+				Path:      "/us",
+				Token:     "REDACTED(7)",
+				APIKey:    "REDACTED(7)",
+				Status:    0,
+				PIN:       1234,
+				LatencyMS: 12.5,
+				Cached:    true,
+				session:   "abc",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			var flatReport, pointerReport, policyReport, traversedReport rere.Report
+
+			redactedInput := rere.Redact(input, append(testCase.opts, rere.WithReport(&flatReport))...)
+			redactedPointer := rere.Redact(&input, append(testCase.opts, rere.WithReport(&pointerReport))...)
+			redactedPolicy := rere.NewPolicy(append(testCase.opts, rere.WithReport(&policyReport))...).Redact(input)
+
+			// pointers to pointers aren't redacted through the flat path, so the flat struct is traversed
+			inputPointer := &input
+			traversedInput := rere.Redact(&inputPointer, append(testCase.opts, rere.WithReport(&traversedReport))...)
+
+			g.Expect(redactedInput).To(gomega.Equal(testCase.output), "Redact should redact flat structs")
+			g.Expect(*redactedPointer).To(gomega.Equal(testCase.output), "Redact should redact pointers to flat structs")
+			g.Expect(redactedPointer).NotTo(gomega.BeIdenticalTo(&input), "Redact should copy pointers to flat structs")
+			g.Expect(redactedPolicy).To(gomega.Equal(testCase.output), "Policy.Redact should redact flat structs")
+			g.Expect(**traversedInput).To(gomega.Equal(redactedInput),
+				"flat structs should be redacted the same as when traversed")
+			g.Expect(flatReport).To(gomega.Equal(traversedReport),
+				"flat structs should record the same redactions as when traversed")
+			g.Expect(pointerReport).To(gomega.Equal(traversedReport),
+				"pointers to flat structs should record the same redactions as when traversed")
+			g.Expect(policyReport).To(gomega.Equal(traversedReport),
+				"Policy.Redact should record the same redactions as when traversed")
+			g.Expect(input.Token).To(gomega.Equal("hunter2"), "Redact should not modify the provided struct")
+		})
+	}
+}
+
+func BenchmarkRedactFlatStruct(b *testing.B) {
+	input := requestLog{
+		Method:    "GET",
+		Path:      "/users/42",
+		Token:     "hunter2",
+		APIKey:    "key-123",
+		Status:    200,
+		PIN:       1234,
+		LatencyMS: 12.5,
+		Cached:    true,
+		session:   "abc",
+	}
+	allowList := []string{"Method", "Path", "Status", "LatencyMS", "Cached"}
+
+	b.Run("FlatStruct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rere.RedactWithAllowList(input, allowList)
+		}
+	})
+
+	b.Run("PointerToFlatStruct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rere.RedactWithAllowList(&input, allowList)
+		}
+	})
+
+	b.Run("PolicyFlatStruct", func(b *testing.B) {
+		policy := rere.NewPolicy(rere.WithAllowList(allowList...))
+
+		for i := 0; i < b.N; i++ {
+			policy.Redact(input)
+		}
+	})
+
+	// pointers to pointers aren't redacted through the flat path, so this measures traversing the flat struct
+	b.Run("TraversedFlatStruct", func(b *testing.B) {
+		inputPointer := &input
+
+		for i := 0; i < b.N; i++ {
+			rere.RedactWithAllowList(&inputPointer, allowList)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+// Package ginmw redacts gin request logs and payloads with rere.
+package ginmw
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/dustinspecker/rere"
+	"github.com/gin-gonic/gin"
+)
+
+// Logger creates a gin middleware logging every request to logger after it is handled. Query parameters and request
+// and response headers are redacted with redactor by name, so an allow list such as []string{"page",
+// "Content-Type"} keeps them while every other value is redacted. The method, path, status, latency, and client IP
+// are logged as-is.
+//
+// Requests are logged at slog.LevelError for 5xx statuses, slog.LevelWarn for 4xx statuses, and slog.LevelInfo
+// otherwise.
+func Logger(logger *slog.Logger, redactor rere.Redactor) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		start := time.Now()
+
+		ginContext.Next()
+
+		status := ginContext.Writer.Status()
+
+		logger.LogAttrs(ginContext.Request.Context(), level(status), "request",
+			slog.String("method", ginContext.Request.Method),
+			slog.String("path", ginContext.Request.URL.Path),
+			slog.String("query", redactQuery(redactor, ginContext.Request.URL.Query())),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", ginContext.ClientIP()),
+			slog.Any("request_headers", redactHeader(redactor, ginContext.Request.Header)),
+			slog.Any("response_headers", redactHeader(redactor, ginContext.Writer.Header())),
+		)
+	}
+}
+
+// H returns a redacted deep copy of payload, such as a gin.H passed to gin.Context.JSON, so payloads can be scrubbed
+// before they are logged or returned.
+func H(redactor rere.Redactor, payload gin.H) gin.H {
+	//nolint:forcetypeassert // rere.Redactor returns values of the same dynamic type
+	return redactor.Redact(payload).(gin.H)
+}
+
+func level(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func redactQuery(redactor rere.Redactor, query url.Values) string {
+	//nolint:forcetypeassert // rere.Redactor returns values of the same dynamic type
+	return redactor.Redact(query).(url.Values).Encode()
+}
+
+func redactHeader(redactor rere.Redactor, header http.Header) http.Header {
+	//nolint:forcetypeassert // rere.Redactor returns values of the same dynamic type
+	return redactor.Redact(header.Clone()).(http.Header)
+}
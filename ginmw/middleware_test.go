@@ -0,0 +1,91 @@
+package ginmw_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/dustinspecker/rere/ginmw"
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/gomega"
+)
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		status int
+		level  string
+	}{
+		{
+			name:   "logs successful requests at info",
+			status: http.StatusOK,
+			level:  "INFO",
+		},
+		{
+			name:   "logs client errors at warn",
+			status: http.StatusNotFound,
+			level:  "WARN",
+		},
+		{
+			name:   "logs server errors at error",
+			status: http.StatusBadGateway,
+			level:  "ERROR",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			var output bytes.Buffer
+
+			policy := rere.NewAllowListPolicy([]string{"page", "Accept", "Content-Type"})
+
+			router := gin.New()
+			router.Use(ginmw.Logger(slog.New(slog.NewJSONHandler(&output, nil)), policy))
+			router.GET("/users", func(ginContext *gin.Context) {
+				ginContext.Header("Set-Cookie", "session=secret")
+				ginContext.JSON(testCase.status, ginmw.H(policy, gin.H{"page": "1", "token": "secret"}))
+			})
+
+			request := httptest.NewRequest(http.MethodGet, "/users?page=2&api_key=secret", nil)
+			request.Header.Set("Accept", "application/json")
+			request.Header.Set("Authorization", "Bearer secret")
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			g.Expect(recorder.Body.String()).To(gomega.MatchJSON(`{"page": "1", "token": "REDACTED"}`),
+				"H should redact payloads")
+
+			var logged map[string]any
+
+			g.Expect(json.Unmarshal(output.Bytes(), &logged)).To(gomega.Succeed(), "Logger should log JSON")
+			g.Expect(logged).To(gomega.HaveKeyWithValue("level", testCase.level), "Logger should log at the status level")
+			g.Expect(logged).To(gomega.HaveKeyWithValue("method", http.MethodGet), "Logger should log the method")
+			g.Expect(logged).To(gomega.HaveKeyWithValue("path", "/users"), "Logger should log the path")
+			g.Expect(logged).To(gomega.HaveKeyWithValue("query", "api_key=REDACTED&page=2"),
+				"Logger should redact query parameters")
+			g.Expect(logged).To(gomega.HaveKeyWithValue("status", float64(testCase.status)), "Logger should log the status")
+			g.Expect(logged).To(gomega.HaveKeyWithValue("request_headers", map[string]any{
+				"Accept":        []any{"application/json"},
+				"Authorization": []any{"REDACTED"},
+			}), "Logger should redact request headers")
+			g.Expect(logged).To(gomega.HaveKeyWithValue("response_headers", map[string]any{
+				"Content-Type": []any{"application/json; charset=utf-8"},
+				"Set-Cookie":   []any{"REDACTED"},
+			}), "Logger should redact response headers")
+			g.Expect(request.Header.Get("Authorization")).To(gomega.Equal("Bearer secret"),
+				"Logger should not modify request headers")
+		})
+	}
+}
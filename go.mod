@@ -1,15 +1,17 @@
 module github.com/dustinspecker/rere
 
-go 1.21.9
+go 1.23.0
 
 require (
+	github.com/fsnotify/fsnotify v1.10.1
 	github.com/onsi/gomega v1.33.1
 	github.com/qdm12/reprint v0.0.0-20200326205758-722754a53494
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/google/go-cmp v0.6.0 // indirect
 	golang.org/x/net v0.24.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/sys v0.19.0 // indirect
 )
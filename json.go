@@ -0,0 +1,122 @@
+package rere
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const jsonTag = "json"
+
+// jsonNumberType is the type of numbers decoded by decodeJSON, which are redacted as numbers instead of strings.
+//
+//nolint:gochecknoglobals // types can't be constants
+var jsonNumberType = reflect.TypeFor[json.Number]()
+
+// RedactedMarshaler is a json.Marshaler emitting the redacted form of a value. See JSON.
+type RedactedMarshaler struct {
+	value any
+	opts  []Option
+}
+
+// JSON wraps value, so it is redacted with opts whenever it is marshaled to JSON. This is useful for values placed in
+// payloads like map[string]any for webhooks and audit events, which are then scrubbed at marshal time automatically.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided.
+func JSON(value any, opts ...Option) RedactedMarshaler {
+	return RedactedMarshaler{
+		value: value,
+		opts:  opts,
+	}
+}
+
+// MarshalJSON marshals a redacted deep copy of the wrapped value.
+func (m RedactedMarshaler) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(m.value, m.opts...)
+}
+
+// MarshalJSON redacts value with opts and returns the JSON encoding of the redacted value. The provided value is not
+// modified.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided.
+func MarshalJSON(value any, opts ...Option) ([]byte, error) {
+	redactOptions := newOptions(allow, nil, opts)
+	redactOptions.startEnvelopes()
+
+	redacted, err := json.Marshal(redactValue(value, redactOptions))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal redacted value: %w", err)
+	}
+
+	return redactOptions.sealEnvelopes(redacted)
+}
+
+// redactJSONText redacts text holding JSON by key name the same as MarshalJSON and returns whether text holds JSON.
+func redactJSONText(text []byte, opts []Option) ([]byte, bool) {
+	decoded, err := decodeJSON(text)
+	if err != nil {
+		return nil, false
+	}
+
+	redacted, err := MarshalJSON(decoded, opts...)
+	if err != nil {
+		return nil, false
+	}
+
+	return redacted, true
+}
+
+// decodeJSON decodes the single JSON value held by data. Numbers are decoded as json.Number instead of float64, so
+// integers too large for a float64, such as IDs, are kept as-is when the value is marshaled again.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errUnexpectedTrailingValues
+	}
+
+	return decoded, nil
+}
+
+// WithJSONSemantics aligns redaction with how values appear in JSON, for policies defined in terms of what appears in
+// JSON logs. Struct fields tagged `json:"-"` are skipped and carried over as-is, the same as with WithFieldFilter, and
+// list entries only match the serialized name of struct fields, such as "user_name" for a field tagged
+// `json:"user_name,omitempty"`, instead of also matching its Go field name and other tag names. Fields without a json
+// tag name are serialized with their Go field name. Empty values are never redacted, so fields omitted by omitempty
+// stay empty either way.
+func WithJSONSemantics() Option {
+	return func(o *options) {
+		o.jsonSemantics = true
+	}
+}
+
+// serializedName returns the name the struct field at valueLocation is serialized with by encoding/json.
+func serializedName(valueLocation location) string {
+	tagValue, found := valueLocation.tag.Lookup(jsonTag)
+	if !found {
+		return valueLocation.name
+	}
+
+	name, _, _ := strings.Cut(tagValue, tagSeparator)
+	if name == "" {
+		return valueLocation.name
+	}
+
+	return name
+}
+
+// skipsJSONField reports whether structField is skipped by WithJSONSemantics, since encoding/json never serializes
+// fields tagged `json:"-"`. Fields tagged `json:"-,"` are serialized with the name "-".
+func (o *options) skipsJSONField(structField reflect.StructField) bool {
+	return o.jsonSemantics && structField.Tag.Get(jsonTag) == "-"
+}
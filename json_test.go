@@ -0,0 +1,155 @@
+package rere_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type jsonUser struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	user := &jsonUser{
+		Username: "alice",
+		Password: "hunter2",
+	}
+
+	payload := map[string]any{
+		"event":   "login",
+		"user":    rere.JSON(user, rere.WithAllowList("username")),
+		"default": rere.JSON(user),
+		"deny":    rere.JSON(user, rere.WithDenyList("password")),
+	}
+
+	marshaled, err := json.Marshal(payload)
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "JSON should marshal")
+	g.Expect(string(marshaled)).To(gomega.MatchJSON(`{
+		"event": "login",
+		"user": {"username": "alice", "password": "REDACTED"},
+		"default": {"username": "REDACTED", "password": "REDACTED"},
+		"deny": {"username": "alice", "password": "REDACTED"}
+	}`), "JSON should marshal the redacted value")
+	g.Expect(user.Password).To(gomega.Equal("hunter2"), "JSON should not modify the provided value")
+
+	_, err = json.Marshal(rere.JSON(func() {}))
+
+	g.Expect(err).To(gomega.HaveOccurred(), "JSON should return marshal errors")
+}
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	user := jsonUser{
+		Username: "alice",
+		Password: "hunter2",
+	}
+
+	marshaled, err := rere.MarshalJSON(user, rere.WithAllowList("username"))
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "MarshalJSON should marshal")
+	g.Expect(string(marshaled)).To(gomega.MatchJSON(`{"username": "alice", "password": "REDACTED"}`),
+		"MarshalJSON should marshal the redacted value")
+	g.Expect(user.Password).To(gomega.Equal("hunter2"), "MarshalJSON should not modify the provided value")
+
+	marshaled, err = rere.MarshalJSON(user)
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "MarshalJSON should marshal")
+	g.Expect(string(marshaled)).To(gomega.MatchJSON(`{"username": "REDACTED", "password": "REDACTED"}`),
+		"MarshalJSON should redact every string by default")
+
+	_, err = rere.MarshalJSON(make(chan string))
+
+	g.Expect(err).To(gomega.HaveOccurred(), "MarshalJSON should return marshal errors")
+}
+
+type jsonLogEntry struct {
+	UserName string            `json:"user_name"`
+	Password string            `json:"pass,omitempty" yaml:"password"`
+	Email    string            `json:",omitempty"`
+	Dash     string            `json:"-,"`
+	Internal string            `json:"-"`
+	Profile  credentialDetails `json:"profile"`
+}
+
+func TestRedactWithJSONSemantics(t *testing.T) {
+	t.Parallel()
+
+	input := jsonLogEntry{
+		UserName: "alice",
+		Password: "hunter2",
+		Email:    "alice@example.com",
+		Dash:     "dash",
+		Internal: "internal secret",
+		Profile:  credentialDetails{Name: "primary", Secret: "hunter3"},
+	}
+
+	testCases := []struct {
+		name      string
+		allowList []string
+		output    jsonLogEntry
+	}{
+		{
+			name:      "matches serialized names",
+			allowList: []string{"user_name", "Email", "-", "Name"},
+			output: jsonLogEntry{
+				UserName: "alice",
+				Password: redacted,
+				Email:    "alice@example.com",
+				Dash:     "dash",
+				Internal: "internal secret",
+				Profile:  credentialDetails{Name: "primary", Secret: redacted},
+			},
+		},
+		{
+			name:      "does not match Go field names and other tag names of renamed fields",
+			allowList: []string{"UserName", "Password", "password", "Dash"},
+			output: jsonLogEntry{
+				UserName: redacted,
+				Password: redacted,
+				Email:    redacted,
+				Dash:     redacted,
+				Internal: "internal secret",
+				Profile:  credentialDetails{Name: redacted, Secret: redacted},
+			},
+		},
+		{
+			name:      "matches serialized names in large lists",
+			allowList: append(generatedEntries("field%d", 100), "pass", "UserName"),
+			output: jsonLogEntry{
+				UserName: redacted,
+				Password: "hunter2",
+				Email:    redacted,
+				Dash:     redacted,
+				Internal: "internal secret",
+				Profile:  credentialDetails{Name: redacted, Secret: redacted},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactWithAllowList(input, testCase.allowList, rere.WithJSONSemantics())).To(
+				gomega.Equal(testCase.output), "WithJSONSemantics should match serialized names and skip json:\"-\" fields")
+
+			policy := rere.NewAllowListPolicy(testCase.allowList, rere.WithJSONSemantics())
+
+			g.Expect(policy.Redact(input)).To(gomega.Equal(testCase.output),
+				"Policy should match serialized names with WithJSONSemantics")
+		})
+	}
+}
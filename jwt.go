@@ -0,0 +1,47 @@
+package rere
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	jwtSegments        = 3
+	jwtSeparator       = "."
+	bearerPrefix       = "Bearer "
+	redactedJWTPayload = redactedMessage + jwtSeparator + redactedMessage
+)
+
+// RedactJWT redacts the payload and signature of a JSON Web Token, such as "eyJhbGciOiJIUzI1NiJ9.REDACTED.REDACTED".
+// If keepHeader is true, the header is kept since it only describes the token, such as its algorithm and key ID,
+// which is useful for debugging. A "Bearer " prefix is kept.
+//
+// Values that are not a JWT with a base64url encoded JSON header are entirely replaced with "REDACTED".
+func RedactJWT(token string, keepHeader bool) string {
+	prefix := ""
+	if strings.HasPrefix(token, bearerPrefix) {
+		prefix = bearerPrefix
+		token = strings.TrimPrefix(token, bearerPrefix)
+	}
+
+	segments := strings.Split(token, jwtSeparator)
+	if len(segments) != jwtSegments || !isJWTHeader(segments[0]) {
+		return prefix + redactedMessage
+	}
+
+	if !keepHeader {
+		return prefix + redactedMessage + jwtSeparator + redactedJWTPayload
+	}
+
+	return prefix + segments[0] + jwtSeparator + redactedJWTPayload
+}
+
+func isJWTHeader(segment string) bool {
+	header, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return false
+	}
+
+	return json.Valid(header)
+}
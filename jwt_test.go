@@ -0,0 +1,67 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactJWT(t *testing.T) {
+	t.Parallel()
+
+	const (
+		header    = "eyJhbGciOiJIUzI1NiIsImtpZCI6ImsxIn0"
+		payload   = "eyJzdWIiOiJhbGljZSJ9"
+		signature = "c2lnbmF0dXJl"
+	)
+
+	testCases := []struct {
+		name       string
+		input      string
+		keepHeader bool
+		output     string
+	}{
+		{
+			name:       "keeps header",
+			input:      header + "." + payload + "." + signature,
+			keepHeader: true,
+			output:     header + ".REDACTED.REDACTED",
+		},
+		{
+			name:       "redacts header",
+			input:      header + "." + payload + "." + signature,
+			keepHeader: false,
+			output:     "REDACTED.REDACTED.REDACTED",
+		},
+		{
+			name:       "keeps bearer prefix",
+			input:      "Bearer " + header + "." + payload + "." + signature,
+			keepHeader: true,
+			output:     "Bearer " + header + ".REDACTED.REDACTED",
+		},
+		{
+			name:       "redacts values that are not a JWT",
+			input:      "not.a.jwt",
+			keepHeader: true,
+			output:     redacted,
+		},
+		{
+			name:       "redacts values with the wrong number of segments",
+			input:      header + "." + payload,
+			keepHeader: true,
+			output:     redacted,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactJWT(testCase.input, testCase.keepHeader)).To(gomega.Equal(testCase.output),
+				"RedactJWT should redact the payload and signature")
+		})
+	}
+}
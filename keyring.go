@@ -0,0 +1,79 @@
+package rere
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// keyIDSeparator separates key IDs from keyed hashes in placeholders, such as "REDACTED:2026-10:9f3a".
+const keyIDSeparator = ":"
+
+var (
+	errMissingPrimaryKey = errors.New("primary key ID is not in keys")
+	errInvalidKeyID      = errors.New("key ID must be non-empty and not contain " + keyIDSeparator)
+)
+
+// Keyring holds keys identified by key IDs, so keys used for keyed hashes can be rotated. New values are hashed with
+// the primary key and placeholders include its key ID, while keys of previous key IDs are kept to recognize values
+// hashed before the primary key was rotated.
+type Keyring struct {
+	primaryKeyID string
+	keys         map[string][]byte
+}
+
+// NewKeyring creates a Keyring from keys keyed by key ID, where primaryKeyID identifies the key used for new values.
+// NewKeyring returns an error when primaryKeyID is not in keys or a key ID is empty or contains ":".
+func NewKeyring(primaryKeyID string, keys map[string][]byte) (*Keyring, error) {
+	for keyID := range keys {
+		if keyID == "" || strings.Contains(keyID, keyIDSeparator) {
+			return nil, fmt.Errorf("%w, got %q", errInvalidKeyID, keyID)
+		}
+	}
+
+	if _, found := keys[primaryKeyID]; !found {
+		return nil, fmt.Errorf("%w: %q", errMissingPrimaryKey, primaryKeyID)
+	}
+
+	return &Keyring{
+		primaryKeyID: primaryKeyID,
+		keys:         maps.Clone(keys),
+	}, nil
+}
+
+// PrimaryKeyID returns the key ID of the key used for new values.
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primaryKeyID
+}
+
+// Key returns the key identified by keyID and whether the Keyring holds it.
+func (k *Keyring) Key(keyID string) ([]byte, bool) {
+	key, found := k.keys[keyID]
+
+	return key, found
+}
+
+// CorrelationSuffixes returns the correlation suffix of original for every key in the Keyring, sorted by key ID, such
+// as "2026-09:1c2d" and "2026-10:9f3a". Searching logs for every suffix finds a known value in records written before
+// and after rotating keys.
+func (k *Keyring) CorrelationSuffixes(original []byte) []string {
+	suffixes := make([]string, 0, len(k.keys))
+
+	for _, keyID := range slices.Sorted(maps.Keys(k.keys)) {
+		suffixes = append(suffixes, keyID+keyIDSeparator+correlationHash(k.keys[keyID], original))
+	}
+
+	return suffixes
+}
+
+// WithCorrelationKeyring hashes correlation suffixes and audit events with the primary key of keyring and includes its
+// key ID in placeholders, such as "REDACTED:2026-10:9f3a", and in audit events. Key IDs make it possible to tell which
+// key produced a suffix after keys are rotated. WithCorrelationKeyring does not enable correlation suffixes on its own.
+func WithCorrelationKeyring(keyring *Keyring) Option {
+	return func(o *options) {
+		o.correlationKey = keyring.keys[keyring.primaryKeyID]
+		o.correlationKeyID = keyring.primaryKeyID
+	}
+}
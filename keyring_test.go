@@ -0,0 +1,104 @@
+package rere_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactWithCorrelationKeyring(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	previousKeyring, err := rere.NewKeyring("2026-09", map[string][]byte{"2026-09": []byte("september key")})
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewKeyring should create a keyring")
+
+	keyring, err := rere.NewKeyring("2026-10", map[string][]byte{
+		"2026-09": []byte("september key"),
+		"2026-10": []byte("october key"),
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewKeyring should create a keyring")
+	g.Expect(keyring.PrimaryKeyID()).To(gomega.Equal("2026-10"), "PrimaryKeyID should return the primary key ID")
+
+	key, found := keyring.Key("2026-09")
+	g.Expect(found).To(gomega.BeTrue(), "Key should find previous keys")
+	g.Expect(key).To(gomega.Equal([]byte("september key")), "Key should return previous keys")
+
+	before := rere.RedactWithAllowList([]string{"token"}, nil,
+		rere.WithCorrelationSuffix(), rere.WithCorrelationKeyring(previousKeyring))
+	after := rere.RedactWithAllowList([]string{"token"}, nil,
+		rere.WithCorrelationSuffix(), rere.WithCorrelationKeyring(keyring))
+
+	g.Expect(before[0]).To(gomega.MatchRegexp(`^REDACTED:2026-09:[0-9a-f]{4}$`), "should include the key ID")
+	g.Expect(after[0]).To(gomega.MatchRegexp(`^REDACTED:2026-10:[0-9a-f]{4}$`), "should use the primary key")
+
+	suffixes := keyring.CorrelationSuffixes([]byte("token"))
+
+	g.Expect(suffixes).To(gomega.HaveLen(2), "CorrelationSuffixes should return a suffix per key")
+	g.Expect("REDACTED:"+suffixes[0]).To(gomega.Equal(before[0]), "suffixes should match values before rotating keys")
+	g.Expect("REDACTED:"+suffixes[1]).To(gomega.Equal(after[0]), "suffixes should match values after rotating keys")
+}
+
+func TestRedactWithCorrelationKeyringAuditSink(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	keyring, err := rere.NewKeyring("v2", map[string][]byte{"v2": []byte("key")})
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewKeyring should create a keyring")
+
+	var buffer bytes.Buffer
+
+	rere.RedactWithAllowList(credentialDetails{Name: "alice", Secret: "hunter2"}, []string{"name"},
+		rere.WithAuditSink(rere.NewJSONAuditSink(&buffer)), rere.WithCorrelationKeyring(keyring))
+
+	var event rere.AuditEvent
+
+	g.Expect(json.Unmarshal(buffer.Bytes(), &event)).To(gomega.Succeed(), "JSONAuditSink should write JSON")
+	g.Expect(event.Redactions).To(gomega.HaveLen(1), "events should include redactions")
+	g.Expect(event.Redactions[0].KeyID).To(gomega.Equal("v2"), "events should include the key ID")
+}
+
+func TestNewKeyringErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		primaryKeyID string
+		keys         map[string][]byte
+		err          string
+	}{
+		{
+			name:         "rejects missing primary keys",
+			primaryKeyID: "v2",
+			keys:         map[string][]byte{"v1": []byte("key")},
+			err:          `primary key ID is not in keys: "v2"`,
+		},
+		{
+			name:         "rejects key IDs containing separators",
+			primaryKeyID: "v:1",
+			keys:         map[string][]byte{"v:1": []byte("key")},
+			err:          `key ID must be non-empty and not contain :, got "v:1"`,
+		},
+		{
+			name:         "rejects empty key IDs",
+			primaryKeyID: "",
+			keys:         map[string][]byte{"": []byte("key")},
+			err:          `key ID must be non-empty and not contain :, got ""`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			_, err := rere.NewKeyring(testCase.primaryKeyID, testCase.keys)
+
+			g.Expect(err).To(gomega.MatchError(testCase.err), "NewKeyring should return an error")
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package rere
+
+// Kind is a kind of value redacted with a placeholder. Kinds can be combined, such as Strings | Bytes.
+type Kind int
+
+const (
+	// Strings are string values, including named string types.
+	Strings Kind = 1 << iota
+	// Bytes are byte slice and byte array values, including named byte slice types.
+	Bytes
+)
+
+// WithKinds restricts redaction with placeholders to kinds, so other kinds are left as-is. Both Strings and Bytes are
+// redacted by default. For example, WithKinds(Strings) never touches byte slices, which is useful when byte slices are
+// always binary telemetry.
+func WithKinds(kinds ...Kind) Option {
+	return func(o *options) {
+		o.kinds = 0
+
+		for _, kind := range kinds {
+			o.kinds |= kind
+		}
+	}
+}
+
+// redactsKind reports whether values of kind are redacted. See WithKinds.
+func (o *options) redactsKind(kind Kind) bool {
+	return o.kinds&kind != 0
+}
@@ -0,0 +1,84 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type telemetry struct {
+	Message string
+	Payload []byte
+	Digest  [4]byte
+}
+
+func TestRedactWithKinds(t *testing.T) {
+	t.Parallel()
+
+	input := telemetry{
+		Message: "message",
+		Payload: []byte{0x01, 0x02},
+		Digest:  [4]byte{0x01, 0x02, 0x03, 0x04},
+	}
+
+	testCases := []struct {
+		name   string
+		opts   []rere.Option
+		output telemetry
+	}{
+		{
+			name: "redacts strings and bytes by default",
+			opts: nil,
+			output: telemetry{
+				Message: redacted,
+				Payload: []byte(redacted),
+				Digest:  [4]byte{'R', 'E', 'D', 'A'},
+			},
+		},
+		{
+			name: "only redacts strings",
+			opts: []rere.Option{rere.WithKinds(rere.Strings)},
+			output: telemetry{
+				Message: redacted,
+				Payload: []byte{0x01, 0x02},
+				Digest:  [4]byte{0x01, 0x02, 0x03, 0x04},
+			},
+		},
+		{
+			name: "only redacts bytes",
+			opts: []rere.Option{rere.WithKinds(rere.Bytes)},
+			output: telemetry{
+				Message: "message",
+				Payload: []byte(redacted),
+				Digest:  [4]byte{'R', 'E', 'D', 'A'},
+			},
+		},
+		{
+			name:   "redacts nothing without kinds",
+			opts:   []rere.Option{rere.WithKinds()},
+			output: input,
+		},
+		{
+			name: "combines kinds",
+			opts: []rere.Option{rere.WithKinds(rere.Strings | rere.Bytes)},
+			output: telemetry{
+				Message: redacted,
+				Payload: []byte(redacted),
+				Digest:  [4]byte{'R', 'E', 'D', 'A'},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactWithAllowList(input, nil, testCase.opts...)).To(gomega.Equal(testCase.output),
+				"WithKinds should restrict the kinds redacted")
+		})
+	}
+}
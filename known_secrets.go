@@ -0,0 +1,92 @@
+package rere
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// KnownSecretsDetector creates a Detector matching every occurrence of secrets in text, such as the API keys and
+// passwords an application loads at startup. Empty secrets are ignored.
+func KnownSecretsDetector(secrets ...string) Detector {
+	secrets = slices.DeleteFunc(slices.Clone(secrets), func(secret string) bool {
+		return secret == ""
+	})
+
+	return DetectorFunc(func(text string) [][]int {
+		var matches [][]int
+
+		for _, secret := range secrets {
+			for offset := 0; ; {
+				index := strings.Index(text[offset:], secret)
+				if index == -1 {
+					break
+				}
+
+				start := offset + index
+				matches = append(matches, []int{start, start + len(secret)})
+
+				offset = start + len(secret)
+			}
+		}
+
+		return matches
+	})
+}
+
+// WithKnownSecrets replaces every occurrence of secrets in string and []byte values kept by rules with the placeholder
+// provided to WithPlaceholder, including occurrences inside of larger values, such as an API key loaded at startup
+// echoed in an error message. Occurrences are replaced without length hints or other placeholder decorations, while
+// values redacted by rules are replaced entirely as usual. Values holding secrets are recorded by WithReport.
+//
+// Map keys are not scrubbed, and byte arrays are truncated or padded with zero bytes to keep their length.
+func WithKnownSecrets(secrets ...string) Option {
+	detector := KnownSecretsDetector(secrets...)
+
+	return func(o *options) {
+		o.knownSecrets = []Detector{detector}
+	}
+}
+
+// scrubKnownSecrets returns text with every occurrence of a secret provided to WithKnownSecrets replaced with the
+// placeholder and whether any occurrence was found.
+func (o *options) scrubKnownSecrets(text string) (string, bool) {
+	if len(o.knownSecrets) == 0 {
+		return text, false
+	}
+
+	matches := detect(text, o.knownSecrets)
+	if len(matches) == 0 {
+		return text, false
+	}
+
+	return replaceMatches(text, matches, o.placeholderMessage+o.placeholderNonce), true
+}
+
+// scrubKnownSecretsValue is the same as scrubKnownSecrets for a string, byte slice, or byte array value kept at
+// valueLocation, recording the replaced value.
+func (o *options) scrubKnownSecretsValue(valueLocation location, value reflect.Value) {
+	if len(o.knownSecrets) == 0 {
+		return
+	}
+
+	var original []byte
+	if value.Kind() == reflect.String {
+		original = []byte(value.String())
+	} else {
+		original = slices.Clone(byteValues(value))
+	}
+
+	scrubbed, found := o.scrubKnownSecrets(string(original))
+	if !found {
+		return
+	}
+
+	if value.Kind() == reflect.String {
+		value.SetString(scrubbed)
+	} else {
+		setBytes(value, slices.Clip([]byte(scrubbed)))
+	}
+
+	o.recordRedaction(valueLocation.path, original, value.Interface())
+}
@@ -0,0 +1,128 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type deployEvent struct {
+	Service string
+	Message string
+	Output  []byte
+	APIKey  string
+	Labels  map[string]string
+}
+
+func TestRedactWithKnownSecrets(t *testing.T) {
+	t.Parallel()
+
+	input := deployEvent{
+		Service: "billing",
+		Message: "request with key sk-live-123 failed, retrying with sk-live-123",
+		Output:  []byte("password=hunter2\n"),
+		APIKey:  "sk-live-123",
+		Labels:  map[string]string{"note": "rotated hunter2"},
+	}
+
+	testCases := []struct {
+		name   string
+		redact func(deployEvent) deployEvent
+		output deployEvent
+	}{
+		{
+			name: "replaces known secrets inside of kept values",
+			redact: func(value deployEvent) deployEvent {
+				return rere.RedactWithDenyList(value, []string{"APIKey"}, rere.WithKnownSecrets("sk-live-123", "hunter2", ""))
+			},
+			output: deployEvent{
+				Service: "billing",
+				Message: "request with key REDACTED failed, retrying with REDACTED",
+				Output:  []byte("password=REDACTED\n"),
+				APIKey:  redacted,
+				Labels:  map[string]string{"note": "rotated REDACTED"},
+			},
+		},
+		{
+			name: "replaces known secrets with the placeholder",
+			redact: func(value deployEvent) deployEvent {
+				return rere.RedactWithAllowList(value, []string{"Service", "Message", "Output", "note"},
+					rere.WithKnownSecrets("hunter2", "sk-live-123"), rere.WithPlaceholder("***"), rere.WithLengthHint())
+			},
+			output: deployEvent{
+				Service: "billing",
+				Message: "request with key *** failed, retrying with ***",
+				Output:  []byte("password=***\n"),
+				APIKey:  "***(11)",
+				Labels:  map[string]string{"note": "rotated ***"},
+			},
+		},
+		{
+			name: "replaces overlapping known secrets once",
+			redact: func(value deployEvent) deployEvent {
+				return rere.RedactWithDenyList(value, nil, rere.WithKnownSecrets("sk-live", "live-123"))
+			},
+			output: deployEvent{
+				Service: "billing",
+				Message: "request with key REDACTED failed, retrying with REDACTED",
+				Output:  []byte("password=hunter2\n"),
+				APIKey:  redacted,
+				Labels:  map[string]string{"note": "rotated hunter2"},
+			},
+		},
+		{
+			name: "replaces known secrets with fast paths",
+			redact: func(value deployEvent) deployEvent {
+				value.Labels = rere.RedactStringMap(value.Labels, rere.WithDenyList(), rere.WithKnownSecrets("hunter2"))
+
+				return value
+			},
+			output: deployEvent{
+				Service: "billing",
+				Message: input.Message,
+				Output:  input.Output,
+				APIKey:  "sk-live-123",
+				Labels:  map[string]string{"note": "rotated REDACTED"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact(input)).To(gomega.Equal(testCase.output),
+				"WithKnownSecrets should replace every occurrence of known secrets")
+		})
+	}
+}
+
+func TestRedactWithKnownSecretsReport(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	redactedBytes := rere.RedactBytesMap(map[string][]byte{"log": []byte("token hunter2"), "name": []byte("billing")},
+		rere.WithDenyList(), rere.WithKnownSecrets("hunter2"), rere.WithReport(&report))
+
+	g.Expect(redactedBytes).To(gomega.Equal(map[string][]byte{"log": []byte("token REDACTED"), "name": []byte("billing")}),
+		"RedactBytesMap should replace known secrets")
+	g.Expect(cap(redactedBytes["log"])).To(gomega.Equal(len(redactedBytes["log"])),
+		"scrubbed byte slices should not have capacity beyond their length")
+	g.Expect(report.Redactions).To(gomega.HaveLen(1), "WithReport should record values holding known secrets")
+	g.Expect(report.Redactions[0].Path).To(gomega.Equal("log"), "WithReport should record the path of scrubbed values")
+}
+
+func TestKnownSecretsDetector(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(rere.RedactText("user alice logged in with hunter2 and hunter2", rere.KnownSecretsDetector("hunter2"))).To(
+		gomega.Equal("user alice logged in with REDACTED and REDACTED"),
+		"KnownSecretsDetector should match every occurrence of known secrets")
+}
@@ -0,0 +1,36 @@
+package rere
+
+// RedactWithAllowAndDenyLists redacts values the same as RedactWithAllowList with allowList, except that field and key
+// names in denyList are always redacted, even when they are also in allowList. Deny wins, so an allow list can keep a
+// name such as "Token" for most types while the deny list force-redacts a field sharing that name elsewhere, such as
+// "auth.Session:Token". Entries of both lists are matched the same as entries of RedactWithAllowList.
+func RedactWithAllowAndDenyLists[T any](value T, allowList, denyList []string, opts ...Option) T {
+	return redactValue(value, newListOptions(WithAllowAndDenyLists(allowList, denyList), opts))
+}
+
+// WithAllowAndDenyLists redacts every string and []byte field and key value except for field and key names in
+// allowList, while always redacting field and key names in denyList, the same as RedactWithAllowAndDenyLists.
+// WithAllowAndDenyLists is useful for functions that only accept options, such as Redact. If multiple WithAllowList,
+// WithDenyList, or WithAllowAndDenyLists options are provided, the last one is used.
+func WithAllowAndDenyLists(allowList, denyList []string) Option {
+	return func(o *options) {
+		o.mode = allow
+		o.fieldKeyNameList = allowList
+		o.overrideDenyList = denyList
+	}
+}
+
+// findNameEntry returns the entry of the deny list provided to WithAllowAndDenyLists or the field and key name list
+// matching the value at valueLocation, and whether the value is redacted by it. Entries of the deny list take priority.
+func (o *options) findNameEntry(valueLocation location) (string, bool, bool) {
+	if entry, denied := o.firstEntry(o.overrideDenyList, valueLocation); denied {
+		return entry, true, true
+	}
+
+	if entry, inList := o.findEntry(valueLocation); inList {
+		// skip redacting fields in the allow list and redact fields in the deny list
+		return entry, o.mode == deny, true
+	}
+
+	return "", false, false
+}
@@ -0,0 +1,108 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type sessionToken struct {
+	Token string
+	Owner string
+}
+
+type apiToken struct {
+	Token   string
+	Scope   string
+	Session sessionToken
+}
+
+func TestRedactWithAllowAndDenyLists(t *testing.T) {
+	t.Parallel()
+
+	input := apiToken{
+		Token:   "public-token",
+		Scope:   "read",
+		Session: sessionToken{Token: "session-secret", Owner: "alice"},
+	}
+
+	testCases := []struct {
+		name   string
+		redact func() apiToken
+		output apiToken
+	}{
+		{
+			name: "deny list entries win over allow list entries",
+			redact: func() apiToken {
+				return rere.RedactWithAllowAndDenyLists(input, []string{"Token", "Scope", "Owner"},
+					[]string{"rere_test.sessionToken:Token"})
+			},
+			output: apiToken{
+				Token:   "public-token",
+				Scope:   "read",
+				Session: sessionToken{Token: redacted, Owner: "alice"},
+			},
+		},
+		{
+			name: "names in neither list are redacted",
+			redact: func() apiToken {
+				return rere.RedactWithAllowAndDenyLists(input, []string{"Token"}, []string{"Scope"})
+			},
+			output: apiToken{
+				Token:   "public-token",
+				Scope:   redacted,
+				Session: sessionToken{Token: "session-secret", Owner: redacted},
+			},
+		},
+		{
+			name: "options configure both lists",
+			redact: func() apiToken {
+				return rere.Redact(input, rere.WithAllowAndDenyLists([]string{"*"}, []string{"/Sc.*/"}))
+			},
+			output: apiToken{
+				Token:   "public-token",
+				Scope:   redacted,
+				Session: sessionToken{Token: "session-secret", Owner: "alice"},
+			},
+		},
+		{
+			name: "later list options replace both lists",
+			redact: func() apiToken {
+				return rere.Redact(input,
+					rere.WithAllowAndDenyLists([]string{"Token", "Scope", "Owner"}, []string{"Token"}),
+					rere.WithAllowList("Token"))
+			},
+			output: apiToken{
+				Token:   "public-token",
+				Scope:   redacted,
+				Session: sessionToken{Token: "session-secret", Owner: redacted},
+			},
+		},
+		{
+			name: "policies use both lists",
+			redact: func() apiToken {
+				policy := rere.NewPolicy(rere.WithAllowAndDenyLists([]string{"Token", "Scope", "Owner"}, []string{"Owner"}))
+
+				redactedToken, _ := policy.Redact(input).(apiToken)
+
+				return redactedToken
+			},
+			output: apiToken{
+				Token:   "public-token",
+				Scope:   "read",
+				Session: sessionToken{Token: "session-secret", Owner: redacted},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output), "deny list entries should win over allow list entries")
+		})
+	}
+}
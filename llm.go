@@ -0,0 +1,89 @@
+package rere
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultLLMContentKeys returns the keys holding message content in common LLM API request and response payloads, such
+// as OpenAI chat completions and responses and Anthropic messages. See RedactLLMPayload.
+func DefaultLLMContentKeys() []string {
+	return []string{
+		"arguments",
+		"completion",
+		"content",
+		"input",
+		"instructions",
+		"output_text",
+		"partial_json",
+		"prompt",
+		"system",
+		"text",
+		"thinking",
+	}
+}
+
+// RedactLLMPayload redacts the message content of an LLM API request or response payload, such as an OpenAI or
+// Anthropic chat request, with detectors provided to WithTextDetectors, so prompts and completions can be logged
+// without the PII and credentials users paste into them. SecretFormatDetector, QueryStringDetector, and
+// URLCredentialsDetector are used when no detectors are provided. Every string held by a key in DefaultLLMContentKeys,
+// such as "content" and "text", is scrubbed, including strings nested in content blocks and tool call arguments. Keys
+// are matched case insensitively.
+//
+// Roles, models, token counts, and other metadata are kept as-is, unlike the rest of rere, since they're needed to
+// troubleshoot LLM calls. Provide WithDenyList to redact metadata by name, such as "user" or "user_id", or
+// WithAllowList to redact every field not in the allow list. Content keys must be in an allow list for their content to
+// be scrubbed instead of replaced.
+//
+// RedactLLMPayload returns an error when payload isn't JSON. Keys of the returned JSON are sorted, and numbers are kept
+// as-is.
+func RedactLLMPayload(payload []byte, opts ...Option) ([]byte, error) {
+	decoded, err := decodeJSON(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode LLM payload: %w", err)
+	}
+
+	redactOptions := newOptions(deny, nil, opts)
+	scrubber := llmContentScrubber{contentKeys: DefaultLLMContentKeys(), detectors: redactOptions.freeTextDetectors()}
+
+	redacted := scrubber.scrub(redactValue(decoded, redactOptions), false)
+
+	encoded, err := json.Marshal(redacted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode LLM payload: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// llmContentScrubber scrubs the strings of decoded LLM API payloads held by content keys with detectors.
+type llmContentScrubber struct {
+	contentKeys []string
+	detectors   []Detector
+}
+
+// scrub scrubs every string in value held by a content key. inContent is true when value is held by a content key.
+func (s llmContentScrubber) scrub(value any, inContent bool) any {
+	switch typedValue := value.(type) {
+	case string:
+		if inContent {
+			return RedactText(typedValue, s.detectors...)
+		}
+	case []any:
+		for index, element := range typedValue {
+			typedValue[index] = s.scrub(element, inContent)
+		}
+	case map[string]any:
+		for key, element := range typedValue {
+			isContentKey := slices.ContainsFunc(s.contentKeys, func(contentKey string) bool {
+				return strings.EqualFold(contentKey, key)
+			})
+
+			typedValue[key] = s.scrub(element, inContent || isContentKey)
+		}
+	}
+
+	return value
+}
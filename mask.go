@@ -0,0 +1,83 @@
+package rere
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	maskCharacter = '*'
+
+	creditCardVisibleDigits = 4
+	phoneVisibleDigits      = 4
+	ibanVisiblePrefix       = 4
+	ibanVisibleSuffix       = 4
+)
+
+// MaskEmail masks the local part of an email address except for its first character, such as
+// "a****@example.com" for "alice@example.com". The domain is kept so the provider is still known. Values without an
+// "@" are masked except for their first character.
+func MaskEmail(email string) string {
+	localPart, domain, found := strings.Cut(email, "@")
+
+	maskedLocalPart := maskRunes(localPart, 1, 0, func(rune) bool { return true })
+	if !found {
+		return maskedLocalPart
+	}
+
+	return maskedLocalPart + "@" + domain
+}
+
+// MaskCreditCard masks every digit of a credit card number except for the last four, such as
+// "**** **** **** 1111" for "4111 1111 1111 1111". Separators such as spaces and dashes are kept.
+func MaskCreditCard(number string) string {
+	return maskRunes(number, 0, creditCardVisibleDigits, unicode.IsDigit)
+}
+
+// MaskPhone masks every digit of a phone number except for the last four, such as "+* (***) ***-4567" for
+// "+1 (555) 123-4567". Formatting characters are kept.
+func MaskPhone(number string) string {
+	return maskRunes(number, 0, phoneVisibleDigits, unicode.IsDigit)
+}
+
+// MaskIBAN masks every letter and digit of an IBAN except for the country code and check digits at the start and
+// the last four characters, such as "DE89 **** **** **** **30 00" for "DE89 3704 0044 0532 0130 00". Spaces are kept.
+func MaskIBAN(iban string) string {
+	return maskRunes(iban, ibanVisiblePrefix, ibanVisibleSuffix, func(character rune) bool {
+		return unicode.IsLetter(character) || unicode.IsDigit(character)
+	})
+}
+
+// maskRunes replaces every rune in value that isMaskable reports true for with maskCharacter, except for the first
+// visiblePrefix and last visibleSuffix maskable runes. Other runes are kept as-is.
+func maskRunes(value string, visiblePrefix, visibleSuffix int, isMaskable func(rune) bool) string {
+	maskableCount := 0
+
+	for _, character := range value {
+		if isMaskable(character) {
+			maskableCount++
+		}
+	}
+
+	var builder strings.Builder
+
+	maskableIndex := 0
+
+	for _, character := range value {
+		if !isMaskable(character) {
+			builder.WriteRune(character)
+
+			continue
+		}
+
+		if maskableIndex < visiblePrefix || maskableIndex >= maskableCount-visibleSuffix {
+			builder.WriteRune(character)
+		} else {
+			builder.WriteRune(maskCharacter)
+		}
+
+		maskableIndex++
+	}
+
+	return builder.String()
+}
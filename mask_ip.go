@@ -0,0 +1,97 @@
+package rere
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultIPv4Bits = 24
+	defaultIPv6Bits = 48
+)
+
+// IPMask configures how many leading bits of an IP address are kept when masking.
+type IPMask struct {
+	IPv4Bits int
+	IPv6Bits int
+}
+
+// DefaultIPMask keeps the first 24 bits of IPv4 addresses and the first 48 bits of IPv6 addresses.
+//
+//nolint:gochecknoglobals // structs can't be constants
+var DefaultIPMask = IPMask{
+	IPv4Bits: defaultIPv4Bits,
+	IPv6Bits: defaultIPv6Bits,
+}
+
+// MaskIP masks ip with DefaultIPMask. See IPMask.MaskIP.
+func MaskIP(ip string) string {
+	return DefaultIPMask.MaskIP(ip)
+}
+
+// MaskAddr masks addr with DefaultIPMask. See IPMask.MaskAddr.
+func MaskAddr(addr netip.Addr) netip.Addr {
+	return DefaultIPMask.MaskAddr(addr)
+}
+
+// MaskIP zeroes the bits of ip after the configured number of leading bits, such as "192.168.1.0" for
+// "192.168.1.42" and "2001:db8:85a3::" for "2001:db8:85a3:8d3:1319:8a2e:370:7348".
+//
+// IPv6 zones are kept and IPv4-mapped IPv6 addresses are masked as IPv4 addresses. Prefixes such as "10.1.2.3/16"
+// are masked to the fewer of the prefix's bits and the configured bits, keeping the prefix length. Values that
+// can't be parsed are replaced with "REDACTED".
+func (m IPMask) MaskIP(ip string) string {
+	if strings.Contains(ip, "/") {
+		prefix, err := netip.ParsePrefix(ip)
+		if err != nil {
+			return redactedMessage
+		}
+
+		masked := m.maskBits(prefix.Addr(), prefix.Bits())
+
+		return masked.String() + "/" + strconv.Itoa(prefix.Bits())
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return redactedMessage
+	}
+
+	return m.MaskAddr(addr).String()
+}
+
+// MaskAddr zeroes the bits of addr after the configured number of leading bits. See IPMask.MaskIP.
+func (m IPMask) MaskAddr(addr netip.Addr) netip.Addr {
+	return m.maskBits(addr, addr.BitLen())
+}
+
+// maskBits zeroes the bits of addr after the fewer of maxBits and the configured bits.
+func (m IPMask) maskBits(addr netip.Addr, maxBits int) netip.Addr {
+	if !addr.IsValid() {
+		return addr
+	}
+
+	if addr.Is4In6() {
+		const mappedPrefixBits = 96
+
+		unmapped := m.maskBits(addr.Unmap(), max(maxBits-mappedPrefixBits, 0))
+
+		return netip.AddrFrom16(unmapped.As16()).WithZone(addr.Zone())
+	}
+
+	bits := m.IPv6Bits
+	if addr.Is4() {
+		bits = m.IPv4Bits
+	}
+
+	bits = max(min(bits, maxBits, addr.BitLen()), 0)
+
+	// netip.Prefix drops zones, so restore the zone after masking
+	prefix, err := addr.WithZone("").Prefix(bits)
+	if err != nil {
+		return netip.Addr{}
+	}
+
+	return prefix.Addr().WithZone(addr.Zone())
+}
@@ -0,0 +1,52 @@
+package rere_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestMaskIP(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		mask   rere.IPMask
+		input  string
+		output string
+	}{
+		{name: "ipv4", mask: rere.DefaultIPMask, input: "192.168.1.42", output: "192.168.1.0"},
+		{name: "ipv6", mask: rere.DefaultIPMask, input: "2001:db8:85a3:8d3:1319:8a2e:370:7348", output: "2001:db8:85a3::"},
+		{name: "ipv6 zone", mask: rere.DefaultIPMask, input: "fe80::1ff:fe23:4567:890a%eth0", output: "fe80::%eth0"},
+		{name: "ipv4-mapped ipv6", mask: rere.DefaultIPMask, input: "::ffff:192.168.1.42", output: "::ffff:192.168.1.0"},
+		{name: "ipv4 prefix", mask: rere.DefaultIPMask, input: "10.1.2.3/16", output: "10.1.0.0/16"},
+		{name: "ipv4 prefix longer than mask", mask: rere.DefaultIPMask, input: "10.1.2.3/30", output: "10.1.2.0/30"},
+		{name: "ipv6 prefix", mask: rere.DefaultIPMask, input: "2001:db8:85a3:8d3::1/64", output: "2001:db8:85a3::/64"},
+		{name: "custom bits", mask: rere.IPMask{IPv4Bits: 16, IPv6Bits: 32}, input: "192.168.1.42", output: "192.168.0.0"},
+		{name: "custom ipv6 bits", mask: rere.IPMask{IPv4Bits: 16, IPv6Bits: 32}, input: "2001:db8:85a3::1", output: "2001:db8::"},
+		{name: "invalid", mask: rere.DefaultIPMask, input: "not an ip", output: redacted},
+		{name: "invalid prefix", mask: rere.DefaultIPMask, input: "10.1.2.3/99", output: redacted},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.mask.MaskIP(testCase.input)).To(gomega.Equal(testCase.output), "MaskIP should mask the IP")
+		})
+	}
+}
+
+func TestMaskAddr(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(rere.MaskIP("192.168.1.42")).To(gomega.Equal("192.168.1.0"), "MaskIP should use the default mask")
+	g.Expect(rere.MaskAddr(netip.MustParseAddr("fe80::1%eth0"))).To(gomega.Equal(netip.MustParseAddr("fe80::%eth0")),
+		"MaskAddr should use the default mask and keep zones")
+	g.Expect(rere.MaskAddr(netip.Addr{})).To(gomega.Equal(netip.Addr{}), "MaskAddr should return invalid addresses as-is")
+}
@@ -0,0 +1,39 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestMaskHelpers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		mask   func(string) string
+		input  string
+		output string
+	}{
+		{name: "email", mask: rere.MaskEmail, input: "alice@example.com", output: "a****@example.com"},
+		{name: "email without at sign", mask: rere.MaskEmail, input: "alice", output: "a****"},
+		{name: "empty email", mask: rere.MaskEmail, input: "", output: ""},
+		{name: "credit card", mask: rere.MaskCreditCard, input: "4111 1111 1111 1111", output: "**** **** **** 1111"},
+		{name: "credit card with dashes", mask: rere.MaskCreditCard, input: "4111-1111-1111-1111", output: "****-****-****-1111"},
+		{name: "short credit card", mask: rere.MaskCreditCard, input: "123", output: "123"},
+		{name: "phone", mask: rere.MaskPhone, input: "+1 (555) 123-4567", output: "+* (***) ***-4567"},
+		{name: "iban", mask: rere.MaskIBAN, input: "DE89 3704 0044 0532 0130 00", output: "DE89 **** **** **** **30 00"},
+		{name: "iban without spaces", mask: rere.MaskIBAN, input: "GB82WEST12345698765432", output: "GB82**************5432"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.mask(testCase.input)).To(gomega.Equal(testCase.output), "mask helpers should mask the input")
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package rere
+
+import (
+	"strings"
+	"unicode"
+)
+
+// nameIndexThreshold is the length of a field and key name list from which a Policy finds entries through a nameIndex
+// instead of scanning the list for every value. Building an index costs more than scanning a short list.
+const nameIndexThreshold = 16
+
+// nameIndex finds the entries of a field and key name list matching a name without scanning the whole list, which
+// matters for policies with hundreds or thousands of entries, such as ones generated from schemas.
+type nameIndex struct {
+	// folded holds entries in list order keyed by their folded field or key name.
+	folded map[string][]string
+	// spellings holds the same entries keyed by field or key names as written in entries, so names spelled the same as
+	// an entry are found without folding them.
+	spellings map[string][]string
+	// patterns holds pattern entries in list order, which might match any name, so they are always scanned.
+	patterns []string
+	// folding folds names, the same as the options the index is built for.
+	folding nameFolding
+}
+
+func newNameIndex(entries []string, folding nameFolding) *nameIndex {
+	index := &nameIndex{
+		folded:    map[string][]string{},
+		spellings: map[string][]string{},
+		patterns:  nil,
+		folding:   folding,
+	}
+
+	for _, entry := range entries {
+		if isPatternEntry(entry) {
+			index.patterns = append(index.patterns, entry)
+
+			continue
+		}
+
+		for _, name := range entryNames(entry) {
+			foldedName := folding.fold(name)
+			index.folded[foldedName] = append(index.folded[foldedName], entry)
+		}
+	}
+
+	for _, entry := range entries {
+		if isPatternEntry(entry) {
+			continue
+		}
+
+		for _, name := range entryNames(entry) {
+			index.spellings[name] = index.folded[folding.fold(name)]
+		}
+	}
+
+	return index
+}
+
+// candidates returns the entries that might match name, in list order.
+func (i *nameIndex) candidates(name string) []string {
+	if entries, found := i.spellings[name]; found {
+		return entries
+	}
+
+	return i.folded[i.folding.fold(name)]
+}
+
+// entryName returns the field or key name of a list entry, without the type of a type-scoped entry.
+func entryName(entry string) string {
+	if _, name, typeScoped := typeScope(entry); typeScoped {
+		return name
+	}
+
+	return entry
+}
+
+// entryNames returns the names a list entry might match. Type-scoped entries match their field name in struct fields
+// and the whole entry in map keys.
+func entryNames(entry string) []string {
+	if name := entryName(entry); name != entry {
+		return []string{name, entry}
+	}
+
+	return []string{entry}
+}
+
+// findEntry returns the first entry of the field and key name list matching the value at valueLocation.
+func (o *options) findEntry(valueLocation location) (string, bool) {
+	if o.nameIndex == nil {
+		return o.firstEntry(o.fieldKeyNameList, valueLocation)
+	}
+
+	if entry, found := o.firstEntry(o.nameIndex.candidates(valueLocation.name), valueLocation); found {
+		return entry, true
+	}
+
+	// entries might match the names provided by struct tags instead of the field name
+	for _, key := range nameTags {
+		taggedName, found := tagFieldName(valueLocation.tag, key)
+		if !found {
+			continue
+		}
+
+		if entry, found := o.firstEntry(o.nameIndex.candidates(taggedName), valueLocation); found {
+			return entry, true
+		}
+	}
+
+	return o.firstEntry(o.nameIndex.patterns, valueLocation)
+}
+
+// firstEntry returns the first of entries matching the value at valueLocation.
+func (o *options) firstEntry(entries []string, valueLocation location) (string, bool) {
+	for _, entry := range entries {
+		if o.matchesEntry(entry, valueLocation) {
+			return entry, true
+		}
+	}
+
+	return "", false
+}
+
+// foldName maps every rune of name to the smallest rune it is equal to under Unicode simple case folding, so two names
+// are equal under strings.EqualFold exactly when their folded names are equal.
+func foldName(name string) string {
+	return strings.Map(func(r rune) rune {
+		folded := r
+		for next := unicode.SimpleFold(r); next != r; next = unicode.SimpleFold(next) {
+			folded = min(folded, next)
+		}
+
+		return folded
+	}, name)
+}
+
+// pathTrie matches values against path rules by walking their paths once, instead of matching every rule against
+// every value. Each node is reached by a path pattern and holds the rules whose pattern ends at it.
+type pathTrie struct {
+	// names holds children for field and key names keyed by their folded name.
+	names map[string]*pathTrie
+	// spellings holds the same children keyed by names as written in patterns, so names spelled the same as a
+	// pattern are matched without folding them.
+	spellings map[string]*pathTrie
+	indexes   map[string]*pathTrie
+	wildcard  *pathTrie
+	// descendant is reached by "..", which matches any number of segments before its children.
+	descendant *pathTrie
+
+	redactRule *pathRule
+	keepRule   *pathRule
+}
+
+func newPathTrie(rules []pathRule) *pathTrie {
+	root := &pathTrie{}
+
+	for i := range rules {
+		node := root
+
+		for _, segment := range rules[i].pattern {
+			node = node.child(segment)
+		}
+
+		// the first rule for a pattern is kept, the same as scanning rules in order
+		switch {
+		case rules[i].redact && node.redactRule == nil:
+			node.redactRule = &rules[i]
+		case !rules[i].redact && node.keepRule == nil:
+			node.keepRule = &rules[i]
+		}
+	}
+
+	return root
+}
+
+// child returns the node reached from t by segment, adding it when missing.
+func (t *pathTrie) child(segment pathSegment) *pathTrie {
+	switch {
+	case segment.isDescendant:
+		if t.descendant == nil {
+			t.descendant = &pathTrie{}
+		}
+
+		return t.descendant
+	case segment.isIndex && segment.name == pathIndexWildcard:
+		if t.wildcard == nil {
+			t.wildcard = &pathTrie{}
+		}
+
+		return t.wildcard
+	case segment.isIndex:
+		if t.indexes == nil {
+			t.indexes = map[string]*pathTrie{}
+		}
+
+		if t.indexes[segment.name] == nil {
+			t.indexes[segment.name] = &pathTrie{}
+		}
+
+		return t.indexes[segment.name]
+	default:
+		if t.names == nil {
+			t.names = map[string]*pathTrie{}
+			t.spellings = map[string]*pathTrie{}
+		}
+
+		folded := foldName(segment.name)
+		if t.names[folded] == nil {
+			t.names[folded] = &pathTrie{}
+		}
+
+		t.spellings[segment.name] = t.names[folded]
+
+		return t.names[folded]
+	}
+}
+
+// pathMatch is the longest path rule matching a value found so far.
+type pathMatch struct {
+	rule   *pathRule
+	length int
+}
+
+// consider replaces the match with rule matching length segments when it is longer, or as long and redacting.
+func (m *pathMatch) consider(rule *pathRule, length int) {
+	if rule == nil {
+		return
+	}
+
+	if m.rule == nil || length > m.length || (length == m.length && rule.redact && !m.rule.redact) {
+		m.rule, m.length = rule, length
+	}
+}
+
+// match considers the rules of t and of every node reached by the rest of valuePath after depth segments.
+func (t *pathTrie) match(valuePath path, depth int, best *pathMatch) {
+	best.consider(t.redactRule, depth)
+	best.consider(t.keepRule, depth)
+
+	if t.descendant != nil {
+		for descendantDepth := depth; descendantDepth <= len(valuePath); descendantDepth++ {
+			t.descendant.match(valuePath, descendantDepth, best)
+		}
+	}
+
+	if depth == len(valuePath) {
+		return
+	}
+
+	segment := valuePath[depth]
+
+	if segment.isIndex {
+		if child := t.indexes[segment.name]; child != nil {
+			child.match(valuePath, depth+1, best)
+		}
+
+		if t.wildcard != nil {
+			t.wildcard.match(valuePath, depth+1, best)
+		}
+
+		return
+	}
+
+	if t.names == nil {
+		return
+	}
+
+	child, found := t.spellings[segment.name]
+	if !found {
+		child = t.names[foldName(segment.name)]
+	}
+
+	if child != nil {
+		child.match(valuePath, depth+1, best)
+	}
+}
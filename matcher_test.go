@@ -0,0 +1,129 @@
+package rere_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type cardholder struct {
+	Name    string
+	Number  string
+	Cards   []credentialDetails
+	Details credentialDetails
+}
+
+// generatedEntries returns count entries formatted with format, such as the entries of a policy generated from a
+// schema.
+func generatedEntries(format string, count int) []string {
+	entries := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		entries = append(entries, fmt.Sprintf(format, i))
+	}
+
+	return entries
+}
+
+func TestRedactWithLargePolicies(t *testing.T) {
+	t.Parallel()
+
+	input := cardholder{
+		Name:    "alice",
+		Number:  "4111111111111111",
+		Cards:   []credentialDetails{{Name: "visa", Secret: "123"}, {Name: "amex", Secret: "456"}},
+		Details: credentialDetails{Name: "primary", Secret: "789"},
+	}
+
+	testCases := []struct {
+		name      string
+		allowList []string
+		opts      []rere.Option
+		output    cardholder
+	}{
+		{
+			name:      "matches names in large lists case insensitively",
+			allowList: append(generatedEntries("field%d", 1400), "NAME", "rere_test.credentialDetails:secret"),
+			output: cardholder{
+				Name:    "alice",
+				Number:  redacted,
+				Cards:   []credentialDetails{{Name: "visa", Secret: "123"}, {Name: "amex", Secret: "456"}},
+				Details: credentialDetails{Name: "primary", Secret: "789"},
+			},
+		},
+		{
+			name:      "matches type-scoped entries in large lists",
+			allowList: append(generatedEntries("field%d", 1400), "rere_test.cardholder:name"),
+			output: cardholder{
+				Name:    "alice",
+				Number:  redacted,
+				Cards:   []credentialDetails{{Name: redacted, Secret: redacted}, {Name: redacted, Secret: redacted}},
+				Details: credentialDetails{Name: redacted, Secret: redacted},
+			},
+		},
+		{
+			name: "matches large path rule sets",
+			opts: []rere.Option{
+				rere.WithKeepPaths(append(generatedEntries("Cards[%d].Name", 1400), "details", "number")...),
+				rere.WithRedactPaths(append(generatedEntries("Details.Field%d", 1400), "DETAILS.secret")...),
+			},
+			output: cardholder{
+				Name:    redacted,
+				Number:  "4111111111111111",
+				Cards:   []credentialDetails{{Name: "visa", Secret: redacted}, {Name: "amex", Secret: redacted}},
+				Details: credentialDetails{Name: "primary", Secret: redacted},
+			},
+		},
+		{
+			name: "prefers exact indexes and wildcards by length and redacting rules for ties",
+			opts: []rere.Option{
+				rere.WithKeepPaths("Cards[*]", "Cards[1].Secret"),
+				rere.WithRedactPaths("Cards[1]", "Cards[*].Secret"),
+			},
+			output: cardholder{
+				Name:    redacted,
+				Number:  redacted,
+				Cards:   []credentialDetails{{Name: "visa", Secret: redacted}, {Name: redacted, Secret: redacted}},
+				Details: credentialDetails{Name: redacted, Secret: redacted},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			redactedInput := rere.RedactWithAllowList(input, testCase.allowList, testCase.opts...)
+
+			g.Expect(redactedInput).To(gomega.Equal(testCase.output), "RedactWithAllowList should match large policies")
+
+			policy := rere.NewAllowListPolicy(testCase.allowList, testCase.opts...)
+
+			// redact twice, so the second call reuses the indexed list
+			g.Expect(policy.Redact(input)).To(gomega.Equal(testCase.output), "Policy should match large policies")
+			g.Expect(policy.Redact(input)).To(gomega.Equal(testCase.output), "Policy should reuse indexed lists")
+		})
+	}
+}
+
+func BenchmarkRedactWithLargePolicies(b *testing.B) {
+	input := cardholder{
+		Name:    "alice",
+		Number:  "4111111111111111",
+		Cards:   []credentialDetails{{Name: "visa", Secret: "123"}, {Name: "amex", Secret: "456"}},
+		Details: credentialDetails{Name: "primary", Secret: "789"},
+	}
+
+	policy := rere.NewAllowListPolicy(generatedEntries("field%d", 1400),
+		rere.WithRedactPaths(generatedEntries("Cards[*].Field%d", 1400)...))
+	defer policy.Close()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		policy.Redact(input)
+	}
+}
@@ -0,0 +1,26 @@
+package rere
+
+// NonStringValues configures how values of kinds other than string and []byte, such as the float64s and bools held by
+// a map[string]any, are handled by WithNonStringValues.
+type NonStringValues int
+
+const (
+	// ZeroExplicitNonStrings sets non-string values to their zero value only when a tag, name, or path rule explicitly
+	// redacts them, such as a deny list including "SSN" for an SSN int64 field. This is the default.
+	ZeroExplicitNonStrings NonStringValues = iota + 1
+	// KeepNonStrings keeps every non-string value as-is, so only strings and byte slices are ever replaced.
+	KeepNonStrings
+	// ZeroNonStrings sets non-string values to their zero value whenever a string at the same location would be
+	// redacted, including by default in allow mode and by type and package rules. Structs are traversed instead of
+	// being zeroed.
+	ZeroNonStrings
+)
+
+// WithNonStringValues configures whether values of kinds other than string and []byte are set to their zero value.
+// Values held by interfaces, such as the values of a map[string]any, keep their dynamic type either way, so a redacted
+// float64 stays a float64 holding 0 instead of becoming a string placeholder, and JSON consumers still see a number.
+func WithNonStringValues(nonStringValues NonStringValues) Option {
+	return func(o *options) {
+		o.nonStringValues = nonStringValues
+	}
+}
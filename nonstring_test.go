@@ -0,0 +1,103 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactMixedMaps(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		redact   func(map[string]any) map[string]any
+		expected map[string]any
+	}{
+		{
+			name: "zeroes non-string values in the deny list by default",
+			redact: func(value map[string]any) map[string]any {
+				return rere.RedactWithDenyList(value, []string{"token", "score", "admin", "key", "tags"})
+			},
+			expected: map[string]any{
+				"token":  redacted,
+				"score":  float64(0),
+				"admin":  false,
+				"key":    []byte(redacted),
+				"tags":   []any{redacted, int64(0)},
+				"nested": map[string]any{"score": float64(0)},
+				"name":   "dustin",
+			},
+		},
+		{
+			name: "keeps non-string values outside of the allow list by default",
+			redact: func(value map[string]any) map[string]any {
+				return rere.RedactWithAllowList(value, []string{"name"})
+			},
+			expected: map[string]any{
+				"token":  redacted,
+				"score":  float64(9.5),
+				"admin":  true,
+				"key":    []byte(redacted),
+				"tags":   []any{redacted, int64(7)},
+				"nested": map[string]any{"score": float64(4.5)},
+				"name":   "dustin",
+			},
+		},
+		{
+			name: "keeps non-string values with KeepNonStrings",
+			redact: func(value map[string]any) map[string]any {
+				return rere.RedactWithDenyList(value, []string{"token", "score", "admin", "key", "tags"},
+					rere.WithNonStringValues(rere.KeepNonStrings))
+			},
+			expected: map[string]any{
+				"token":  redacted,
+				"score":  float64(9.5),
+				"admin":  true,
+				"key":    []byte(redacted),
+				"tags":   []any{redacted, int64(7)},
+				"nested": map[string]any{"score": float64(4.5)},
+				"name":   "dustin",
+			},
+		},
+		{
+			name: "zeroes non-string values outside of the allow list with ZeroNonStrings",
+			redact: func(value map[string]any) map[string]any {
+				return rere.RedactWithAllowList(value, []string{"name", "nested"},
+					rere.WithNonStringValues(rere.ZeroNonStrings))
+			},
+			expected: map[string]any{
+				"token":  redacted,
+				"score":  float64(0),
+				"admin":  false,
+				"key":    []byte(redacted),
+				"tags":   []any{redacted, int64(0)},
+				"nested": map[string]any{"score": float64(0)},
+				"name":   "dustin",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			value := map[string]any{
+				"token":  "abc123",
+				"score":  float64(9.5),
+				"admin":  true,
+				"key":    []byte("secret"),
+				"tags":   []any{"vip", int64(7)},
+				"nested": map[string]any{"score": float64(4.5)},
+				"name":   "dustin",
+			}
+
+			g.Expect(testCase.redact(value)).To(gomega.Equal(testCase.expected),
+				"values should keep their dynamic types when redacted")
+			g.Expect(value).To(gomega.HaveKeyWithValue("score", float64(9.5)), "the original map should not be modified")
+		})
+	}
+}
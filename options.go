@@ -0,0 +1,359 @@
+package rere
+
+import (
+	"encoding/hex"
+	"reflect"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// Option configures how a value is redacted.
+type Option func(*options)
+
+type options struct {
+	mode             redactMode
+	fieldKeyNameList []string
+	overrideDenyList []string
+	nameIndex        *nameIndex
+	precedence       []RuleSource
+	redactPackages   []string
+	redactTypes      []string
+	pathRules        []*pathRuleSet
+	nameFolding      nameFolding
+	jsonSemantics    bool
+
+	nilFuncsAndChans       bool
+	shallow                bool
+	keepBareValues         bool
+	nonStringValues        NonStringValues
+	redactStructMapKeys    bool
+	syncMapContents        bool
+	kinds                  Kind
+	stringerBoundary       StringerBoundary
+	unsupportedKindHandler UnsupportedKindHandler
+	fieldFilter            func(structField reflect.StructField) bool
+	stringerDetectors      []Detector
+	transforms             []fieldTransform
+	embeddedFormats        []embeddedFormat
+	textDetectors          []Detector
+	valuePatterns          []*regexp.Regexp
+	valueDetectors         []Detector
+	knownSecrets           []Detector
+
+	kindAnnotatedPlaceholders bool
+	placeholderMessage        string
+	typeNamePlaceholders      bool
+	lengthHint                bool
+	correlationSuffix         bool
+	collisionGuard            bool
+	skipAlreadyRedacted       bool
+	copiedPlaceholders        bool
+	policyStampedPlaceholders bool
+	correlationKey            []byte
+	correlationKeyID          string
+	bytePrefixLength          int
+	binaryBytes               BinaryBytes
+	fieldPlaceholders         []fieldPlaceholder
+	pathPlaceholders          []pathPlaceholders
+	placeholderNonce          string
+	envelopeKeyring           *Keyring
+	envelopes                 *envelopeState
+	internedPlaceholder       []byte
+
+	report        *Report
+	policyName    string
+	policyVersion string
+	auditSink     AuditSink
+	auditRecord   []AuditRedaction
+	stats         *Stats
+
+	scratch     *scratch
+	scratchPool *sync.Pool
+}
+
+//nolint:gochecknoglobals // defaults are intentionally global so they can be installed once at startup
+var (
+	defaultOptions      []Option
+	defaultOptionsMutex sync.RWMutex
+)
+
+// SetDefaults installs options applied to every redaction before the options provided to each call, so an
+// application can configure placeholders, rules, and other options once at startup. Options provided to a call
+// override defaults. Calling SetDefaults replaces previously installed defaults and calling SetDefaults without
+// options removes them.
+//
+// Lists provided to SetDefaults, such as WithAllowList, only apply to functions without a list of their own, such as
+// Redact and NewPolicy. They never replace the list provided to RedactWithAllowList, RedactWithDenyList,
+// RedactWithAllowAndDenyLists, NewAllowListPolicy, NewDenyListPolicy, or a PolicyFile.
+func SetDefaults(opts ...Option) {
+	defaultOptionsMutex.Lock()
+	defer defaultOptionsMutex.Unlock()
+
+	defaultOptions = slices.Clone(opts)
+}
+
+// newOptions returns the options of an entrypoint without a field and key name list of its own, such as Redact, so a
+// list provided to SetDefaults or opts replaces mode and fieldKeyNameList.
+func newOptions(mode redactMode, fieldKeyNameList []string, opts []Option) *options {
+	return buildOptions(mode, fieldKeyNameList, nil, opts)
+}
+
+// newListOptions returns the options of an entrypoint provided a field and key name list, such as RedactWithAllowList.
+// listOption sets the list after the defaults are applied, so a list provided to SetDefaults can't replace it or flip
+// its mode, while opts still can. newListOptions is the same as newOptions when listOption is nil.
+func newListOptions(listOption Option, opts []Option) *options {
+	return buildOptions(allow, nil, listOption, opts)
+}
+
+func buildOptions(mode redactMode, fieldKeyNameList []string, listOption Option, opts []Option) *options {
+	redactOptions := &options{
+		mode:             mode,
+		fieldKeyNameList: fieldKeyNameList,
+		overrideDenyList: nil,
+		nameIndex:        nil,
+		precedence:       defaultPrecedence,
+		redactPackages:   nil,
+		redactTypes:      nil,
+		pathRules:        nil,
+		nameFolding:      nameFolding{caseSensitive: false, locale: nil, normalization: 0, normalize: false},
+		jsonSemantics:    false,
+
+		nilFuncsAndChans:       false,
+		shallow:                false,
+		keepBareValues:         false,
+		nonStringValues:        0,
+		redactStructMapKeys:    false,
+		syncMapContents:        false,
+		kinds:                  Strings | Bytes,
+		stringerBoundary:       0,
+		unsupportedKindHandler: nil,
+		fieldFilter:            nil,
+		stringerDetectors:      nil,
+		transforms:             nil,
+		embeddedFormats:        nil,
+		textDetectors:          nil,
+		valuePatterns:          nil,
+		valueDetectors:         nil,
+		knownSecrets:           nil,
+
+		kindAnnotatedPlaceholders: false,
+		placeholderMessage:        redactedMessage,
+		typeNamePlaceholders:      false,
+		lengthHint:                false,
+		correlationSuffix:         false,
+		collisionGuard:            false,
+		skipAlreadyRedacted:       false,
+		copiedPlaceholders:        false,
+		policyStampedPlaceholders: false,
+		correlationKey:            processCorrelationKey,
+		correlationKeyID:          "",
+		bytePrefixLength:          0,
+		binaryBytes:               0,
+		fieldPlaceholders:         nil,
+		pathPlaceholders:          nil,
+		placeholderNonce:          "",
+		envelopeKeyring:           nil,
+		envelopes:                 nil,
+		internedPlaceholder:       nil,
+
+		report:        nil,
+		policyName:    "",
+		policyVersion: "",
+		auditSink:     nil,
+		auditRecord:   nil,
+		stats:         nil,
+
+		scratch:     nil,
+		scratchPool: &scratchPool,
+	}
+
+	defaultOptionsMutex.RLock()
+	for _, opt := range defaultOptions {
+		opt(redactOptions)
+	}
+	defaultOptionsMutex.RUnlock()
+
+	if listOption != nil {
+		listOption(redactOptions)
+	}
+
+	for _, opt := range opts {
+		opt(redactOptions)
+	}
+
+	if redactOptions.report != nil {
+		redactOptions.report.Redactions = nil
+		redactOptions.report.AlreadyRedacted = nil
+		redactOptions.report.Policy = redactOptions.policyName
+		redactOptions.report.PolicyVersion = redactOptions.policyVersion
+	}
+
+	if redactOptions.stats != nil {
+		redactOptions.stats.registerEntries(redactOptions)
+	}
+
+	return redactOptions
+}
+
+// WithAllowList redacts every string and []byte field and key value except for field and key names in allowList,
+// the same as RedactWithAllowList. WithAllowList is useful for functions that only accept options, such as Redact. If
+// multiple WithAllowList, WithDenyList, or WithAllowAndDenyLists options are provided, the last one is used.
+func WithAllowList(allowList ...string) Option {
+	return func(o *options) {
+		o.mode = allow
+		o.fieldKeyNameList = allowList
+		o.overrideDenyList = nil
+	}
+}
+
+// WithDenyList only redacts string and []byte field and key values for field and key names in denyList, the same as
+// RedactWithDenyList. WithDenyList is useful for functions that only accept options, such as Redact. If multiple
+// WithAllowList, WithDenyList, or WithAllowAndDenyLists options are provided, the last one is used.
+//
+// NOTE: It is *STRONGLY* discouraged to use WithDenyList in production code. See RedactWithDenyList for details.
+func WithDenyList(denyList ...string) Option {
+	return func(o *options) {
+		o.mode = deny
+		o.fieldKeyNameList = denyList
+		o.overrideDenyList = nil
+	}
+}
+
+// WithNilFuncsAndChans sets func and chan values to nil in the redacted copy instead of carrying them over by
+// reference. This is useful when the redacted copy is handed to code that should not be able to invoke callbacks or
+// send on channels owned by the original value.
+func WithNilFuncsAndChans() Option {
+	return func(o *options) {
+		o.nilFuncsAndChans = true
+	}
+}
+
+// WithShallow only redacts the top-level struct fields and map keys of the provided value. Nested structs and maps
+// are left as-is, which is useful when nested values are owned by another policy. Slices and arrays of strings and
+// byte slices in top-level fields are still redacted, and the fields of structs in a provided slice or array are
+// treated as top-level fields.
+func WithShallow() Option {
+	return func(o *options) {
+		o.shallow = true
+	}
+}
+
+// WithKeepBareValues keeps strings and byte slices not found in a struct field or map key instead of redacting them
+// in allow mode, such as a string provided directly to RedactWithAllowList, the elements of a provided []string, or a
+// string provided to RedactN. This is useful for generic logging wrappers passing plain messages through. Rules still
+// apply to bare values, so a path provided to WithRedactPaths, such as "[0]", redacts them.
+func WithKeepBareValues() Option {
+	return func(o *options) {
+		o.keepBareValues = true
+	}
+}
+
+// WithPlaceholder replaces redacted values with placeholder instead of "REDACTED". Other placeholder options build on
+// placeholder, such as WithLengthHint producing "***(23)" for a placeholder of "***".
+func WithPlaceholder(placeholder string) Option {
+	return func(o *options) {
+		o.placeholderMessage = placeholder
+	}
+}
+
+// WithKindAnnotatedPlaceholders redacts values with a placeholder stating what kind of value was removed.
+// String values are redacted with "[REDACTED string]" and byte slice values are redacted with
+// []byte("[REDACTED bytes]").
+func WithKindAnnotatedPlaceholders() Option {
+	return func(o *options) {
+		o.kindAnnotatedPlaceholders = true
+	}
+}
+
+// WithTypeNamePlaceholders redacts values with a placeholder stating the Go type of the value that was removed, such as
+// "<redacted config.APIKey>". This is useful when redacting any typed fields where readers can't tell what was there.
+// WithTypeNamePlaceholders takes priority over WithKindAnnotatedPlaceholders.
+func WithTypeNamePlaceholders() Option {
+	return func(o *options) {
+		o.typeNamePlaceholders = true
+	}
+}
+
+// WithLengthHint includes the length in bytes of the original value in placeholders, such as "REDACTED(23)".
+func WithLengthHint() Option {
+	return func(o *options) {
+		o.lengthHint = true
+	}
+}
+
+// WithBytePrefix keeps the first length bytes of redacted byte slice values followed by the placeholder, such as
+// []byte("\x89PNGREDACTED"). Knowing the magic number or key ID prefix of a blob helps debugging without exposing it.
+// Values of length bytes or fewer are redacted entirely.
+func WithBytePrefix(length int) Option {
+	return func(o *options) {
+		o.bytePrefixLength = length
+	}
+}
+
+// WithCopiedPlaceholders redacts every byte slice value with its own copy of the placeholder. By default, byte slice
+// values redacted by the same call share the bytes of equal placeholders, so redacting many fields only allocates a
+// placeholder once. Callers modifying the bytes of redacted values in place should provide WithCopiedPlaceholders,
+// since modifying one shared placeholder modifies every value sharing it.
+func WithCopiedPlaceholders() Option {
+	return func(o *options) {
+		o.copiedPlaceholders = true
+	}
+}
+
+// WithCorrelationSuffix appends a short keyed hash of the original value to placeholders, such as "REDACTED:9f3a".
+// Equal original values produce equal suffixes, so reuse of the same value can be spotted without exposing it.
+//
+// The hash is keyed with a random key generated when the process starts unless WithCorrelationKey is provided.
+func WithCorrelationSuffix() Option {
+	return func(o *options) {
+		o.correlationSuffix = true
+	}
+}
+
+// WithCorrelationKey sets the key used to hash correlation suffixes. Processes sharing the same key produce the same
+// suffix for the same original value. WithCorrelationKey does not enable correlation suffixes on its own.
+func WithCorrelationKey(key []byte) Option {
+	return func(o *options) {
+		o.correlationKey = key
+		o.correlationKeyID = ""
+	}
+}
+
+// WithPolicyName names the policy used to redact, so audit events can identify which policy scrubbed a value.
+func WithPolicyName(name string) Option {
+	return func(o *options) {
+		o.policyName = name
+	}
+}
+
+// WithPolicyVersion sets the version of the policy used to redact, such as "v3", so audit events and reports can
+// identify which revision of a policy scrubbed a value as policies evolve.
+func WithPolicyVersion(version string) Option {
+	return func(o *options) {
+		o.policyVersion = version
+	}
+}
+
+// WithPolicyStampedPlaceholders appends the policy name and version provided to WithPolicyName and WithPolicyVersion to
+// placeholders, such as "REDACTED[pci-v3]", so auditors can tell which policy scrubbed a historical record from the
+// record alone. Placeholders are left as-is when neither a policy name nor version is provided.
+func WithPolicyStampedPlaceholders() Option {
+	return func(o *options) {
+		o.policyStampedPlaceholders = true
+	}
+}
+
+func (o *options) recordRedaction(valuePath path, original []byte, value any) {
+	if o.report != nil {
+		o.report.add(valuePath, value)
+	}
+
+	if o.auditSink != nil {
+		o.auditRecord = append(o.auditRecord, AuditRedaction{
+			Path:         valuePath.String(),
+			OriginalHash: hex.EncodeToString(keyedHash(o.correlationKey, original)),
+			KeyID:        o.correlationKeyID,
+		})
+	}
+}
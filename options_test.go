@@ -0,0 +1,219 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type structWithNestedMap struct {
+	Password string
+	Tokens   []string
+	Nested   structWithRedactedFields
+	Labels   map[string]string
+}
+
+//nolint:funlen // I'm okay with test functions with several statements of test data
+func TestRedactWithOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		input     any
+		allowList []string
+		opts      []rere.Option
+		output    any
+	}{
+		{
+			name: "shallow only redacts top-level fields",
+			input: structWithNestedMap{
+				Password: "password",
+				Tokens:   []string{"token"},
+				Nested: structWithRedactedFields{
+					Username:  "username",
+					username:  "",
+					Password:  "password",
+					password:  "",
+					byteSlice: nil,
+					stringPtr: nil,
+				},
+				Labels: map[string]string{"password": "password"},
+			},
+			allowList: nil,
+			opts:      []rere.Option{rere.WithShallow()},
+			output: structWithNestedMap{
+				Password: redacted,
+				Tokens:   []string{redacted},
+				Nested: structWithRedactedFields{
+					Username:  "username",
+					username:  "",
+					Password:  "password",
+					password:  "",
+					byteSlice: nil,
+					stringPtr: nil,
+				},
+				Labels: map[string]string{"password": "password"},
+			},
+		},
+		{
+			name: "shallow redacts top-level map keys",
+			input: map[string]any{
+				"password": "password",
+				"nested":   map[string]string{"password": "password"},
+			},
+			allowList: nil,
+			opts:      []rere.Option{rere.WithShallow()},
+			output: map[string]any{
+				"password": redacted,
+				"nested":   map[string]string{"password": "password"},
+			},
+		},
+		{
+			name: "shallow redacts fields of structs in provided slices",
+			input: []structWithByteSlice{
+				{Password: []byte("password"), password: nil},
+			},
+			allowList: nil,
+			opts:      []rere.Option{rere.WithShallow()},
+			output: []structWithByteSlice{
+				{Password: []byte(redacted), password: nil},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			redacted := rere.RedactWithAllowList(testCase.input, testCase.allowList, testCase.opts...)
+
+			g.Expect(redacted).To(gomega.Equal(testCase.output), "RedactWithAllowList should apply options")
+		})
+	}
+}
+
+//nolint:paralleltest // SetDefaults modifies global state shared by every test
+func TestSetDefaults(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	rere.SetDefaults(rere.WithKindAnnotatedPlaceholders(), rere.WithRedactTypes("rere_test.apiKey"))
+	t.Cleanup(func() {
+		rere.SetDefaults()
+	})
+
+	g.Expect(rere.RedactWithAllowList("password", nil)).To(gomega.Equal("[REDACTED string]"),
+		"RedactWithAllowList should honor defaults")
+	g.Expect(rere.RedactWithDenyList(apiKey("key"), nil)).To(gomega.Equal(apiKey("[REDACTED string]")),
+		"RedactWithDenyList should honor defaults")
+	g.Expect(rere.RedactWithAllowList("password", nil, rere.WithTypeNamePlaceholders())).
+		To(gomega.Equal("<redacted string>"), "options provided to a call should override defaults")
+
+	rere.SetDefaults()
+
+	g.Expect(rere.RedactWithAllowList("password", nil)).To(gomega.Equal(redacted), "SetDefaults should remove defaults")
+}
+
+//nolint:funlen,paralleltest // SetDefaults modifies global state shared by every test
+func TestSetDefaultsWithLists(t *testing.T) {
+	t.Cleanup(func() {
+		rere.SetDefaults()
+	})
+
+	input := map[string]string{"username": "dustin", "ssn": "123-45-6789", "password": "hunter2"}
+	allowed := map[string]string{"username": "dustin", "ssn": redacted, "password": redacted}
+	denied := map[string]string{"username": "dustin", "ssn": redacted, "password": "hunter2"}
+
+	policyFile, err := rere.ParsePolicy([]byte("mode: allow\nlist: [username]\n"))
+	if err != nil {
+		t.Fatalf("ParsePolicy should parse the policy: %v", err)
+	}
+
+	entrypoints := []struct {
+		name   string
+		redact func() any
+		output map[string]string
+	}{
+		{
+			name:   "RedactWithAllowList",
+			redact: func() any { return rere.RedactWithAllowList(input, []string{"username"}) },
+			output: allowed,
+		},
+		{
+			name:   "RedactWithDenyList",
+			redact: func() any { return rere.RedactWithDenyList(input, []string{"ssn"}) },
+			output: denied,
+		},
+		{
+			name: "RedactWithAllowAndDenyLists",
+			redact: func() any {
+				return rere.RedactWithAllowAndDenyLists(input, []string{"username", "ssn"}, []string{"ssn"})
+			},
+			output: allowed,
+		},
+		{
+			name:   "NewAllowListPolicy",
+			redact: func() any { return rere.NewAllowListPolicy([]string{"username"}).Redact(input) },
+			output: allowed,
+		},
+		{
+			name:   "NewDenyListPolicy",
+			redact: func() any { return rere.NewDenyListPolicy([]string{"ssn"}).Redact(input) },
+			output: denied,
+		},
+		{
+			name:   "PolicyFile",
+			redact: func() any { return policyFile.Redact(input) },
+			output: allowed,
+		},
+	}
+
+	defaults := []struct {
+		name string
+		list rere.Option
+	}{
+		{name: "default allow list", list: rere.WithAllowList("ssn")},
+		{name: "default deny list", list: rere.WithDenyList("ssn")},
+	}
+
+	for _, defaultList := range defaults {
+		for _, entrypoint := range entrypoints {
+			t.Run(defaultList.name+" with "+entrypoint.name, func(t *testing.T) {
+				g := gomega.NewWithT(t)
+
+				rere.SetDefaults(defaultList.list)
+
+				g.Expect(entrypoint.redact()).To(gomega.Equal(entrypoint.output),
+					"a default list should not replace the list provided to "+entrypoint.name)
+			})
+		}
+	}
+
+	rere.SetDefaults(rere.WithDenyList("ssn"))
+
+	g := gomega.NewWithT(t)
+	g.Expect(rere.Redact(input)).To(gomega.Equal(denied), "Redact should honor a default list")
+}
+
+func TestRedactWithKeepBareValues(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	user := credentialDetails{Name: "alice", Secret: "secret"}
+
+	g.Expect(rere.RedactWithAllowList("message", nil, rere.WithKeepBareValues())).To(gomega.Equal("message"),
+		"WithKeepBareValues should keep strings provided directly")
+	g.Expect(rere.RedactWithAllowList([]byte("message"), nil, rere.WithKeepBareValues())).To(
+		gomega.Equal([]byte("message")), "WithKeepBareValues should keep byte slices provided directly")
+	g.Expect(rere.RedactWithAllowList([]string{"a", "b"}, nil, rere.WithKeepBareValues())).To(
+		gomega.Equal([]string{"a", "b"}), "WithKeepBareValues should keep elements of a provided []string")
+	g.Expect(rere.RedactN([]rere.Option{rere.WithAllowList("name"), rere.WithKeepBareValues()}, "login", user)).To(
+		gomega.Equal([]any{"login", credentialDetails{Name: "alice", Secret: redacted}}),
+		"WithKeepBareValues should keep bare values while redacting fields")
+	g.Expect(rere.RedactN([]rere.Option{rere.WithKeepBareValues(), rere.WithRedactPaths("[1]")}, "a", "b")).To(
+		gomega.Equal([]any{"a", redacted}), "WithKeepBareValues should still apply rules to bare values")
+	g.Expect(rere.RedactWithAllowList(map[string]string{"key": "value"}, nil, rere.WithKeepBareValues())).To(
+		gomega.Equal(map[string]string{"key": redacted}), "WithKeepBareValues should redact map values")
+}
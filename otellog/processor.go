@@ -0,0 +1,170 @@
+// Package otellog redacts OpenTelemetry log records with rere before they are exported.
+package otellog
+
+import (
+	"context"
+
+	"github.com/dustinspecker/rere"
+	"go.opentelemetry.io/otel/attribute"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// bodyKey is the name the body of a log record is redacted with, so allow and deny lists can refer to it.
+const bodyKey = "body"
+
+// Processor is an OpenTelemetry Logs SDK processor redacting the body and attributes of log records with a
+// rere.Redactor before passing them to the next processor, so OTLP-first services get the same guarantees as code
+// redacting values before logging them.
+type Processor struct {
+	next     sdklog.Processor
+	redactor rere.Redactor
+}
+
+var _ sdklog.Processor = (*Processor)(nil)
+
+// NewProcessor creates a Processor redacting log records with redactor before passing them to next, such as a batch
+// processor wrapping an OTLP exporter.
+//
+// The body is redacted as the value of a "body" key, so an allow list including "body" keeps a string body while its
+// nested map keys are still redacted by name. Attributes are redacted as map keys named after their attribute key.
+// Values of other kinds, such as int64 attributes, are only changed when a deny list, tag, or path explicitly redacts
+// them.
+func NewProcessor(next sdklog.Processor, redactor rere.Redactor) *Processor {
+	return &Processor{
+		next:     next,
+		redactor: redactor,
+	}
+}
+
+// Enabled reports whether the next processor processes a record.
+func (p *Processor) Enabled(ctx context.Context, param sdklog.EnabledParameters) bool {
+	return p.next.Enabled(ctx, param)
+}
+
+// OnEmit redacts the body and attributes of record in place and passes it to the next processor.
+func (p *Processor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	//nolint:wrapcheck // errors of the next processor are returned as-is
+	return p.next.OnEmit(ctx, p.redact(record))
+}
+
+// Shutdown shuts down the next processor.
+func (p *Processor) Shutdown(ctx context.Context) error {
+	//nolint:wrapcheck // errors of the next processor are returned as-is
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor.
+func (p *Processor) ForceFlush(ctx context.Context) error {
+	//nolint:wrapcheck // errors of the next processor are returned as-is
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *Processor) redact(record *sdklog.Record) *sdklog.Record {
+	body := record.Body()
+	if body.Type() != attribute.EMPTY {
+		//nolint:forcetypeassert // rere.Redactor returns values of the same dynamic type
+		redactedBody := p.redactor.Redact(map[string]any{bodyKey: fromValue(body)}).(map[string]any)
+
+		record.SetBody(toValue(body, redactedBody[bodyKey]))
+	}
+
+	if record.AttributesLen() == 0 {
+		return record
+	}
+
+	attributes := make([]attribute.KeyValue, 0, record.AttributesLen())
+
+	record.WalkAttributes(func(keyValue attribute.KeyValue) bool {
+		attributes = append(attributes, keyValue)
+
+		return true
+	})
+
+	//nolint:forcetypeassert // rere.Redactor returns values of the same dynamic type
+	redactedAttributes := p.redactor.Redact(fromKeyValues(attributes)).(map[string]any)
+
+	record.SetAttributes(toKeyValues(attributes, redactedAttributes)...)
+
+	return record
+}
+
+// fromValue converts value to a Go value redacted by rere, such as a map[string]any for a map value.
+func fromValue(value attribute.Value) any {
+	//nolint:exhaustive // every other type is converted by AsInterface
+	switch value.Type() {
+	case attribute.EMPTY:
+		return nil
+	case attribute.MAP:
+		return fromKeyValues(value.AsMap())
+	case attribute.SLICE:
+		values := value.AsSlice()
+
+		converted := make([]any, 0, len(values))
+		for _, element := range values {
+			converted = append(converted, fromValue(element))
+		}
+
+		return converted
+	default:
+		return value.AsInterface()
+	}
+}
+
+func fromKeyValues(keyValues []attribute.KeyValue) map[string]any {
+	converted := make(map[string]any, len(keyValues))
+	for _, keyValue := range keyValues {
+		converted[string(keyValue.Key)] = fromValue(keyValue.Value)
+	}
+
+	return converted
+}
+
+// toValue converts redacted, a redacted copy of the value returned by fromValue for original, back to a value. The
+// order of map keys in original is kept.
+func toValue(original attribute.Value, redacted any) attribute.Value {
+	switch redacted := redacted.(type) {
+	case map[string]any:
+		return attribute.MapValue(toKeyValues(original.AsMap(), redacted)...)
+	case []any:
+		elements := original.AsSlice()
+
+		converted := make([]attribute.Value, 0, len(redacted))
+		for i, element := range redacted {
+			converted = append(converted, toValue(elements[i], element))
+		}
+
+		return attribute.SliceValue(converted...)
+	case string:
+		return attribute.StringValue(redacted)
+	case []byte:
+		return attribute.ByteSliceValue(redacted)
+	case bool:
+		return attribute.BoolValue(redacted)
+	case int64:
+		return attribute.Int64Value(redacted)
+	case float64:
+		return attribute.Float64Value(redacted)
+	case []string:
+		return attribute.StringSliceValue(redacted)
+	case []bool:
+		return attribute.BoolSliceValue(redacted)
+	case []int64:
+		return attribute.Int64SliceValue(redacted)
+	case []float64:
+		return attribute.Float64SliceValue(redacted)
+	default:
+		return original
+	}
+}
+
+func toKeyValues(original []attribute.KeyValue, redacted map[string]any) []attribute.KeyValue {
+	converted := make([]attribute.KeyValue, 0, len(original))
+	for _, keyValue := range original {
+		converted = append(converted, attribute.KeyValue{
+			Key:   keyValue.Key,
+			Value: toValue(keyValue.Value, redacted[string(keyValue.Key)]),
+		})
+	}
+
+	return converted
+}
@@ -0,0 +1,113 @@
+package otellog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/dustinspecker/rere/otellog"
+	"github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+type captureProcessor struct {
+	records []sdklog.Record
+}
+
+func (p *captureProcessor) Enabled(context.Context, sdklog.EnabledParameters) bool {
+	return true
+}
+
+func (p *captureProcessor) OnEmit(_ context.Context, record *sdklog.Record) error {
+	p.records = append(p.records, record.Clone())
+
+	return nil
+}
+
+func (p *captureProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *captureProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+func emit(redactor rere.Redactor, body attribute.Value, attributes ...attribute.KeyValue) sdklog.Record {
+	capture := &captureProcessor{records: nil}
+
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(otellog.NewProcessor(capture, redactor)))
+
+	var record log.Record
+
+	record.SetBody(body)
+	record.AddAttributes(attributes...)
+
+	provider.Logger("test").Emit(context.Background(), record)
+
+	return capture.records[0]
+}
+
+func attributesOf(record sdklog.Record) []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+
+	record.WalkAttributes(func(keyValue attribute.KeyValue) bool {
+		attributes = append(attributes, keyValue)
+
+		return true
+	})
+
+	return attributes
+}
+
+func TestProcessor(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	record := emit(
+		rere.NewAllowListPolicy([]string{"body", "user", "http.method", "retries"}),
+		attribute.MapValue(
+			attribute.String("user", "alice"),
+			attribute.String("password", "hunter2"),
+		),
+		attribute.String("http.method", "GET"),
+		attribute.String("http.request.header.authorization", "Bearer token"),
+		attribute.Int64("retries", 3),
+		attribute.StringSlice("tokens", []string{"a", "b"}),
+		attribute.Slice("users", attribute.MapValue(attribute.String("user", "bob"), attribute.String("ssn", "123"))),
+	)
+
+	g.Expect(record.Body()).To(gomega.Equal(attribute.MapValue(
+		attribute.String("user", "alice"),
+		attribute.String("password", "REDACTED"),
+	)), "Processor should redact the body by key")
+	g.Expect(attributesOf(record)).To(gomega.Equal([]attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.String("http.request.header.authorization", "REDACTED"),
+		attribute.Int64("retries", 3),
+		attribute.StringSlice("tokens", []string{"REDACTED", "REDACTED"}),
+		attribute.Slice("users", attribute.MapValue(attribute.String("user", "bob"), attribute.String("ssn", "REDACTED"))),
+	}), "Processor should redact attributes by key")
+}
+
+func TestProcessorStringBody(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	record := emit(rere.NewDenyListPolicy([]string{"session"}), attribute.StringValue("user logged in"),
+		attribute.String("session", "abc"), attribute.Int("attempt", 1))
+
+	g.Expect(record.Body()).To(gomega.Equal(attribute.StringValue("user logged in")),
+		"Processor should keep bodies not in the deny list")
+	g.Expect(attributesOf(record)).To(gomega.Equal([]attribute.KeyValue{
+		attribute.String("session", "REDACTED"),
+		attribute.Int("attempt", 1),
+	}), "Processor should redact attributes in the deny list")
+
+	record = emit(rere.NewAllowListPolicy(nil), attribute.StringValue("user logged in"))
+
+	g.Expect(record.Body()).To(gomega.Equal(attribute.StringValue("REDACTED")),
+		"Processor should redact bodies not in the allow list")
+	g.Expect(attributesOf(record)).To(gomega.BeEmpty(), "Processor should keep records without attributes")
+}
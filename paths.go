@@ -0,0 +1,243 @@
+package rere
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	pathSeparator     = "."
+	pathIndexStart    = "["
+	pathIndexEnd      = "]"
+	pathIndexWildcard = "*"
+	pathQuote         = `"`
+	pathRoot          = "$"
+	pathDescendant    = ".."
+)
+
+var (
+	errEmptyPath           = errors.New("path is empty")
+	errEmptyName           = errors.New("field or key name is empty")
+	errUnclosedIndex       = errors.New("index is missing closing bracket")
+	errInvalidIndex        = errors.New("index is not a number or *")
+	errUnexpectedCharacter = errors.New("unexpected character")
+	errInvalidQuotedKey    = errors.New("quoted key is invalid")
+	errInvalidRoot         = errors.New("root must be followed by . or [")
+)
+
+// pathRule redacts or keeps values at paths matching pattern.
+type pathRule struct {
+	raw     string
+	pattern path
+	redact  bool
+}
+
+// pathRuleSet holds the path rules provided to a single WithRedactPaths or WithKeepPaths call and a trie matching
+// them, which is built once when the option is created.
+type pathRuleSet struct {
+	rules []pathRule
+	trie  *pathTrie
+}
+
+// WithRedactPaths redacts string and []byte values at paths, such as "Users[0].Password", and every string and []byte
+// nested inside of them. Paths use the same form as Redaction.Path: struct field and map key names separated by dots,
+// and slice and array indexes in brackets. "[*]" matches every index, such as "Users[*].Token". Names containing dots
+// or brackets can be quoted in brackets with Go string literal escaping, such as `Headers["Set-Cookie"]` or
+// `Labels["app.kubernetes.io/name"]`. Names are matched case insensitively.
+//
+// Paths also accept JSONPath-style selectors. Paths may start with the root "$", such as "$.users[*].password", and
+// ".." matches any number of segments, such as "$..token" matching every "token" at any depth.
+//
+// WithRedactPaths panics if a path is invalid, the same as regexp.MustCompile, since paths are expected to be
+// constants.
+func WithRedactPaths(paths ...string) Option {
+	return withPathRules(mustParsePathRules(paths, true))
+}
+
+// WithKeepPaths keeps string and []byte values at paths and every string and []byte nested inside of them. See
+// WithRedactPaths for the form of paths. When paths provided to WithRedactPaths and WithKeepPaths both match a value,
+// the longest path decides and WithRedactPaths wins ties.
+func WithKeepPaths(paths ...string) Option {
+	return withPathRules(mustParsePathRules(paths, false))
+}
+
+func mustParsePathRules(paths []string, redact bool) *pathRuleSet {
+	rules, err := parsePathRules(paths, redact)
+	if err != nil {
+		panic("rere: " + err.Error())
+	}
+
+	return rules
+}
+
+func parsePathRules(paths []string, redact bool) (*pathRuleSet, error) {
+	rules := make([]pathRule, 0, len(paths))
+
+	for _, rawPath := range paths {
+		pattern, err := parsePath(rawPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %w", rawPath, err)
+		}
+
+		rules = append(rules, pathRule{raw: rawPath, pattern: pattern, redact: redact})
+	}
+
+	return &pathRuleSet{rules: rules, trie: newPathTrie(rules)}, nil
+}
+
+// withPathRules adds rules parsed by parsePathRules.
+func withPathRules(rules *pathRuleSet) Option {
+	return func(o *options) {
+		o.pathRules = append(o.pathRules, rules)
+	}
+}
+
+// parsePath parses a path of the form used by Redaction.Path, optionally starting with the JSONPath root "$" and
+// including ".." to match any number of segments.
+func parsePath(rawPath string) (path, error) {
+	remaining, err := cutPathRoot(rawPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed path
+
+	for remaining != "" {
+		switch {
+		case strings.HasPrefix(remaining, pathDescendant):
+			parsed = append(parsed, pathSegment{name: "", isIndex: false, isDescendant: true})
+			remaining = remaining[len(pathDescendant):]
+
+			if strings.HasPrefix(remaining, pathIndexStart) {
+				continue
+			}
+
+			name, rest := cutName(remaining)
+			if name == "" {
+				return nil, errEmptyName
+			}
+
+			parsed = parsed.field(name)
+			remaining = rest
+		case strings.HasPrefix(remaining, pathIndexStart+pathQuote):
+			name, rest, err := cutQuotedName(remaining[len(pathIndexStart):])
+			if err != nil {
+				return nil, err
+			}
+
+			parsed = parsed.field(name)
+			remaining = rest
+		case strings.HasPrefix(remaining, pathIndexStart):
+			index, rest, found := strings.Cut(remaining[len(pathIndexStart):], pathIndexEnd)
+			if !found {
+				return nil, errUnclosedIndex
+			}
+
+			if _, err := strconv.Atoi(index); err != nil && index != pathIndexWildcard {
+				return nil, fmt.Errorf("%w: %q", errInvalidIndex, index)
+			}
+
+			parsed = append(parsed, pathSegment{name: index, isIndex: true, isDescendant: false})
+			remaining = rest
+		case strings.HasPrefix(remaining, pathSeparator) && len(parsed) > 0:
+			remaining = remaining[len(pathSeparator):]
+
+			name, rest := cutName(remaining)
+			if name == "" {
+				return nil, errEmptyName
+			}
+
+			parsed = parsed.field(name)
+			remaining = rest
+		case len(parsed) == 0:
+			name, rest := cutName(remaining)
+			if name == "" {
+				return nil, errEmptyName
+			}
+
+			parsed = parsed.field(name)
+			remaining = rest
+		default:
+			return nil, fmt.Errorf("%w at %q", errUnexpectedCharacter, remaining)
+		}
+	}
+
+	return parsed, nil
+}
+
+// cutPathRoot returns rawPath without the JSONPath root "$" and the separator following it, such as "users[*]" for
+// "$.users[*]". Paths starting with ".." keep it, since it matches any number of segments.
+func cutPathRoot(rawPath string) (string, error) {
+	if rawPath == "" {
+		return "", errEmptyPath
+	}
+
+	remaining, rooted := strings.CutPrefix(rawPath, pathRoot)
+	if !rooted {
+		return rawPath, nil
+	}
+
+	switch {
+	case remaining == "":
+		return "", errEmptyPath
+	case strings.HasPrefix(remaining, pathDescendant), strings.HasPrefix(remaining, pathIndexStart):
+		return remaining, nil
+	case strings.HasPrefix(remaining, pathSeparator):
+		return remaining[len(pathSeparator):], nil
+	default:
+		return "", fmt.Errorf("%w at %q", errInvalidRoot, remaining)
+	}
+}
+
+// cutQuotedName returns the name of a quoted key selector at the start of remaining, such as "Set-Cookie" in
+// `"Set-Cookie"]`, and the rest of remaining after the closing bracket. Quoted names use Go string literal escaping.
+func cutQuotedName(remaining string) (string, string, error) {
+	quoted, err := strconv.QuotedPrefix(remaining)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", errInvalidQuotedKey, err)
+	}
+
+	rest, found := strings.CutPrefix(remaining[len(quoted):], pathIndexEnd)
+	if !found {
+		return "", "", errUnclosedIndex
+	}
+
+	// strconv.QuotedPrefix already validated quoted
+	name, _ := strconv.Unquote(quoted)
+
+	return name, rest, nil
+}
+
+// cutName returns the field or key name at the start of remaining and the rest of remaining after it.
+func cutName(remaining string) (string, string) {
+	end := strings.IndexAny(remaining, pathSeparator+pathIndexStart)
+	if end == -1 {
+		return remaining, ""
+	}
+
+	return remaining[:end], remaining[end:]
+}
+
+// applyPathRule returns whether the longest path rule matching valuePath redacts it and whether any path rule matches.
+func (o *options) applyPathRule(valuePath path) (bool, bool) {
+	rule, matched := o.matchPathRule(valuePath)
+
+	return rule.redact, matched
+}
+
+// matchPathRule returns the longest path rule matching valuePath, preferring rules redacting values for ties.
+func (o *options) matchPathRule(valuePath path) (pathRule, bool) {
+	var best pathMatch
+
+	for _, ruleSet := range o.pathRules {
+		ruleSet.trie.match(valuePath, 0, &best)
+	}
+
+	if best.rule == nil {
+		return pathRule{raw: "", pattern: nil, redact: false}, false
+	}
+
+	return *best.rule, true
+}
@@ -0,0 +1,247 @@
+package rere_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type pathUser struct {
+	Name     string
+	Password string
+	Token    string
+}
+
+type pathAccount struct {
+	Owner   pathUser
+	Users   []pathUser
+	Rows    [][]string
+	Headers map[string]string
+}
+
+func newPathAccount() pathAccount {
+	return pathAccount{
+		Owner: pathUser{Name: "owner", Password: "owner-password", Token: "owner-token"},
+		Users: []pathUser{
+			{Name: "alice", Password: "alice-password", Token: "alice-token"},
+			{Name: "bob", Password: "bob-password", Token: "bob-token"},
+		},
+		Rows:    [][]string{{"id", "ssn"}, {"1", "123-45-6789"}},
+		Headers: map[string]string{"Authorization": "Bearer token", "Accept": "text/plain"},
+	}
+}
+
+func TestRedactWithPaths(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		redact func(input pathAccount) pathAccount
+		output func(output *pathAccount)
+	}{
+		{
+			name: "redacts values at an index",
+			redact: func(input pathAccount) pathAccount {
+				return rere.RedactWithDenyList(input, nil, rere.WithRedactPaths("Users[0].Password", "Rows[1][1]"))
+			},
+			output: func(output *pathAccount) {
+				output.Users[0].Password = redacted
+				output.Rows[1][1] = redacted
+			},
+		},
+		{
+			name: "redacts values at every index",
+			redact: func(input pathAccount) pathAccount {
+				return rere.RedactWithDenyList(input, nil, rere.WithRedactPaths("users[*].token"))
+			},
+			output: func(output *pathAccount) {
+				output.Users[0].Token = redacted
+				output.Users[1].Token = redacted
+			},
+		},
+		{
+			name: "redacts every value nested inside of a path",
+			redact: func(input pathAccount) pathAccount {
+				return rere.RedactWithDenyList(input, nil, rere.WithRedactPaths("Owner", "Headers.Authorization"))
+			},
+			output: func(output *pathAccount) {
+				output.Owner = pathUser{Name: redacted, Password: redacted, Token: redacted}
+				output.Headers["Authorization"] = redacted
+			},
+		},
+		{
+			name: "keeps values at paths over the allow list",
+			redact: func(input pathAccount) pathAccount {
+				return rere.RedactWithAllowList(input, []string{"name"}, rere.WithKeepPaths("Users[1]", "Rows[0]"))
+			},
+			output: func(output *pathAccount) {
+				output.Owner.Password = redacted
+				output.Owner.Token = redacted
+				output.Users[0].Password = redacted
+				output.Users[0].Token = redacted
+				output.Rows[1] = []string{redacted, redacted}
+				output.Headers = map[string]string{"Authorization": redacted, "Accept": redacted}
+			},
+		},
+		{
+			name: "redacts values at JSONPath selectors",
+			redact: func(input pathAccount) pathAccount {
+				return rere.RedactWithDenyList(input, nil, rere.WithRedactPaths("$.users[*].password", `$["Rows"][1]`))
+			},
+			output: func(output *pathAccount) {
+				output.Users[0].Password = redacted
+				output.Users[1].Password = redacted
+				output.Rows[1] = []string{redacted, redacted}
+			},
+		},
+		{
+			name: "redacts values at any depth with descendant selectors",
+			redact: func(input pathAccount) pathAccount {
+				return rere.RedactWithDenyList(input, nil, rere.WithRedactPaths("$..token", "Rows..[0]"))
+			},
+			output: func(output *pathAccount) {
+				output.Owner.Token = redacted
+				output.Users[0].Token = redacted
+				output.Users[1].Token = redacted
+				// "Rows..[0]" matches both Rows[0] and the first element of every row
+				output.Rows = [][]string{{redacted, redacted}, {redacted, "123-45-6789"}}
+			},
+		},
+		{
+			name: "keeps values at descendant selectors",
+			redact: func(input pathAccount) pathAccount {
+				return rere.RedactWithAllowList(input, nil, rere.WithKeepPaths("..Name", "$.Headers"))
+			},
+			output: func(output *pathAccount) {
+				output.Owner = pathUser{Name: "owner", Password: redacted, Token: redacted}
+				output.Users = []pathUser{
+					{Name: "alice", Password: redacted, Token: redacted},
+					{Name: "bob", Password: redacted, Token: redacted},
+				}
+				output.Rows = [][]string{{redacted, redacted}, {redacted, redacted}}
+			},
+		},
+		{
+			name: "uses the longest matching path",
+			redact: func(input pathAccount) pathAccount {
+				return rere.RedactWithDenyList(input, nil,
+					rere.WithRedactPaths("Users"), rere.WithKeepPaths("Users[*].Name"))
+			},
+			output: func(output *pathAccount) {
+				output.Users[0].Password = redacted
+				output.Users[0].Token = redacted
+				output.Users[1].Password = redacted
+				output.Users[1].Token = redacted
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			expected := newPathAccount()
+			testCase.output(&expected)
+
+			g.Expect(testCase.redact(newPathAccount())).To(gomega.Equal(expected), "paths should decide what is redacted")
+		})
+	}
+}
+
+func TestRedactWithJSONPathsOverDecodedJSON(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var input map[string]any
+
+	g.Expect(json.Unmarshal([]byte(`{
+		"users": [{"name": "alice", "password": "hunter2", "profile": {"token": "abc"}}],
+		"meta": {"token": "def", "request": "1"}
+	}`), &input)).To(gomega.Succeed())
+
+	g.Expect(rere.RedactWithDenyList(input, nil, rere.WithRedactPaths("$.users[*].password", "$..token"))).To(
+		gomega.Equal(map[string]any{
+			"users": []any{map[string]any{"name": "alice", "password": redacted, "profile": map[string]any{"token": redacted}}},
+			"meta":  map[string]any{"token": redacted, "request": "1"},
+		}), "JSONPath selectors should apply to values decoded by json.Unmarshal")
+}
+
+func TestRedactWithPathsTopLevelSlice(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := []pathUser{{Name: "alice", Password: "alice-password", Token: ""}}
+
+	g.Expect(rere.RedactWithDenyList(input, nil, rere.WithRedactPaths("[0].Password"))).To(gomega.Equal([]pathUser{
+		{Name: "alice", Password: redacted, Token: ""},
+	}), "paths should support top-level slices")
+}
+
+func TestWithRedactPathsPanicsForInvalidPaths(t *testing.T) {
+	t.Parallel()
+
+	for _, invalidPath := range []string{
+		"", "Users[0", "Users[first]", "Users...Name", "Users..", "Users[0]Name", ".Users", `Users["Name`, `Users["Name"`,
+		"$", "$Users", "$...Users",
+	} {
+		invalidPath := invalidPath
+
+		t.Run(invalidPath, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(func() { rere.WithRedactPaths(invalidPath) }).To(gomega.Panic(), "WithRedactPaths should panic")
+			g.Expect(func() { rere.WithKeepPaths(invalidPath) }).To(gomega.Panic(), "WithKeepPaths should panic")
+		})
+	}
+}
+
+func TestRedactWithQuotedPaths(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := map[string]map[string]string{
+		"Headers": {
+			"Set-Cookie": "session=secret",
+			"Accept":     "text/plain",
+		},
+		"Labels": {
+			"app.kubernetes.io/name": "billing",
+			`team["payments"]`:       "payments",
+			"owner":                  "alice",
+		},
+	}
+
+	var report rere.Report
+
+	output := rere.RedactWithDenyList(input, nil,
+		rere.WithRedactPaths(`Headers["Set-Cookie"]`, `Labels["app.kubernetes.io/name"]`, `labels["team[\"payments\"]"]`),
+		rere.WithReport(&report))
+
+	g.Expect(output).To(gomega.Equal(map[string]map[string]string{
+		"Headers": {
+			"Set-Cookie": redacted,
+			"Accept":     "text/plain",
+		},
+		"Labels": {
+			"app.kubernetes.io/name": redacted,
+			`team["payments"]`:       redacted,
+			"owner":                  "alice",
+		},
+	}), "quoted paths should match map keys")
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "Headers.Set-Cookie", Pointer: "/Headers/Set-Cookie", Value: redacted},
+		{Path: `Labels["app.kubernetes.io/name"]`, Pointer: "/Labels/app.kubernetes.io~1name", Value: redacted},
+		{Path: `Labels["team[\"payments\"]"]`, Pointer: `/Labels/team["payments"]`, Value: redacted},
+	}), "redaction paths should quote keys with dots and brackets")
+
+	for _, redaction := range report.Redactions {
+		g.Expect(rere.RedactWithDenyList(input, nil, rere.WithRedactPaths(redaction.Path))).ToNot(gomega.Equal(input),
+			"redaction paths should be usable as path rules")
+	}
+}
@@ -0,0 +1,104 @@
+package rere
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// regexpEntryDelimiter encloses list entries that are regular expressions, such as "/(?i).*secret.*/".
+	regexpEntryDelimiter = "/"
+	// globWildcard matches any sequence of characters in list entries that are globs, such as "*_token".
+	globWildcard = "*"
+)
+
+//nolint:gochecknoglobals // patterns are compiled once per entry and shared by every call
+var entryPatterns sync.Map
+
+// entryPatternKey identifies a compiled pattern in entryPatterns, since globs compile differently when matching names
+// case sensitively.
+type entryPatternKey struct {
+	entry         string
+	caseSensitive bool
+}
+
+// isPatternEntry reports whether a list entry is matched against names as a pattern instead of a name.
+func isPatternEntry(entry string) bool {
+	return isRegexpEntry(entry) || isGlobName(entryName(entry))
+}
+
+// isRegexpEntry reports whether a list entry is a regular expression enclosed in slashes.
+func isRegexpEntry(entry string) bool {
+	return len(entry) > len(regexpEntryDelimiter)*2 &&
+		strings.HasPrefix(entry, regexpEntryDelimiter) && strings.HasSuffix(entry, regexpEntryDelimiter)
+}
+
+// isGlobName reports whether the field or key name of a list entry is a glob.
+func isGlobName(name string) bool {
+	return strings.Contains(name, globWildcard)
+}
+
+// entryPattern returns the compiled pattern of a regular expression entry or glob name, compiling it the first time
+// it is used. entryPattern panics if a regular expression entry is not valid. See validateEntries.
+func entryPattern(entry string, caseSensitive bool) *regexp.Regexp {
+	key := entryPatternKey{entry: entry, caseSensitive: caseSensitive}
+
+	if pattern, found := entryPatterns.Load(key); found {
+		//nolint:forcetypeassert // only patterns are stored
+		return pattern.(*regexp.Regexp)
+	}
+
+	pattern, err := compileEntryPattern(entry, caseSensitive)
+	if err != nil {
+		panic("rere: " + err.Error())
+	}
+
+	entryPatterns.Store(key, pattern)
+
+	return pattern
+}
+
+// compileEntryPattern compiles a regular expression entry or glob name matching whole names, so "/.*secret.*/" matches
+// "client_secret" while "/secret/" only matches "secret". Globs match names case insensitively, the same as names,
+// unless caseSensitive is set by WithCaseSensitiveNames.
+func compileEntryPattern(entry string, caseSensitive bool) (*regexp.Regexp, error) {
+	if !isRegexpEntry(entry) {
+		parts := strings.Split(entry, globWildcard)
+		for i := range parts {
+			parts[i] = regexp.QuoteMeta(parts[i])
+		}
+
+		flags := "(?i)"
+		if caseSensitive {
+			flags = ""
+		}
+
+		return regexp.MustCompile(flags + "^" + strings.Join(parts, ".*") + "$"), nil
+	}
+
+	expression := strings.TrimSuffix(strings.TrimPrefix(entry, regexpEntryDelimiter), regexpEntryDelimiter)
+
+	pattern, err := regexp.Compile("^(?:" + expression + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid list entry %q: %w", entry, err)
+	}
+
+	return pattern, nil
+}
+
+// validateEntries returns an error for the first regular expression entry of entries that is not valid.
+func validateEntries(entries []string) error {
+	for _, entry := range entries {
+		if !isRegexpEntry(entry) {
+			continue
+		}
+
+		if _, err := compileEntryPattern(entry, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type apiClient struct {
+	Name         string
+	ClientSecret string
+	SecretKey    string `json:"secret_key"`
+	Endpoint     string
+}
+
+func TestRedactWithRegexpEntries(t *testing.T) {
+	t.Parallel()
+
+	input := apiClient{Name: "billing", ClientSecret: "abc", SecretKey: "def", Endpoint: "https://example.com"}
+
+	testCases := []struct {
+		name   string
+		redact func() apiClient
+		output apiClient
+	}{
+		{
+			name: "deny list entries match names with regular expressions",
+			redact: func() apiClient {
+				return rere.RedactWithDenyList(input, []string{"/(?i).*secret.*/"})
+			},
+			output: apiClient{Name: "billing", ClientSecret: redacted, SecretKey: redacted, Endpoint: "https://example.com"},
+		},
+		{
+			name: "allow list entries match names with regular expressions",
+			redact: func() apiClient {
+				return rere.RedactWithAllowList(input, []string{"/Name|Endpoint/"})
+			},
+			output: apiClient{Name: "billing", ClientSecret: redacted, SecretKey: redacted, Endpoint: "https://example.com"},
+		},
+		{
+			name: "regular expressions match whole names",
+			redact: func() apiClient {
+				return rere.RedactWithDenyList(input, []string{"/Secret/", "/Client/"})
+			},
+			output: input,
+		},
+		{
+			name: "regular expressions are case sensitive unless they opt out",
+			redact: func() apiClient {
+				return rere.RedactWithDenyList(input, []string{"/client.*/"})
+			},
+			output: input,
+		},
+		{
+			name: "regular expressions match tag names",
+			redact: func() apiClient {
+				return rere.RedactWithDenyList(input, []string{"/[a-z]+_key/"})
+			},
+			output: apiClient{Name: "billing", ClientSecret: "abc", SecretKey: redacted, Endpoint: "https://example.com"},
+		},
+		{
+			name: "regular expressions match in large lists",
+			redact: func() apiClient {
+				policy := rere.NewDenyListPolicy(append(generatedEntries("field%d", 100), "/.*Secret/"))
+
+				redactedClient, _ := policy.Redact(input).(apiClient)
+
+				return redactedClient
+			},
+			output: apiClient{Name: "billing", ClientSecret: redacted, SecretKey: "def", Endpoint: "https://example.com"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output), "entries should match names with regular expressions")
+		})
+	}
+}
+
+func TestRedactWithInvalidRegexpEntries(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(func() {
+		rere.RedactWithDenyList(apiClient{Name: "billing"}, []string{"/secret(/"})
+	}).To(gomega.PanicWith(gomega.HavePrefix(`rere: invalid list entry "/secret(/"`)),
+		"RedactWithDenyList should panic for invalid regular expression entries")
+}
+
+type serviceTokens struct {
+	AccessToken  string
+	RefreshToken string `json:"refresh_token"`
+	APIKey       string
+	APIPublicKey string
+	Tokenizer    string
+}
+
+func TestRedactWithGlobEntries(t *testing.T) {
+	t.Parallel()
+
+	input := serviceTokens{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		APIKey:       "key",
+		APIPublicKey: "public",
+		Tokenizer:    "tokenizer",
+	}
+
+	testCases := []struct {
+		name   string
+		redact func() serviceTokens
+		output serviceTokens
+	}{
+		{
+			name: "deny list entries match name suffixes case insensitively",
+			redact: func() serviceTokens {
+				return rere.RedactWithDenyList(input, []string{"*TOKEN"})
+			},
+			output: serviceTokens{
+				AccessToken:  redacted,
+				RefreshToken: redacted,
+				APIKey:       "key",
+				APIPublicKey: "public",
+				Tokenizer:    "tokenizer",
+			},
+		},
+		{
+			name: "deny list entries match name prefixes and suffixes",
+			redact: func() serviceTokens {
+				return rere.RedactWithDenyList(input, []string{"api*key"})
+			},
+			output: serviceTokens{
+				AccessToken:  "access",
+				RefreshToken: "refresh",
+				APIKey:       redacted,
+				APIPublicKey: redacted,
+				Tokenizer:    "tokenizer",
+			},
+		},
+		{
+			name: "allow list entries match tag names with globs",
+			redact: func() serviceTokens {
+				return rere.RedactWithAllowList(input, []string{"*_token", "token*"})
+			},
+			output: serviceTokens{
+				AccessToken:  redacted,
+				RefreshToken: "refresh",
+				APIKey:       redacted,
+				APIPublicKey: redacted,
+				Tokenizer:    "tokenizer",
+			},
+		},
+		{
+			name: "type-scoped entries match names with globs",
+			redact: func() serviceTokens {
+				return rere.RedactWithDenyList(input, []string{"rere_test.serviceTokens:access*"})
+			},
+			output: serviceTokens{
+				AccessToken:  redacted,
+				RefreshToken: "refresh",
+				APIKey:       "key",
+				APIPublicKey: "public",
+				Tokenizer:    "tokenizer",
+			},
+		},
+		{
+			name: "globs match in large lists",
+			redact: func() serviceTokens {
+				policy := rere.NewDenyListPolicy(append(generatedEntries("field%d", 100), "*token"))
+
+				redactedTokens, _ := policy.Redact(input).(serviceTokens)
+
+				return redactedTokens
+			},
+			output: serviceTokens{
+				AccessToken:  redacted,
+				RefreshToken: redacted,
+				APIKey:       "key",
+				APIPublicKey: "public",
+				Tokenizer:    "tokenizer",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output), "entries should match names with globs")
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package rere
+
+// Pipe returns a channel receiving a redacted deep copy of every value received from in, so redaction can be a stage of
+// a channel pipeline. Up to concurrency values are redacted at once, and values are redacted one at a time when
+// concurrency is 1 or less. Values are sent in the order they are received, even when redacted concurrently. The
+// returned channel is closed after in is closed and every value has been sent.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided. A Report provided with
+// WithReport must not be combined with a concurrency above 1, since a Report must not be shared by concurrent
+// redactions.
+func Pipe[T any](in <-chan T, concurrency int, opts ...Option) <-chan T {
+	concurrency = max(concurrency, 1)
+
+	out := make(chan T)
+
+	// pending holds a channel per value being redacted in the order values were received. Its capacity and the value
+	// waiting to be sent bound how many values are redacted at once.
+	pending := make(chan chan T, concurrency-1)
+
+	go func() {
+		defer close(pending)
+
+		for value := range in {
+			result := make(chan T, 1)
+			pending <- result
+
+			go func(value T) {
+				result <- redactValue(value, newOptions(allow, nil, opts))
+			}(value)
+		}
+	}()
+
+	go func() {
+		defer close(out)
+
+		for result := range pending {
+			out <- <-result
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,67 @@
+package rere_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type pipeEvent struct {
+	ID     string
+	Secret string
+}
+
+func TestPipe(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		concurrency int
+	}{
+		{
+			name:        "redacts values one at a time",
+			concurrency: 0,
+		},
+		{
+			name:        "redacts values concurrently in order",
+			concurrency: 4,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			in := make(chan *pipeEvent)
+			inputs := make([]*pipeEvent, 0, 20)
+
+			go func() {
+				defer close(in)
+
+				for i := 0; i < 20; i++ {
+					event := &pipeEvent{ID: strconv.Itoa(i), Secret: "secret"}
+					inputs = append(inputs, event)
+					in <- event
+				}
+			}()
+
+			outputs := make([]*pipeEvent, 0, 20)
+			for event := range rere.Pipe(in, testCase.concurrency, rere.WithAllowList("id")) {
+				outputs = append(outputs, event)
+			}
+
+			g.Expect(outputs).To(gomega.HaveLen(20), "Pipe should send every value")
+
+			for i, event := range outputs {
+				g.Expect(event).To(gomega.Equal(&pipeEvent{ID: strconv.Itoa(i), Secret: redacted}),
+					"Pipe should send redacted values in order")
+				g.Expect(inputs[i].Secret).To(gomega.Equal("secret"), "Pipe should not modify received values")
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package rere
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	bytesKind  = "bytes"
+	stringKind = "string"
+
+	correlationKeyLength    = 32
+	correlationSuffixLength = 2
+)
+
+//nolint:gochecknoglobals // the key must be shared by every redaction in the process to correlate values
+var processCorrelationKey = func() []byte {
+	key := make([]byte, correlationKeyLength)
+
+	// crypto/rand.Read never returns an error on supported platforms
+	_, _ = rand.Read(key)
+
+	return key
+}()
+
+// placeholder returns the value used to replace a redacted original string or byte slice value of the provided kind.
+func (o *options) placeholder(valueLocation location, kind string, originalValue reflect.Value) string {
+	if originalValue.Kind() == reflect.String {
+		return o.placeholderFor(valueLocation, kind, []byte(originalValue.String()), originalValue.Type())
+	}
+
+	return o.placeholderFor(valueLocation, kind, originalValue.Bytes(), originalValue.Type())
+}
+
+// placeholderFor returns the value used to replace original of the provided kind and type without requiring a
+// reflect.Value, so fast paths can avoid reflection.
+func (o *options) placeholderFor(
+	valueLocation location,
+	kind string,
+	original []byte,
+	originalType reflect.Type,
+) string {
+	if override, found := o.placeholderOverride(valueLocation); found {
+		return override
+	}
+
+	if token, enveloped := o.envelopeToken(original, originalType); enveloped {
+		return token
+	}
+
+	message := o.placeholderMessage
+
+	switch {
+	case o.typeNamePlaceholders:
+		message = "<" + strings.ToLower(message) + " " + originalType.String() + ">"
+	case o.kindAnnotatedPlaceholders:
+		message = "[" + message + " " + kind + "]"
+	}
+
+	if stamp := o.policyStamp(); stamp != "" {
+		message += "[" + stamp + "]"
+	}
+
+	if o.lengthHint {
+		message += "(" + strconv.Itoa(len(original)) + ")"
+	}
+
+	if o.correlationSuffix {
+		message += ":" + o.correlationSuffixFor(original)
+	}
+
+	return message + o.placeholderNonce
+}
+
+// policyStamp returns the policy name and version appended to placeholders by WithPolicyStampedPlaceholders, such as
+// "pci-v3".
+func (o *options) policyStamp() string {
+	if !o.policyStampedPlaceholders {
+		return ""
+	}
+
+	switch {
+	case o.policyName != "" && o.policyVersion != "":
+		return o.policyName + "-" + o.policyVersion
+	case o.policyName != "":
+		return o.policyName
+	default:
+		return o.policyVersion
+	}
+}
+
+// bytesPlaceholder returns the value used to replace a redacted byte slice or byte array value, keeping the first
+// bytes of the original value when configured by WithBytePrefix.
+func (o *options) bytesPlaceholder(valueLocation location, originalValue reflect.Value) string {
+	return o.bytesPlaceholderFor(valueLocation, byteValues(originalValue), originalValue.Type())
+}
+
+// bytesPlaceholderFor is the same as bytesPlaceholder without requiring a reflect.Value, so fast paths can avoid
+// reflection.
+func (o *options) bytesPlaceholderFor(valueLocation location, original []byte, originalType reflect.Type) string {
+	if override, found := o.placeholderOverride(valueLocation); found {
+		return override
+	}
+
+	if token, enveloped := o.envelopeToken(original, originalType); enveloped {
+		return token
+	}
+
+	if o.binaryBytes == NoteBinary && !looksLikeText(original) {
+		return "<binary " + strconv.Itoa(len(original)) + " bytes>"
+	}
+
+	message := o.placeholderFor(valueLocation, bytesKind, original, originalType)
+
+	if o.bytePrefixLength > 0 && len(original) > o.bytePrefixLength {
+		return string(original[:o.bytePrefixLength]) + message
+	}
+
+	return message
+}
+
+// placeholderBytes returns placeholder as a byte slice whose capacity is its length, so appending to a redacted value
+// never writes to the placeholder. The byte slice of the previous placeholder is reused when it is equal, since most
+// values redacted by a call share the same placeholder. See WithCopiedPlaceholders.
+func (o *options) placeholderBytes(placeholder string) []byte {
+	if !o.copiedPlaceholders && o.internedPlaceholder != nil && string(o.internedPlaceholder) == placeholder {
+		return o.internedPlaceholder
+	}
+
+	placeholderBytes := []byte(placeholder)
+	placeholderBytes = placeholderBytes[:len(placeholderBytes):len(placeholderBytes)]
+
+	if !o.copiedPlaceholders {
+		o.internedPlaceholder = placeholderBytes
+	}
+
+	return placeholderBytes
+}
+
+// correlationSuffixFor returns the correlation suffix of original, prefixed with the key ID of the correlation key when
+// provided by WithCorrelationKeyring.
+func (o *options) correlationSuffixFor(original []byte) string {
+	if o.correlationKeyID != "" {
+		return o.correlationKeyID + keyIDSeparator + correlationHash(o.correlationKey, original)
+	}
+
+	return correlationHash(o.correlationKey, original)
+}
+
+func correlationHash(key, original []byte) string {
+	return hex.EncodeToString(keyedHash(key, original)[:correlationSuffixLength])
+}
+
+func keyedHash(key, original []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+
+	// hash.Hash.Write never returns an error
+	_, _ = mac.Write(original)
+
+	return mac.Sum(nil)
+}
+
+// fieldPlaceholder replaces values redacted at field or key names matching entry with placeholder.
+type fieldPlaceholder struct {
+	entry       string
+	placeholder string
+}
+
+// pathPlaceholders replaces values redacted at paths matching rules with the placeholder of the matching rule, keyed by
+// the rule's raw path.
+type pathPlaceholders struct {
+	rules        *pathRuleSet
+	placeholders map[string]string
+}
+
+// WithFieldPlaceholders replaces values redacted at field or key names with specific placeholders instead of the
+// global placeholder, such as {"Password": "********", "CardNumber": "####-####-####-XXXX"}, which is useful when
+// redacted values are displayed to end users. Names are matched case insensitively and can be scoped to a type, the
+// same as list entries, such as "billing.Card:Number". Entries scoped to a type take priority over other entries.
+//
+// Placeholder overrides are used as-is, so placeholder options such as WithLengthHint and WithCorrelationSuffix don't
+// apply to them.
+func WithFieldPlaceholders(placeholders map[string]string) Option {
+	entries := make([]fieldPlaceholder, 0, len(placeholders))
+	for _, entry := range slices.Sorted(maps.Keys(placeholders)) {
+		entries = append(entries, fieldPlaceholder{entry: entry, placeholder: placeholders[entry]})
+	}
+
+	// check entries scoped to a type first, so they take priority over other entries
+	slices.SortStableFunc(entries, func(first, second fieldPlaceholder) int {
+		_, _, firstScoped := typeScope(first.entry)
+		_, _, secondScoped := typeScope(second.entry)
+
+		switch {
+		case firstScoped && !secondScoped:
+			return -1
+		case !firstScoped && secondScoped:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return func(o *options) {
+		o.fieldPlaceholders = append(o.fieldPlaceholders, entries...)
+	}
+}
+
+// WithPathPlaceholders replaces values redacted at paths with specific placeholders instead of the global placeholder,
+// such as {"Billing.Card.Number": "####-####-####-XXXX"}. Paths use the same form as WithRedactPaths and match every
+// value nested inside of them. When multiple paths match a value, the longest path decides. Path placeholders take
+// priority over WithFieldPlaceholders.
+//
+// WithPathPlaceholders panics if a path is invalid, the same as WithRedactPaths.
+func WithPathPlaceholders(placeholders map[string]string) Option {
+	// the redact flag of rules is unused, since rules only select placeholders
+	rules := mustParsePathRules(slices.Sorted(maps.Keys(placeholders)), true)
+
+	return func(o *options) {
+		o.pathPlaceholders = append(o.pathPlaceholders, pathPlaceholders{rules: rules, placeholders: placeholders})
+	}
+}
+
+// placeholderOverride returns the placeholder provided by WithPathPlaceholders or WithFieldPlaceholders for the value
+// at valueLocation.
+func (o *options) placeholderOverride(valueLocation location) (string, bool) {
+	var (
+		best        pathMatch
+		placeholder string
+	)
+
+	for _, overrides := range o.pathPlaceholders {
+		previous := best.rule
+
+		overrides.rules.trie.match(valueLocation.path, 0, &best)
+
+		if best.rule != previous {
+			placeholder = overrides.placeholders[best.rule.raw]
+		}
+	}
+
+	if best.rule != nil {
+		return placeholder + o.placeholderNonce, true
+	}
+
+	if valueLocation.name == "" {
+		return "", false
+	}
+
+	for _, override := range o.fieldPlaceholders {
+		if o.matchesEntry(override.entry, valueLocation) {
+			return override.placeholder + o.placeholderNonce, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,290 @@
+package rere_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type (
+	apiKey     string
+	privateKey []byte
+)
+
+type structWithNamedTypes struct {
+	APIKey     any
+	PrivateKey privateKey
+	Signature  [4]byte
+}
+
+func TestRedactWithPlaceholderOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		input  any
+		opts   []rere.Option
+		output any
+	}{
+		{
+			name: "annotates placeholders with kind",
+			input: structWithRedactedFields{
+				Username:  "username",
+				username:  "",
+				Password:  "password",
+				password:  "",
+				byteSlice: []byte("password"),
+				stringPtr: nil,
+			},
+			opts: []rere.Option{rere.WithKindAnnotatedPlaceholders()},
+			output: structWithRedactedFields{
+				Username:  "[REDACTED string]",
+				username:  "",
+				Password:  "[REDACTED string]",
+				password:  "",
+				byteSlice: []byte("[REDACTED bytes]"),
+				stringPtr: nil,
+			},
+		},
+		{
+			name: "includes length of original values",
+			input: structWithRedactedFields{
+				Username:  "username",
+				username:  "",
+				Password:  "hunter2",
+				password:  "",
+				byteSlice: []byte("password"),
+				stringPtr: nil,
+			},
+			opts: []rere.Option{rere.WithLengthHint()},
+			output: structWithRedactedFields{
+				Username:  "REDACTED(8)",
+				username:  "",
+				Password:  "REDACTED(7)",
+				password:  "",
+				byteSlice: []byte("REDACTED(8)"),
+				stringPtr: nil,
+			},
+		},
+		{
+			name:   "combines kind annotations and length hints",
+			input:  []string{"hunter2"},
+			opts:   []rere.Option{rere.WithLengthHint(), rere.WithKindAnnotatedPlaceholders()},
+			output: []string{"[REDACTED string](7)"},
+		},
+		{
+			name:  "stamps placeholders with policy name and version",
+			input: []string{"hunter2"},
+			opts: []rere.Option{
+				rere.WithPolicyStampedPlaceholders(), rere.WithPolicyName("pci"), rere.WithPolicyVersion("v3"),
+				rere.WithLengthHint(),
+			},
+			output: []string{"REDACTED[pci-v3](7)"},
+		},
+		{
+			name:   "stamps placeholders with policy version only",
+			input:  []string{"hunter2"},
+			opts:   []rere.Option{rere.WithPolicyStampedPlaceholders(), rere.WithPolicyVersion("v3")},
+			output: []string{"REDACTED[v3]"},
+		},
+		{
+			name:   "leaves placeholders as-is without policy name or version",
+			input:  []string{"hunter2"},
+			opts:   []rere.Option{rere.WithPolicyStampedPlaceholders()},
+			output: []string{redacted},
+		},
+		{
+			name: "redacts named byte slices and byte arrays",
+			input: structWithNamedTypes{
+				APIKey:     apiKey("key"),
+				PrivateKey: privateKey("private key"),
+				Signature:  [4]byte{1, 2, 3, 4},
+			},
+			opts: nil,
+			output: structWithNamedTypes{
+				APIKey:     apiKey(redacted),
+				PrivateKey: privateKey(redacted),
+				Signature:  [4]byte{'R', 'E', 'D', 'A'},
+			},
+		},
+		{
+			name: "includes type names",
+			input: structWithNamedTypes{
+				APIKey:     apiKey("key"),
+				PrivateKey: privateKey("private key"),
+				Signature:  [4]byte{},
+			},
+			opts: []rere.Option{rere.WithTypeNamePlaceholders(), rere.WithKindAnnotatedPlaceholders()},
+			output: structWithNamedTypes{
+				APIKey:     apiKey("<redacted rere_test.apiKey>"),
+				PrivateKey: privateKey("<redacted rere_test.privateKey>"),
+				Signature:  [4]byte{},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			redacted := rere.RedactWithAllowList(testCase.input, nil, testCase.opts...)
+
+			g.Expect(redacted).To(gomega.Equal(testCase.output), "RedactWithAllowList should use configured placeholders")
+		})
+	}
+}
+
+func TestRedactWithCorrelationSuffix(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := map[string]string{
+		"first":  "token",
+		"second": "token",
+		"third":  "other token",
+	}
+
+	redacted := rere.RedactWithAllowList(input, nil, rere.WithCorrelationSuffix())
+
+	g.Expect(redacted["first"]).To(gomega.MatchRegexp(`^REDACTED:[0-9a-f]{4}$`), "should append a short hash")
+	g.Expect(redacted["second"]).To(gomega.Equal(redacted["first"]), "equal values should have equal suffixes")
+	g.Expect(redacted["third"]).ToNot(gomega.Equal(redacted["first"]), "different values should have different suffixes")
+
+	withKey := rere.RedactWithAllowList(input, nil, rere.WithCorrelationSuffix(), rere.WithCorrelationKey([]byte("key")))
+	withSameKey := rere.RedactWithAllowList(input, nil, rere.WithCorrelationKey([]byte("key")), rere.WithCorrelationSuffix())
+	withOtherKey := rere.RedactWithAllowList(input, nil, rere.WithCorrelationSuffix(), rere.WithCorrelationKey([]byte("other")))
+
+	g.Expect(withKey).To(gomega.Equal(withSameKey), "the same key should produce the same suffixes")
+	g.Expect(withKey["first"]).ToNot(gomega.Equal(withOtherKey["first"]), "a different key should produce different suffixes")
+
+	bytesRedacted := rere.RedactWithAllowList([]byte("token"), nil, rere.WithCorrelationSuffix(), rere.WithKindAnnotatedPlaceholders())
+
+	g.Expect(regexp.MustCompile(`^\[REDACTED bytes\]:[0-9a-f]{4}$`).Match(bytesRedacted)).To(gomega.BeTrue(),
+		"should combine kind annotations and correlation suffixes")
+}
+
+func TestRedactWithBytePrefix(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := structWithByteSlice{
+		Password: []byte("\x89PNG\r\n\x1a\nimage data"),
+		password: []byte("key"),
+	}
+
+	redactedInput := rere.RedactWithAllowList(input, nil, rere.WithBytePrefix(4), rere.WithLengthHint())
+
+	g.Expect(redactedInput).To(gomega.Equal(structWithByteSlice{
+		Password: []byte("\x89PNGREDACTED(18)"),
+		password: []byte("REDACTED(3)"),
+	}), "WithBytePrefix should keep the first bytes of redacted values longer than the prefix")
+}
+
+func TestRedactSharesPlaceholderBytes(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := structWithByteSlices{
+		Token:   []byte("token"),
+		Public:  []byte("public"),
+		Payload: map[string][]byte{"token": []byte("token")},
+		Values:  nil,
+	}
+
+	redactedInput := rere.Redact(input)
+
+	g.Expect(redactedInput.Token).To(gomega.Equal([]byte(redacted)), "Redact should redact byte slices")
+	g.Expect(&redactedInput.Public[0]).To(gomega.BeIdenticalTo(&redactedInput.Token[0]),
+		"Redact should share the bytes of equal placeholders")
+	g.Expect(&redactedInput.Payload["token"][0]).To(gomega.BeIdenticalTo(&redactedInput.Token[0]),
+		"Redact should share the bytes of equal placeholders in maps")
+	g.Expect(cap(redactedInput.Token)).To(gomega.Equal(len(redactedInput.Token)),
+		"shared placeholders should not have capacity beyond their length")
+
+	redactedInput.Token = append(redactedInput.Token, '!')
+	g.Expect(redactedInput.Public).To(gomega.Equal([]byte(redacted)),
+		"appending to a redacted value should not modify shared placeholders")
+
+	copiedInput := rere.Redact(input, rere.WithCopiedPlaceholders())
+
+	g.Expect(copiedInput.Public).To(gomega.Equal([]byte(redacted)), "WithCopiedPlaceholders should redact byte slices")
+	g.Expect(&copiedInput.Public[0]).NotTo(gomega.BeIdenticalTo(&copiedInput.Token[0]),
+		"WithCopiedPlaceholders should redact every byte slice with its own placeholder")
+
+	copiedInput.Token[0] = 'r'
+	g.Expect(copiedInput.Public).To(gomega.Equal([]byte(redacted)),
+		"modifying a copied placeholder should not modify other redacted values")
+}
+
+type billingCard struct {
+	Holder string
+	Number string
+	CVV    []byte
+}
+
+type billingAccount struct {
+	Password string
+	Card     billingCard
+	Backup   billingCard
+}
+
+func TestRedactWithPlaceholderOverrides(t *testing.T) {
+	t.Parallel()
+
+	input := billingAccount{
+		Password: "hunter2",
+		Card:     billingCard{Holder: "alice", Number: "4111111111111111", CVV: []byte("123")},
+		Backup:   billingCard{Holder: "bob", Number: "5500000000000004", CVV: []byte("456")},
+	}
+
+	testCases := []struct {
+		name   string
+		opts   []rere.Option
+		output billingAccount
+	}{
+		{
+			name: "replaces values at field names with overrides",
+			opts: []rere.Option{
+				rere.WithFieldPlaceholders(map[string]string{"password": "********", "Number": "####-####-####-XXXX"}),
+				rere.WithLengthHint(),
+			},
+			output: billingAccount{
+				Password: "********",
+				Card:     billingCard{Holder: "REDACTED(5)", Number: "####-####-####-XXXX", CVV: []byte("REDACTED(3)")},
+				Backup:   billingCard{Holder: "REDACTED(3)", Number: "####-####-####-XXXX", CVV: []byte("REDACTED(3)")},
+			},
+		},
+		{
+			name: "prefers path overrides and type-scoped names",
+			opts: []rere.Option{
+				rere.WithFieldPlaceholders(map[string]string{
+					"cvv":                          "***",
+					"rere_test.billingCard:cvv":    "###",
+					"rere_test.billingCard:holder": "card holder",
+				}),
+				rere.WithPathPlaceholders(map[string]string{"Backup": "backup card", "Backup.CVV": "backup cvv"}),
+			},
+			output: billingAccount{
+				Password: redacted,
+				Card:     billingCard{Holder: "card holder", Number: redacted, CVV: []byte("###")},
+				Backup:   billingCard{Holder: "backup card", Number: "backup card", CVV: []byte("backup cvv")},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			redactedInput := rere.RedactWithAllowList(input, nil, testCase.opts...)
+
+			g.Expect(redactedInput).To(gomega.Equal(testCase.output), "RedactWithAllowList should use placeholder overrides")
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package rere
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+var errInvalidPolicyMode = errors.New(`mode must be "allow" or "deny"`)
+
+// PolicyFile is the form of a policy file parsed by ParsePolicy. Policy files are YAML or JSON, such as:
+//
+//	name: payments
+//	version: v3
+//	mode: allow
+//	list: [id, status]
+//	redactPaths: ["Metadata.token"]
+type PolicyFile struct {
+	// Name names the policy for audit events, the same as WithPolicyName.
+	Name string `json:"name" yaml:"name"`
+	// Version is the version of the policy for audit events and reports, the same as WithPolicyVersion.
+	Version string `json:"version" yaml:"version"`
+	// Mode is "allow" to redact every value except for names in List, the same as NewAllowListPolicy, or "deny" to
+	// only redact names in List, the same as NewDenyListPolicy.
+	Mode string `json:"mode" yaml:"mode"`
+	// List is the allow or deny list of field and key names.
+	List []string `json:"list" yaml:"list"`
+	// RedactPaths are provided to WithRedactPaths.
+	RedactPaths []string `json:"redactPaths" yaml:"redactPaths"`
+	// KeepPaths are provided to WithKeepPaths.
+	KeepPaths []string `json:"keepPaths" yaml:"keepPaths"`
+	// RedactTypes are provided to WithRedactTypes.
+	RedactTypes []string `json:"redactTypes" yaml:"redactTypes"`
+	// RedactPackages are provided to WithRedactPackages.
+	RedactPackages []string `json:"redactPackages" yaml:"redactPackages"`
+}
+
+// ParsePolicy parses a policy file and creates a Policy from it. opts are applied after the options described by the
+// policy file, so code can add options policy files don't describe, such as placeholders. Unknown keys are rejected,
+// so a misspelled key doesn't silently leave values unredacted.
+func ParsePolicy(data []byte, opts ...Option) (*Policy, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var policyFile PolicyFile
+
+	if err := decoder.Decode(&policyFile); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	return policyFile.Policy(opts...)
+}
+
+// LoadPolicy reads the policy file at filePath and creates a Policy from it with ParsePolicy.
+func LoadPolicy(filePath string, opts ...Option) (*Policy, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	return ParsePolicy(data, opts...)
+}
+
+// Policy creates a Policy from the policy file. opts are applied after the options described by the policy file.
+func (f PolicyFile) Policy(opts ...Option) (*Policy, error) {
+	redactPaths, err := parsePathRules(f.RedactPaths, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy redact paths: %w", err)
+	}
+
+	keepPaths, err := parsePathRules(f.KeepPaths, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy keep paths: %w", err)
+	}
+
+	if err := validateEntries(f.List); err != nil {
+		return nil, fmt.Errorf("failed to parse policy list: %w", err)
+	}
+
+	// only options described by the policy file are added, so defaults installed by SetDefaults still apply
+	var fileOpts []Option
+
+	if len(redactPaths.rules) != 0 {
+		fileOpts = append(fileOpts, withPathRules(redactPaths))
+	}
+
+	if len(keepPaths.rules) != 0 {
+		fileOpts = append(fileOpts, withPathRules(keepPaths))
+	}
+
+	if len(f.RedactTypes) != 0 {
+		fileOpts = append(fileOpts, WithRedactTypes(f.RedactTypes...))
+	}
+
+	if len(f.RedactPackages) != 0 {
+		fileOpts = append(fileOpts, WithRedactPackages(f.RedactPackages...))
+	}
+
+	if f.Name != "" {
+		fileOpts = append(fileOpts, WithPolicyName(f.Name))
+	}
+
+	if f.Version != "" {
+		fileOpts = append(fileOpts, WithPolicyVersion(f.Version))
+	}
+
+	fileOpts = append(fileOpts, opts...)
+
+	switch redactMode(f.Mode) {
+	case allow:
+		return NewAllowListPolicy(f.List, fileOpts...), nil
+	case deny:
+		return NewDenyListPolicy(f.List, fileOpts...), nil
+	default:
+		return nil, fmt.Errorf("%w, got %q", errInvalidPolicyMode, f.Mode)
+	}
+}
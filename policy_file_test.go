@@ -0,0 +1,138 @@
+package rere_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type policyFileUser struct {
+	Name     string
+	Password string
+	Metadata map[string]string
+}
+
+func TestParsePolicy(t *testing.T) {
+	t.Parallel()
+
+	input := policyFileUser{
+		Name:     "alice",
+		Password: "hunter2",
+		Metadata: map[string]string{"region": "us-east-1", "token": "secret"},
+	}
+
+	testCases := []struct {
+		name   string
+		policy string
+		output policyFileUser
+	}{
+		{
+			name:   "parses allow list policies",
+			policy: "mode: allow\nlist: [name, region, token]\nredactPaths: [Metadata.token]\n",
+			output: policyFileUser{
+				Name:     "alice",
+				Password: redacted,
+				Metadata: map[string]string{"region": "us-east-1", "token": redacted},
+			},
+		},
+		{
+			name:   "parses deny list policies",
+			policy: "mode: deny\nlist: [password]\nkeepPaths: [Password]\nredactPaths: [Metadata]\n",
+			output: policyFileUser{
+				Name:     "alice",
+				Password: "hunter2",
+				Metadata: map[string]string{"region": redacted, "token": redacted},
+			},
+		},
+		{
+			name:   "parses JSON policies",
+			policy: `{"mode": "deny", "list": ["password", "token"]}`,
+			output: policyFileUser{
+				Name:     "alice",
+				Password: redacted,
+				Metadata: map[string]string{"region": "us-east-1", "token": redacted},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			policy, err := rere.ParsePolicy([]byte(testCase.policy))
+
+			g.Expect(err).NotTo(gomega.HaveOccurred(), "ParsePolicy should parse the policy")
+			g.Expect(policy.Redact(input)).To(gomega.Equal(testCase.output), "ParsePolicy should create the described policy")
+		})
+	}
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{
+			name:   "rejects unknown keys",
+			policy: "mode: allow\nallowList: [name]\n",
+			err:    "field allowList not found",
+		},
+		{
+			name:   "rejects unknown modes",
+			policy: "mode: redact\n",
+			err:    `mode must be "allow" or "deny", got "redact"`,
+		},
+		{
+			name:   "rejects invalid paths",
+			policy: "mode: allow\nredactPaths: [\"Users[\"]\n",
+			err:    `invalid path "Users["`,
+		},
+		{
+			name:   "rejects invalid regular expression entries",
+			policy: "mode: allow\nlist: [\"/secret(/\"]\n",
+			err:    `failed to parse policy list: invalid list entry "/secret(/"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			_, err := rere.ParsePolicy([]byte(testCase.policy))
+
+			g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(testCase.err)), "ParsePolicy should return an error")
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+
+	g.Expect(os.WriteFile(policyPath, []byte("name: users\nversion: v1\nmode: allow\nlist: [name]\n"), 0o600)).To(gomega.Succeed())
+
+	policy, err := rere.LoadPolicy(policyPath, rere.WithKindAnnotatedPlaceholders(), rere.WithPolicyStampedPlaceholders())
+
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "LoadPolicy should load the policy")
+	g.Expect(policy.Redact(credentialDetails{Name: "alice", Secret: "hunter2"})).To(
+		gomega.Equal(credentialDetails{Name: "alice", Secret: "[REDACTED string][users-v1]"}),
+		"LoadPolicy should apply options after the policy file",
+	)
+
+	_, err = rere.LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to read policy")),
+		"LoadPolicy should return an error for missing files")
+}
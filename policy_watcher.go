@@ -0,0 +1,147 @@
+package rere
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// PolicyWatcher is a Redactor redacting values with a Policy loaded from a policy file and reloaded whenever the file
+// changes, so redaction rules can be tightened in production without redeploying services. Reloaded policies are
+// swapped in atomically, so every call to Redact uses either the previous or the reloaded Policy.
+type PolicyWatcher struct {
+	filePath      string
+	opts          []Option
+	onReloadError func(error)
+
+	policy atomic.Pointer[Policy]
+	// contents are the contents of the last loaded policy file, so events not changing the file are ignored. Contents
+	// are only accessed by the goroutine watching the file after NewPolicyWatcher returns.
+	contents []byte
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+var _ Redactor = (*PolicyWatcher)(nil)
+
+// NewPolicyWatcher loads the policy file at filePath with ParsePolicy and opts, then watches it for changes until Close
+// is called. NewPolicyWatcher returns an error when the policy file can't be loaded.
+//
+// When a changed policy file can't be loaded, the previous Policy keeps being used and onReloadError is called with the
+// error, such as to log it or alert on it. onReloadError can be nil to ignore reload errors.
+//
+// The directory of the policy file is watched instead of the file itself, so policy files replaced by renaming a new
+// file over them, such as by editors and Kubernetes ConfigMap volumes, keep being watched.
+func NewPolicyWatcher(filePath string, onReloadError func(error), opts ...Option) (*PolicyWatcher, error) {
+	policyWatcher := &PolicyWatcher{
+		filePath:      filepath.Clean(filePath),
+		opts:          opts,
+		onReloadError: onReloadError,
+
+		policy:   atomic.Pointer[Policy]{},
+		contents: nil,
+
+		watcher: nil,
+		done:    make(chan struct{}),
+	}
+
+	if err := policyWatcher.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy watcher: %w", err)
+	}
+
+	if err := watcher.Add(filepath.Dir(policyWatcher.filePath)); err != nil {
+		_ = watcher.Close()
+
+		return nil, fmt.Errorf("failed to watch policy: %w", err)
+	}
+
+	policyWatcher.watcher = watcher
+
+	go policyWatcher.watch()
+
+	return policyWatcher, nil
+}
+
+// Redact returns a redacted deep copy of value with the current Policy.
+func (w *PolicyWatcher) Redact(value any) any {
+	return w.policy.Load().Redact(value)
+}
+
+// Close stops watching the policy file. Redact keeps using the last loaded Policy after Close.
+func (w *PolicyWatcher) Close() error {
+	err := w.watcher.Close()
+
+	<-w.done
+
+	if err != nil {
+		return fmt.Errorf("failed to close policy watcher: %w", err)
+	}
+
+	return nil
+}
+
+func (w *PolicyWatcher) watch() {
+	defer close(w.done)
+
+	for {
+		select {
+		case _, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			// every event in the directory reloads the policy file, since a policy file replaced through symlinks,
+			// such as a Kubernetes ConfigMap volume, only changes other names in the directory
+			if err := w.reload(); err != nil {
+				w.reportReloadError(err)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			w.reportReloadError(fmt.Errorf("failed to watch policy: %w", err))
+		}
+	}
+}
+
+// reload loads the policy file and swaps in the loaded Policy when the contents of the policy file changed.
+func (w *PolicyWatcher) reload() error {
+	contents, err := os.ReadFile(w.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	if w.contents != nil && bytes.Equal(contents, w.contents) {
+		return nil
+	}
+
+	policy, err := ParsePolicy(contents, w.opts...)
+	if err != nil {
+		return err
+	}
+
+	w.contents = contents
+
+	if previous := w.policy.Swap(policy); previous != nil {
+		previous.Close()
+	}
+
+	return nil
+}
+
+func (w *PolicyWatcher) reportReloadError(err error) {
+	if w.onReloadError != nil {
+		w.onReloadError(err)
+	}
+}
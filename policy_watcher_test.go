@@ -0,0 +1,75 @@
+package rere_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestPolicyWatcher(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+
+	g.Expect(os.WriteFile(policyPath, []byte("mode: allow\nlist: [name, secret]\n"), 0o600)).To(gomega.Succeed())
+
+	var (
+		reloadErrors []error
+		mutex        sync.Mutex
+	)
+
+	watcher, err := rere.NewPolicyWatcher(policyPath, func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		reloadErrors = append(reloadErrors, err)
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewPolicyWatcher should load the policy")
+
+	defer func() {
+		g.Expect(watcher.Close()).To(gomega.Succeed(), "Close should stop watching the policy")
+	}()
+
+	input := credentialDetails{Name: "alice", Secret: "hunter2"}
+
+	g.Expect(watcher.Redact(input)).To(gomega.Equal(input), "Redact should use the loaded policy")
+
+	// replace the policy file by renaming a new file over it, the same as editors
+	newPolicyPath := filepath.Join(filepath.Dir(policyPath), "policy.yaml.tmp")
+	g.Expect(os.WriteFile(newPolicyPath, []byte("mode: allow\nlist: [name]\n"), 0o600)).To(gomega.Succeed())
+	g.Expect(os.Rename(newPolicyPath, policyPath)).To(gomega.Succeed())
+
+	g.Eventually(func() any {
+		return watcher.Redact(input)
+	}).Should(gomega.Equal(credentialDetails{Name: "alice", Secret: redacted}), "Redact should use the reloaded policy")
+
+	g.Expect(os.WriteFile(policyPath, []byte("mode: invalid\n"), 0o600)).To(gomega.Succeed())
+
+	g.Eventually(func() []error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return reloadErrors
+	}).Should(gomega.ContainElement(gomega.MatchError(gomega.ContainSubstring(`got "invalid"`))),
+		"NewPolicyWatcher should report reload errors")
+	g.Expect(watcher.Redact(input)).To(gomega.Equal(credentialDetails{Name: "alice", Secret: redacted}),
+		"Redact should keep using the previous policy when reloading fails")
+}
+
+func TestNewPolicyWatcherWithInvalidPolicy(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+
+	g.Expect(os.WriteFile(policyPath, []byte("mode: invalid\n"), 0o600)).To(gomega.Succeed())
+
+	_, err := rere.NewPolicyWatcher(policyPath, nil)
+
+	g.Expect(err).To(gomega.HaveOccurred(), "NewPolicyWatcher should return an error for invalid policies")
+}
@@ -0,0 +1,59 @@
+package rere
+
+import (
+	"sync"
+)
+
+// scratchPathCapacity is the initial capacity of scratch paths, which covers the nesting depth of most values.
+const scratchPathCapacity = 32
+
+// scratch holds structures reused across redactions, so high-throughput callers don't allocate them for every call.
+type scratch struct {
+	// path backs the paths of values being redacted. Values are traversed depth first, so the paths of siblings share
+	// the same backing array.
+	path path
+}
+
+//nolint:gochecknoglobals // scratch structures are shared by every redaction not made through a Policy
+var scratchPool = sync.Pool{
+	New: newPooledScratch,
+}
+
+func newPooledScratch() any {
+	return newScratch()
+}
+
+func newScratch() *scratch {
+	return &scratch{
+		path: make(path, 0, scratchPathCapacity),
+	}
+}
+
+// acquireScratch takes scratch structures from the pool of redactOptions, or allocates them when there is no pool.
+func (o *options) acquireScratch() {
+	if o.scratchPool == nil {
+		o.scratch = newScratch()
+
+		return
+	}
+
+	//nolint:forcetypeassert // the pool only holds scratch structures
+	o.scratch = o.scratchPool.Get().(*scratch)
+}
+
+// releaseScratch returns scratch structures to the pool of redactOptions once a redaction is done with them.
+func (o *options) releaseScratch() {
+	if o.scratchPool != nil {
+		o.scratchPool.Put(o.scratch)
+	}
+
+	o.scratch = nil
+}
+
+// root returns the location of the provided value with a path backed by scratch structures.
+func (o *options) root() location {
+	rootScratchLocation := rootLocation
+	rootScratchLocation.path = o.scratch.path[:0]
+
+	return rootScratchLocation
+}
@@ -0,0 +1,92 @@
+package rere_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type pooledRequest struct {
+	User    credentialDetails
+	Headers map[string]string
+	Tags    []string
+}
+
+func BenchmarkPolicyRedact(b *testing.B) {
+	input := pooledRequest{
+		User:    credentialDetails{Name: "alice", Secret: "hunter2"},
+		Headers: map[string]string{"Accept": "application/json", "Authorization": "Bearer token"},
+		Tags:    []string{"a", "b", "c"},
+	}
+
+	policy := rere.NewAllowListPolicy([]string{"name", "accept", "tags"})
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		policy.Redact(input)
+	}
+}
+
+func TestPolicyReusesScratchStructures(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	policy := rere.NewAllowListPolicy([]string{"name", "accept", "tags"})
+
+	input := pooledRequest{
+		User:    credentialDetails{Name: "alice", Secret: "hunter2"},
+		Headers: map[string]string{"Accept": "application/json", "Authorization": "Bearer token"},
+		Tags:    []string{"a", "b"},
+	}
+	expected := pooledRequest{
+		User:    credentialDetails{Name: "alice", Secret: redacted},
+		Headers: map[string]string{"Accept": "application/json", "Authorization": redacted},
+		Tags:    []string{"a", "b"},
+	}
+
+	var waitGroup sync.WaitGroup
+
+	results := make([]any, 8)
+
+	for i := 0; i < len(results); i++ {
+		waitGroup.Add(1)
+
+		go func(i int) {
+			defer waitGroup.Done()
+
+			results[i] = policy.Redact(input)
+		}(i)
+	}
+
+	waitGroup.Wait()
+
+	g.Expect(results).To(gomega.HaveEach(expected), "Redact should redact concurrent calls independently")
+
+	policy.Close()
+
+	g.Expect(policy.Redact(input)).To(gomega.Equal(expected), "Redact should redact values after Close")
+}
+
+func TestRedactReportsPathsWithReusedScratch(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	rere.RedactWithAllowList(pooledRequest{
+		User:    credentialDetails{Name: "alice", Secret: "hunter2"},
+		Headers: map[string]string{"Authorization": "Bearer token"},
+		Tags:    []string{"a", "b"},
+	}, []string{"name"}, rere.WithReport(&report))
+
+	paths := make([]string, 0, len(report.Redactions))
+	for _, redaction := range report.Redactions {
+		paths = append(paths, redaction.Path)
+	}
+
+	g.Expect(paths).To(gomega.Equal([]string{"User.Secret", "Headers.Authorization", "Tags[0]", "Tags[1]"}),
+		"sibling paths should not overwrite paths already recorded")
+}
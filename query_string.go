@@ -0,0 +1,63 @@
+package rere
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultSensitiveQueryParameters are the query parameter names matched by QueryStringDetector when no names are
+// provided. They cover common API keys and tokens as well as the signatures and credentials of presigned AWS S3,
+// Google Cloud Storage, and Azure shared access signature URLs.
+//
+//nolint:gochecknoglobals // slices can't be constants
+var DefaultSensitiveQueryParameters = []string{
+	"access_token",
+	"api_key",
+	"apikey",
+	"auth",
+	"client_secret",
+	"code",
+	"id_token",
+	"key",
+	"password",
+	"refresh_token",
+	"secret",
+	"sig",
+	"signature",
+	"token",
+	"X-Amz-Credential",
+	"X-Amz-Security-Token",
+	"X-Amz-Signature",
+	"X-Goog-Credential",
+	"X-Goog-Signature",
+}
+
+// QueryStringDetector creates a Detector matching the values of sensitive query parameters in URLs and query strings
+// found anywhere in text, such as "sig" in an error message quoting a presigned URL. Only parameter values are matched,
+// so the URL and the other parameters remain useful after redaction. Parameter names are matched case insensitively.
+//
+// DefaultSensitiveQueryParameters are matched when no parameterNames are provided.
+func QueryStringDetector(parameterNames ...string) Detector {
+	if len(parameterNames) == 0 {
+		parameterNames = DefaultSensitiveQueryParameters
+	}
+
+	quotedNames := make([]string, 0, len(parameterNames))
+	for _, name := range parameterNames {
+		quotedNames = append(quotedNames, regexp.QuoteMeta(name))
+	}
+
+	// a parameter follows the start of a query string or another parameter, and its value ends at the next parameter,
+	// the fragment, or the end of the URL
+	parameter := regexp.MustCompile(`(?i)[?&](?:` + strings.Join(quotedNames, "|") + `)=([^&#\s"'<>]*)`)
+
+	return DetectorFunc(func(text string) [][]int {
+		var matches [][]int
+
+		for _, match := range parameter.FindAllStringSubmatchIndex(text, -1) {
+			matches = append(matches, match[2:4])
+		}
+
+		return matches
+	})
+}
@@ -0,0 +1,58 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestQueryStringDetector(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		detector rere.Detector
+		input    string
+		output   string
+	}{
+		{
+			name:     "redacts sensitive parameters in presigned URLs",
+			detector: rere.QueryStringDetector(),
+			input: `failed to GET "https://bucket.s3.amazonaws.com/report.csv?X-Amz-Algorithm=AWS4-HMAC-SHA256` +
+				`&X-Amz-Credential=AKIA%2F20240101&x-amz-signature=abc123#top": 403 Forbidden`,
+			output: `failed to GET "https://bucket.s3.amazonaws.com/report.csv?X-Amz-Algorithm=AWS4-HMAC-SHA256` +
+				`&X-Amz-Credential=REDACTED&x-amz-signature=REDACTED#top": 403 Forbidden`,
+		},
+		{
+			name:     "redacts parameters anywhere in text",
+			detector: rere.QueryStringDetector(),
+			input:    "retrying /v1/search?q=shoes&api_key=abc&page=2 and /v1/items?token=def",
+			output:   "retrying /v1/search?q=shoes&api_key=REDACTED&page=2 and /v1/items?token=REDACTED",
+		},
+		{
+			name:     "ignores parameter names outside of query strings",
+			detector: rere.QueryStringDetector(),
+			input:    "token=abc is not part of a query string, nor is monkey=banana?x=1",
+			output:   "token=abc is not part of a query string, nor is monkey=banana?x=1",
+		},
+		{
+			name:     "matches provided parameter names",
+			detector: rere.QueryStringDetector("session", "x.y"),
+			input:    "https://example.com/?session=abc&token=def&x.y=ghi&xzy=jkl",
+			output:   "https://example.com/?session=REDACTED&token=def&x.y=REDACTED&xzy=jkl",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(rere.RedactText(testCase.input, testCase.detector)).To(gomega.Equal(testCase.output),
+				"QueryStringDetector should match sensitive parameter values")
+		})
+	}
+}
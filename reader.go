@@ -0,0 +1,94 @@
+package rere
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxLineLength is the longest line scrubbed at once. Longer lines are scrubbed in chunks of maxLineLength bytes, so
+// memory use is bounded when a stream contains very long or unterminated lines.
+const maxLineLength = 64 * 1024
+
+// Reader is an io.Reader scrubbing each line read from an underlying io.Reader with detectors. Reader is suitable for
+// tailing logs through a redaction sidecar since each line is made available as soon as it is complete.
+//
+// Lines may end with "\n" or "\r\n" and line endings are kept as-is. Lines longer than 64 KiB are scrubbed in 64 KiB
+// chunks, so a match spanning two chunks may not be detected.
+type Reader struct {
+	reader    *bufio.Reader
+	detectors []Detector
+	pending   []byte
+	err       error
+}
+
+// NewReader creates a Reader scrubbing lines read from reader with detectors.
+func NewReader(reader io.Reader, detectors ...Detector) *Reader {
+	return &Reader{
+		reader:    bufio.NewReaderSize(reader, maxLineLength),
+		detectors: detectors,
+		pending:   nil,
+		err:       nil,
+	}
+}
+
+// Read reads scrubbed lines into p.
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		r.readLine()
+	}
+
+	read := copy(p, r.pending)
+	r.pending = r.pending[read:]
+
+	return read, nil
+}
+
+// readLine reads and scrubs the next line or chunk of a long line into pending.
+func (r *Reader) readLine() {
+	line, err := r.reader.ReadSlice('\n')
+
+	switch {
+	case errors.Is(err, bufio.ErrBufferFull):
+		// scrub the chunk of a long line, but hold back a trailing carriage return in case it's part of "\r\n"
+		if line[len(line)-1] == '\r' {
+			line = line[:len(line)-1]
+			_ = r.reader.UnreadByte()
+		}
+	case err != nil:
+		r.err = err
+	}
+
+	r.pending = append(r.pending[:0], scrubLine(string(line), r.detectors)...)
+}
+
+// Scrub copies scrubbed lines from src to dst until src returns io.EOF, returning the number of bytes written.
+func Scrub(dst io.Writer, src io.Reader, detectors ...Detector) (int64, error) {
+	written, err := io.Copy(dst, NewReader(src, detectors...))
+	if err != nil {
+		return written, fmt.Errorf("failed to scrub stream: %w", err)
+	}
+
+	return written, nil
+}
+
+// scrubLine redacts line with detectors, excluding any "\n" or "\r\n" line ending from detection.
+func scrubLine(line string, detectors []Detector) string {
+	content, hasNewline := strings.CutSuffix(line, "\n")
+	if !hasNewline {
+		return RedactText(content, detectors...)
+	}
+
+	content, hasCarriageReturn := strings.CutSuffix(content, "\r")
+	if hasCarriageReturn {
+		return RedactText(content, detectors...) + "\r\n"
+	}
+
+	return RedactText(content, detectors...) + "\n"
+}
@@ -0,0 +1,83 @@
+package rere_test
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestReader(t *testing.T) {
+	t.Parallel()
+
+	password := rere.RegexpDetector(regexp.MustCompile(`password=\S+`))
+	longLine := strings.Repeat("a", 70*1024)
+
+	testCases := []struct {
+		name   string
+		input  io.Reader
+		output string
+	}{
+		{
+			name:   "scrubs lines",
+			input:  strings.NewReader("first password=hunter2\nsecond\n"),
+			output: "first REDACTED\nsecond\n",
+		},
+		{
+			name:   "keeps carriage returns out of matches",
+			input:  strings.NewReader("password=hunter2\r\nnext\r\n"),
+			output: "REDACTED\r\nnext\r\n",
+		},
+		{
+			name:   "scrubs final line without newline",
+			input:  strings.NewReader("line\npassword=hunter2"),
+			output: "line\nREDACTED",
+		},
+		{
+			name:   "scrubs lines from partial reads",
+			input:  iotest.OneByteReader(strings.NewReader("a password=hunter2\r\nb\n")),
+			output: "a REDACTED\r\nb\n",
+		},
+		{
+			name:   "scrubs long lines",
+			input:  strings.NewReader(longLine + " password=hunter2\n"),
+			output: longLine + " REDACTED\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			scrubbed, err := io.ReadAll(iotest.OneByteReader(rere.NewReader(testCase.input, password)))
+
+			g.Expect(err).ToNot(gomega.HaveOccurred(), "Reader should not error")
+			g.Expect(string(scrubbed)).To(gomega.Equal(testCase.output), "Reader should scrub lines")
+		})
+	}
+}
+
+func TestScrub(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var buffer bytes.Buffer
+
+	written, err := rere.Scrub(&buffer, strings.NewReader("token ghp_abc\n"),
+		rere.RegexpDetector(regexp.MustCompile(`ghp_\w+`)))
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "Scrub should not error")
+	g.Expect(written).To(gomega.Equal(int64(15)), "Scrub should return the number of bytes written")
+	g.Expect(buffer.String()).To(gomega.Equal("token REDACTED\n"), "Scrub should scrub lines")
+
+	_, err = rere.Scrub(&buffer, iotest.ErrReader(errWriteFailed))
+
+	g.Expect(err).To(gomega.MatchError(errWriteFailed), "Scrub should return read errors")
+}
@@ -0,0 +1,67 @@
+package rere
+
+import (
+	"errors"
+	"log/slog"
+	"runtime/debug"
+)
+
+// RecoverConfig configures how RecoverRedacted reports a recovered panic.
+type RecoverConfig struct {
+	// Logger receives the redacted panic value and stack trace at the error level. Logging is skipped when Logger is nil.
+	Logger *slog.Logger
+	// Detectors scrub the text of recovered errors and strings, as well as the stack trace, in addition to
+	// GoStackTraceDetector. SecretFormatDetector, QueryStringDetector, and URLCredentialsDetector are used when
+	// Detectors is empty.
+	Detectors []Detector
+	// Repanic makes RecoverRedacted panic again with the redacted panic value after logging it, so the process still
+	// crashes without printing raw values carried by the original panic.
+	Repanic bool
+}
+
+// RecoverRedacted recovers a panic and logs the redacted panic value and stack trace to config.Logger. It must be
+// deferred directly, such as defer rere.RecoverRedacted(rere.RecoverConfig{Logger: logger}), since recover only stops
+// a panic when called by a deferred function.
+//
+// Panic values carrying secrets are redacted before they are logged. The text of errors and strings is scrubbed with
+// config.Detectors, or detectors matching credentials with well-known formats, sensitive query parameters, and
+// passwords of URLs by default, since messages are needed to troubleshoot the panic. Every other value, such as a
+// struct, is redacted with opts. The stack trace is scrubbed with RedactStackTrace and the same detectors.
+//
+// Every string and []byte in other values is redacted unless WithAllowList or WithDenyList is provided.
+func RecoverRedacted(config RecoverConfig, opts ...Option) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	detectors := config.Detectors
+	if len(detectors) == 0 {
+		detectors = defaultTextDetectors()
+	}
+
+	redactedValue := redactPanicValue(recovered, detectors, newOptions(allow, nil, opts))
+
+	if config.Logger != nil {
+		stackTrace := RedactStackTrace(string(debug.Stack()), detectors...)
+
+		config.Logger.Error("recovered from panic", slog.Any("panic", redactedValue), slog.String("stack", stackTrace))
+	}
+
+	if config.Repanic {
+		panic(redactedValue)
+	}
+}
+
+func redactPanicValue(value any, detectors []Detector, redactOptions *options) any {
+	switch typedValue := value.(type) {
+	case error:
+		// errors are replaced since their Error method might include secrets regardless of their fields
+		//nolint:err113 // the redacted message is only used to report the recovered panic
+		return errors.New(RedactText(typedValue.Error(), detectors...))
+	case string:
+		return RedactText(typedValue, detectors...)
+	default:
+		return redactValue(value, redactOptions)
+	}
+}
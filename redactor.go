@@ -0,0 +1,116 @@
+package rere
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Redactor redacts values. Redactor makes it possible to inject redaction as a dependency, such as replacing a
+// Policy with Noop in tests and development environments.
+type Redactor interface {
+	Redact(value any) any
+}
+
+// Policy is a Redactor configured once with an allow or deny list and options, so it can be shared instead of
+// passing lists and options to every call. A Policy pools the scratch structures used to redact, such as paths, and
+// reuses them across calls to reduce allocations. Call Close once a Policy is no longer used to release them.
+type Policy struct {
+	// listOption sets the list provided to NewAllowListPolicy or NewDenyListPolicy, and is nil for NewPolicy.
+	listOption Option
+	opts       []Option
+
+	scratchPool sync.Pool
+	closed      atomic.Bool
+	nameIndex   atomic.Pointer[policyNameIndex]
+}
+
+// policyNameIndex is a nameIndex built by a Policy for the entries of its field and key name list.
+type policyNameIndex struct {
+	entries []string
+	folding nameFolding
+	index   *nameIndex
+}
+
+// NewPolicy creates a Policy redacting values the same as Redact, configured entirely through options, such as
+// WithAllowList, WithDenyList, and WithPlaceholder:
+//
+//	policy := rere.NewPolicy(rere.WithAllowList("Username", "Email"), rere.WithPlaceholder("***"))
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided.
+func NewPolicy(opts ...Option) *Policy {
+	return newPolicy(nil, opts)
+}
+
+// NewAllowListPolicy creates a Policy redacting values the same as RedactWithAllowList.
+func NewAllowListPolicy(allowList []string, opts ...Option) *Policy {
+	return newPolicy(WithAllowList(allowList...), opts)
+}
+
+// NewDenyListPolicy creates a Policy redacting values the same as RedactWithDenyList.
+func NewDenyListPolicy(denyList []string, opts ...Option) *Policy {
+	return newPolicy(WithDenyList(denyList...), opts)
+}
+
+func newPolicy(listOption Option, opts []Option) *Policy {
+	return &Policy{
+		listOption: listOption,
+		opts:       opts,
+
+		scratchPool: sync.Pool{New: newPooledScratch},
+		closed:      atomic.Bool{},
+		nameIndex:   atomic.Pointer[policyNameIndex]{},
+	}
+}
+
+// Redact returns a redacted deep copy of value. The returned value has the same dynamic type as value.
+func (p *Policy) Redact(value any) any {
+	redactOptions := newListOptions(p.listOption, p.opts)
+	redactOptions.nameIndex = p.indexNames(redactOptions)
+
+	// scratch structures are allocated for every call once the Policy is closed
+	redactOptions.scratchPool = nil
+	if !p.closed.Load() {
+		redactOptions.scratchPool = &p.scratchPool
+	}
+
+	return redactValue(value, redactOptions)
+}
+
+// Close releases the scratch structures pooled by the Policy, which are garbage collected instead of reused. Redact can
+// still be called after Close, but allocates scratch structures for every call. Close is safe to call concurrently with
+// Redact.
+func (p *Policy) Close() {
+	p.closed.Store(true)
+}
+
+// indexNames returns a nameIndex for the field and key name list of redactOptions, reusing the index built by a
+// previous call for the same entries, so large lists are only indexed once. Short lists are scanned instead.
+func (p *Policy) indexNames(redactOptions *options) *nameIndex {
+	entries := redactOptions.fieldKeyNameList
+	if len(entries) < nameIndexThreshold {
+		return nil
+	}
+
+	// entries are compared, since options and defaults might provide different entries between calls
+	cached := p.nameIndex.Load()
+	if cached != nil && cached.folding == redactOptions.nameFolding && slices.Equal(cached.entries, entries) {
+		return cached.index
+	}
+
+	index := newNameIndex(entries, redactOptions.nameFolding)
+	p.nameIndex.Store(&policyNameIndex{entries: slices.Clone(entries), folding: redactOptions.nameFolding, index: index})
+
+	return index
+}
+
+type noopRedactor struct{}
+
+func (noopRedactor) Redact(value any) any {
+	return value
+}
+
+// Noop is a Redactor returning values as-is without copying or redacting them.
+//
+//nolint:gochecknoglobals // Noop is stateless and safe to share
+var Noop Redactor = noopRedactor{}
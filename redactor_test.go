@@ -0,0 +1,92 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactors(t *testing.T) {
+	t.Parallel()
+
+	input := structWithRedactedFields{
+		Username:  "username",
+		username:  "",
+		Password:  "password",
+		password:  "",
+		byteSlice: nil,
+		stringPtr: nil,
+	}
+
+	testCases := []struct {
+		name     string
+		redactor rere.Redactor
+		output   any
+	}{
+		{
+			name:     "allow list policy",
+			redactor: rere.NewAllowListPolicy([]string{"username"}),
+			output: structWithRedactedFields{
+				Username:  "username",
+				username:  "",
+				Password:  redacted,
+				password:  "",
+				byteSlice: nil,
+				stringPtr: nil,
+			},
+		},
+		{
+			name:     "deny list policy with options",
+			redactor: rere.NewDenyListPolicy([]string{"username"}, rere.WithLengthHint()),
+			output: structWithRedactedFields{
+				Username:  "REDACTED(8)",
+				username:  "",
+				Password:  "password",
+				password:  "",
+				byteSlice: nil,
+				stringPtr: nil,
+			},
+		},
+		{
+			name:     "policy configured with options",
+			redactor: rere.NewPolicy(rere.WithAllowList("password"), rere.WithPlaceholder("***")),
+			output: structWithRedactedFields{
+				Username:  "***",
+				username:  "",
+				Password:  "password",
+				password:  "",
+				byteSlice: nil,
+				stringPtr: nil,
+			},
+		},
+		{
+			name:     "policy redacting everything by default",
+			redactor: rere.NewPolicy(),
+			output: structWithRedactedFields{
+				Username:  redacted,
+				username:  "",
+				Password:  redacted,
+				password:  "",
+				byteSlice: nil,
+				stringPtr: nil,
+			},
+		},
+		{
+			name:     "noop",
+			redactor: rere.Noop,
+			output:   input,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redactor.Redact(input)).To(gomega.Equal(testCase.output), "Redact should redact the value")
+			g.Expect(input.Password).To(gomega.Equal("password"), "Redact should not modify the provided input")
+		})
+	}
+}
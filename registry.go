@@ -0,0 +1,64 @@
+package rere
+
+import (
+	"reflect"
+	"sync"
+)
+
+//nolint:gochecknoglobals // policies are intentionally global so they can be registered once at startup
+var (
+	typePolicies      = map[reflect.Type]*Policy{}
+	typePoliciesMutex sync.RWMutex
+)
+
+// For registers a Policy created from opts, the same as NewPolicy, for values of type T and returns it. Auto redacts
+// values of type T, and pointers to them, with the registered Policy, so central logging wrappers receiving any can
+// apply the right list for every type. Calling For again for the same type replaces its Policy.
+//
+//	rere.For[User](rere.WithAllowList("Username", "Email"))
+//	rere.For[Invoice](rere.WithAllowList("Number", "Currency"))
+func For[T any](opts ...Option) *Policy {
+	policy := NewPolicy(opts...)
+
+	typePoliciesMutex.Lock()
+	defer typePoliciesMutex.Unlock()
+
+	if previous, found := typePolicies[reflect.TypeFor[T]()]; found {
+		previous.Close()
+	}
+
+	typePolicies[reflect.TypeFor[T]()] = policy
+
+	return policy
+}
+
+// Auto redacts value with the Policy registered by For for the dynamic type of value. When no Policy is registered for
+// the dynamic type, pointers are followed to find a Policy for the type they point to, such as User for a *User. Values
+// of types without a registered Policy are redacted the same as Redact without options, so every string and []byte is
+// redacted. The returned value has the same dynamic type as value.
+func Auto(value any) any {
+	if policy := policyFor(reflect.TypeOf(value)); policy != nil {
+		return policy.Redact(value)
+	}
+
+	return Redact(value)
+}
+
+func policyFor(valueType reflect.Type) *Policy {
+	typePoliciesMutex.RLock()
+	defer typePoliciesMutex.RUnlock()
+
+	for valueType != nil {
+		if policy, found := typePolicies[valueType]; found {
+			return policy
+		}
+
+		if valueType.Kind() != reflect.Pointer {
+			return nil
+		}
+
+		valueType = valueType.Elem()
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type registeredUser struct {
+	Username string
+	Password string
+}
+
+type registeredInvoice struct {
+	Number string
+	Card   string
+}
+
+type unregisteredRecord struct {
+	Name string
+}
+
+//nolint:paralleltest // For registers policies globally
+func TestAuto(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	rere.For[registeredUser](rere.WithAllowList("username"))
+	rere.For[registeredInvoice](rere.WithAllowList("number"), rere.WithPlaceholder("***"))
+
+	user := registeredUser{Username: "dustin", Password: "hunter2"}
+
+	g.Expect(rere.Auto(user)).To(gomega.Equal(registeredUser{Username: "dustin", Password: redacted}),
+		"Auto should redact with the policy registered for the type")
+	g.Expect(rere.Auto(&user)).To(gomega.Equal(&registeredUser{Username: "dustin", Password: redacted}),
+		"Auto should redact pointers with the policy registered for the type they point to")
+	g.Expect(rere.Auto(registeredInvoice{Number: "INV-1", Card: "4111111111111111"})).To(
+		gomega.Equal(registeredInvoice{Number: "INV-1", Card: "***"}),
+		"Auto should pick the policy of every type")
+	g.Expect(rere.Auto(unregisteredRecord{Name: "dustin"})).To(gomega.Equal(unregisteredRecord{Name: redacted}),
+		"Auto should redact every string of types without a policy")
+	g.Expect(rere.Auto(nil)).To(gomega.BeNil(), "Auto should support nil")
+
+	rere.For[registeredUser](rere.WithAllowList("password"))
+
+	g.Expect(rere.Auto(user)).To(gomega.Equal(registeredUser{Username: redacted, Password: "hunter2"}),
+		"For should replace the policy registered for the type")
+	g.Expect(user.Password).To(gomega.Equal("hunter2"), "Auto should not modify the provided value")
+}
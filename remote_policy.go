@@ -0,0 +1,244 @@
+package rere
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxPolicySize is the size in bytes of the largest policy file RemotePolicy fetches, so a misbehaving policy server
+// can't exhaust memory.
+const maxPolicySize = 16 << 20
+
+var (
+	errUnexpectedStatus = errors.New("unexpected status")
+	errPolicyTooLarge   = errors.New("policy is too large")
+)
+
+// RemotePolicyConfig configures where and how often NewRemotePolicy fetches a policy file.
+type RemotePolicyConfig struct {
+	// URL is the HTTP(S) URL of the policy file.
+	URL string
+	// Client sends requests for the policy file, such as a client authenticating with mutual TLS. http.DefaultClient
+	// is used when Client is nil.
+	Client *http.Client
+	// RefreshInterval is how often the policy file is fetched again. The policy file is only fetched once when
+	// RefreshInterval is zero.
+	RefreshInterval time.Duration
+	// CachePath is an optional file path the last good policy file is written to. When the policy file can't be
+	// fetched at startup, the cached policy file is loaded instead, so services still start when the policy server is
+	// down.
+	CachePath string
+	// OnRefreshError is called with errors fetching or loading the policy file after startup, such as to log them or
+	// alert on them. The last good Policy keeps being used. OnRefreshError can be nil to ignore refresh errors.
+	OnRefreshError func(error)
+}
+
+// RemotePolicy is a Redactor redacting values with a Policy fetched from an HTTP(S) URL and refreshed periodically,
+// so a central policy server can keep the redaction rules of many services consistent. Policy files are fetched with
+// the ETag of the last fetched policy file, so unchanged policy files are not downloaded and parsed again. Policy files
+// larger than 16 MiB are rejected.
+type RemotePolicy struct {
+	config RemotePolicyConfig
+	opts   []Option
+
+	policy atomic.Pointer[Policy]
+	// etag is the ETag of the last good policy file. etag is only accessed by the goroutine refreshing the policy
+	// after NewRemotePolicy returns.
+	etag string
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ Redactor = (*RemotePolicy)(nil)
+
+// NewRemotePolicy fetches the policy file at config.URL with ParsePolicy and opts, then refreshes it every
+// config.RefreshInterval until Close is called or ctx is done. NewRemotePolicy returns an error when the policy file
+// can neither be fetched nor loaded from config.CachePath.
+func NewRemotePolicy(ctx context.Context, config RemotePolicyConfig, opts ...Option) (*RemotePolicy, error) {
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+
+	remotePolicy := &RemotePolicy{
+		config: config,
+		opts:   opts,
+
+		policy: atomic.Pointer[Policy]{},
+		etag:   "",
+
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		closeOnce: sync.Once{},
+	}
+
+	if err := remotePolicy.refresh(ctx); err != nil {
+		if config.CachePath == "" {
+			return nil, err
+		}
+
+		if cacheErr := remotePolicy.loadCache(); cacheErr != nil {
+			return nil, errors.Join(err, cacheErr)
+		}
+	}
+
+	go remotePolicy.refreshPeriodically(ctx)
+
+	return remotePolicy, nil
+}
+
+// Redact returns a redacted deep copy of value with the last good Policy.
+func (r *RemotePolicy) Redact(value any) any {
+	return r.policy.Load().Redact(value)
+}
+
+// Close stops refreshing the policy file. Redact keeps using the last good Policy after Close. Close is safe to call
+// more than once.
+func (r *RemotePolicy) Close() {
+	r.closeOnce.Do(func() {
+		close(r.stop)
+	})
+
+	<-r.done
+}
+
+func (r *RemotePolicy) refreshPeriodically(ctx context.Context) {
+	defer close(r.done)
+
+	if r.config.RefreshInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.refresh(ctx); err != nil && r.config.OnRefreshError != nil {
+				r.config.OnRefreshError(err)
+			}
+		case <-r.stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refresh fetches the policy file and swaps in the fetched Policy when the policy file changed.
+func (r *RemotePolicy) refresh(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, r.config.URL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create policy request: %w", err)
+	}
+
+	if r.etag != "" {
+		request.Header.Set("If-None-Match", r.etag)
+	}
+
+	response, err := r.config.Client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to fetch policy: %w", err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusNotModified:
+		return nil
+	case http.StatusOK:
+	default:
+		return fmt.Errorf("failed to fetch policy: %w: %s", errUnexpectedStatus, response.Status)
+	}
+
+	// read one byte past the limit to tell policies of exactly the limit apart from larger ones
+	contents, err := io.ReadAll(io.LimitReader(response.Body, maxPolicySize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	if len(contents) > maxPolicySize {
+		return fmt.Errorf("failed to read policy: %w: more than %d bytes", errPolicyTooLarge, maxPolicySize)
+	}
+
+	if err := r.swap(contents); err != nil {
+		return err
+	}
+
+	r.etag = response.Header.Get("ETag")
+
+	if r.config.CachePath != "" {
+		if err := writeFileAtomically(r.config.CachePath, contents); err != nil {
+			return fmt.Errorf("failed to cache policy: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadCache loads the policy file cached at config.CachePath.
+func (r *RemotePolicy) loadCache() error {
+	contents, err := os.ReadFile(r.config.CachePath)
+	if err != nil {
+		return fmt.Errorf("failed to read cached policy: %w", err)
+	}
+
+	return r.swap(contents)
+}
+
+// swap parses contents and swaps in the parsed Policy.
+func (r *RemotePolicy) swap(contents []byte) error {
+	policy, err := ParsePolicy(contents, r.opts...)
+	if err != nil {
+		return err
+	}
+
+	if previous := r.policy.Swap(policy); previous != nil {
+		previous.Close()
+	}
+
+	return nil
+}
+
+// writeFileAtomically writes contents to a temporary file next to filePath and renames it to filePath, so a crash
+// while writing never leaves a truncated file at filePath.
+func writeFileAtomically(filePath string, contents []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	// removing fails once the temporary file is renamed, which is expected
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(contents); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), filePath); err != nil {
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return nil
+}
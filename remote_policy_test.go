@@ -0,0 +1,177 @@
+package rere_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+// policyServer serves a policy file with an ETag and counts full responses.
+type policyServer struct {
+	mutex     sync.Mutex
+	policy    string
+	etag      string
+	status    int
+	responses int
+}
+
+func (s *policyServer) set(policy, etag string, status int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.policy, s.etag, s.status = policy, etag, status
+}
+
+func (s *policyServer) fullResponses() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.responses
+}
+
+func (s *policyServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.status != http.StatusOK {
+		writer.WriteHeader(s.status)
+
+		return
+	}
+
+	if request.Header.Get("If-None-Match") == s.etag {
+		writer.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	s.responses++
+
+	writer.Header().Set("ETag", s.etag)
+	_, _ = writer.Write([]byte(s.policy))
+}
+
+func TestRemotePolicy(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	handler := &policyServer{policy: "mode: allow\nlist: [name, secret]\n", etag: `"v1"`, status: http.StatusOK}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var (
+		refreshErrors []error
+		mutex         sync.Mutex
+	)
+
+	remotePolicy, err := rere.NewRemotePolicy(context.Background(), rere.RemotePolicyConfig{
+		URL:             server.URL,
+		Client:          server.Client(),
+		RefreshInterval: 10 * time.Millisecond,
+		CachePath:       "",
+		OnRefreshError: func(err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			refreshErrors = append(refreshErrors, err)
+		},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewRemotePolicy should fetch the policy")
+
+	defer remotePolicy.Close()
+
+	input := credentialDetails{Name: "alice", Secret: "hunter2"}
+
+	g.Expect(remotePolicy.Redact(input)).To(gomega.Equal(input), "Redact should use the fetched policy")
+	g.Consistently(handler.fullResponses, 50*time.Millisecond).Should(gomega.Equal(1),
+		"RemotePolicy should not download unchanged policies")
+
+	handler.set("mode: allow\nlist: [name]\n", `"v2"`, http.StatusOK)
+
+	g.Eventually(func() any {
+		return remotePolicy.Redact(input)
+	}).Should(gomega.Equal(credentialDetails{Name: "alice", Secret: redacted}), "Redact should use the refreshed policy")
+
+	handler.set("", `"v3"`, http.StatusInternalServerError)
+
+	g.Eventually(func() []error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return refreshErrors
+	}).Should(gomega.ContainElement(gomega.MatchError(gomega.ContainSubstring("500 Internal Server Error"))),
+		"RemotePolicy should report refresh errors")
+	g.Expect(remotePolicy.Redact(input)).To(gomega.Equal(credentialDetails{Name: "alice", Secret: redacted}),
+		"Redact should keep using the last good policy when refreshing fails")
+}
+
+func TestRemotePolicyWithCache(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	handler := &policyServer{policy: "mode: allow\nlist: [name]\n", etag: `"v1"`, status: http.StatusOK}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	config := rere.RemotePolicyConfig{
+		URL:             server.URL,
+		Client:          server.Client(),
+		RefreshInterval: 0,
+		CachePath:       filepath.Join(t.TempDir(), "policy.yaml"),
+		OnRefreshError:  nil,
+	}
+
+	remotePolicy, err := rere.NewRemotePolicy(context.Background(), config)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewRemotePolicy should fetch the policy")
+	remotePolicy.Close()
+	remotePolicy.Close()
+
+	cacheFiles, err := os.ReadDir(filepath.Dir(config.CachePath))
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "reading the cache directory should succeed")
+	g.Expect(cacheFiles).To(gomega.HaveLen(1), "RemotePolicy should not leave temporary files next to the cache")
+
+	handler.set("", "", http.StatusServiceUnavailable)
+
+	cachedPolicy, err := rere.NewRemotePolicy(context.Background(), config)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "NewRemotePolicy should load the cached policy")
+
+	defer cachedPolicy.Close()
+
+	g.Expect(cachedPolicy.Redact(credentialDetails{Name: "alice", Secret: "hunter2"})).To(
+		gomega.Equal(credentialDetails{Name: "alice", Secret: redacted}), "Redact should use the cached policy")
+
+	config.CachePath = ""
+
+	_, err = rere.NewRemotePolicy(context.Background(), config)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("503 Service Unavailable")),
+		"NewRemotePolicy should return an error without a policy or cache")
+}
+
+func TestRemotePolicyRejectsLargePolicies(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte("mode: allow\n# " + strings.Repeat("x", 16<<20) + "\n"))
+	}))
+	defer server.Close()
+
+	_, err := rere.NewRemotePolicy(context.Background(), rere.RemotePolicyConfig{
+		URL:             server.URL,
+		Client:          server.Client(),
+		RefreshInterval: 0,
+		CachePath:       "",
+		OnRefreshError:  nil,
+	})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("policy is too large")),
+		"NewRemotePolicy should reject policies larger than 16 MiB")
+}
@@ -0,0 +1,196 @@
+package rere
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Report records the redactions applied by a redaction call when provided through WithReport.
+//
+// Redactions are recorded in traversal order: struct fields in declaration order, slice and array elements by index,
+// and map entries sorted by key. Map traversal order does not affect the redacted value, and sorting keys while
+// recording keeps reports stable between runs for auditing.
+//
+// A Report is reset at the start of each redaction call and must not be shared by concurrent redaction calls.
+type Report struct {
+	// Policy is the name provided by WithPolicyName.
+	Policy string
+	// PolicyVersion is the version provided by WithPolicyVersion.
+	PolicyVersion string
+	Redactions    []Redaction
+	// AlreadyRedacted holds the paths of values skipped by WithSkipAlreadyRedacted since they already held a
+	// placeholder. A non-empty AlreadyRedacted means values were redacted more than once on their way here.
+	AlreadyRedacted []string
+}
+
+// Redaction describes a single value that was redacted.
+type Redaction struct {
+	// Path is where the redacted value was found, such as "Users[0].Password" or "Headers.Authorization". Map keys
+	// containing dots or brackets are quoted, such as `Labels["app.kubernetes.io/name"]`. Path is empty when the
+	// provided value itself was redacted.
+	Path string
+	// Pointer is the RFC 6901 JSON Pointer of the redacted value, such as "/Users/0/Password".
+	Pointer string
+	// Value is the placeholder the original value was replaced with.
+	Value any
+}
+
+type jsonPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// JSONPatch returns an RFC 6902 JSON Patch of replace operations describing the redactions applied to the
+// JSON-encoded original value. Paths use Go field names, so the patch applies to values whose JSON encoding uses
+// field names as keys.
+func (r *Report) JSONPatch() ([]byte, error) {
+	operations := make([]jsonPatchOperation, 0, len(r.Redactions))
+
+	for _, redaction := range r.Redactions {
+		operations = append(operations, jsonPatchOperation{
+			Op:    "replace",
+			Path:  redaction.Pointer,
+			Value: redaction.Value,
+		})
+	}
+
+	patch, err := json.Marshal(operations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON patch: %w", err)
+	}
+
+	return patch, nil
+}
+
+func (r *Report) add(valuePath path, value any) {
+	r.Redactions = append(r.Redactions, Redaction{
+		Path:    valuePath.String(),
+		Pointer: valuePath.pointer(),
+		Value:   value,
+	})
+}
+
+// WithReport records every redaction applied into report.
+func WithReport(report *Report) Option {
+	return func(o *options) {
+		o.report = report
+	}
+}
+
+// mapKeys returns the keys of mapValue. Keys are sorted when redactions are recorded, so reports and audit events are
+// deterministic, otherwise sorting is skipped since the redacted value does not depend on traversal order.
+func (o *options) mapKeys(mapValue reflect.Value) []reflect.Value {
+	keys := mapValue.MapKeys()
+
+	if o.recording() {
+		slices.SortFunc(keys, compareMapKeys)
+	}
+
+	return keys
+}
+
+// recording reports whether redactions are recorded into a report or audit event.
+func (o *options) recording() bool {
+	return o.report != nil || o.auditSink != nil
+}
+
+func compareMapKeys(first, second reflect.Value) int {
+	//nolint:exhaustive // every other kind is compared by its formatted value
+	switch first.Kind() {
+	case reflect.String:
+		return cmp.Compare(first.String(), second.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(first.Int(), second.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(first.Uint(), second.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(first.Float(), second.Float())
+	default:
+		return cmp.Compare(fmt.Sprint(first.Interface()), fmt.Sprint(second.Interface()))
+	}
+}
+
+type pathSegment struct {
+	name    string
+	isIndex bool
+	// isDescendant is only set for path rules, where the segment matches any number of segments, such as ".." in
+	// "$..token".
+	isDescendant bool
+}
+
+// path is the location of a value being redacted relative to the provided value.
+//
+// Paths are appended in place, so the paths of siblings share a backing array while values are traversed depth first.
+// A path must be converted, such as with String, before it is kept after redacting the value at it.
+type path []pathSegment
+
+func (p path) field(name string) path {
+	return append(p, pathSegment{name: name, isIndex: false, isDescendant: false})
+}
+
+func (p path) index(index int) path {
+	return append(p, pathSegment{name: strconv.Itoa(index), isIndex: true, isDescendant: false})
+}
+
+func (p path) anyIndex() path {
+	return append(p, pathSegment{name: pathIndexWildcard, isIndex: true, isDescendant: false})
+}
+
+func (p path) key(key reflect.Value) path {
+	if key.Kind() == reflect.String {
+		return p.field(key.String())
+	}
+
+	return p.field(fmt.Sprint(key.Interface()))
+}
+
+// hasField reports whether the path includes a struct field or map key, as opposed to only slice or array indexes.
+func (p path) hasField() bool {
+	return slices.ContainsFunc(p, func(segment pathSegment) bool {
+		return !segment.isIndex
+	})
+}
+
+func (p path) String() string {
+	var builder strings.Builder
+
+	for i, segment := range p {
+		switch {
+		case segment.isDescendant:
+			builder.WriteString(pathDescendant)
+		case segment.isIndex:
+			builder.WriteString("[" + segment.name + "]")
+		case needsQuoting(segment.name):
+			builder.WriteString("[" + strconv.Quote(segment.name) + "]")
+		case builder.Len() == 0 || p[i-1].isDescendant:
+			builder.WriteString(segment.name)
+		default:
+			builder.WriteString("." + segment.name)
+		}
+	}
+
+	return builder.String()
+}
+
+// needsQuoting reports whether a field or key name must be quoted to be parsed as a single name. See WithRedactPaths.
+func needsQuoting(name string) bool {
+	return name == "" || strings.ContainsAny(name, `.[]"`)
+}
+
+func (p path) pointer() string {
+	var builder strings.Builder
+
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+
+	for _, segment := range p {
+		builder.WriteString("/" + escaper.Replace(segment.name))
+	}
+
+	return builder.String()
+}
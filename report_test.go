@@ -0,0 +1,121 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type reportUser struct {
+	Username string
+	Password string
+	Key      []byte
+}
+
+type reportUsers struct {
+	Users   []reportUser
+	Headers map[string]string
+}
+
+func TestRedactWithReport(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := reportUsers{
+		Users: []reportUser{
+			{
+				Username: "alice",
+				Password: "hunter2",
+				Key:      []byte("key"),
+			},
+		},
+		Headers: map[string]string{
+			"a/b~c": "value",
+		},
+	}
+
+	report := &rere.Report{
+		Policy:          "stale",
+		PolicyVersion:   "stale",
+		Redactions:      []rere.Redaction{{Path: "stale", Pointer: "/stale", Value: "stale"}},
+		AlreadyRedacted: []string{"stale"},
+	}
+
+	rere.RedactWithAllowList(input, []string{"username"}, rere.WithReport(report))
+
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "Users[0].Password", Pointer: "/Users/0/Password", Value: redacted},
+		{Path: "Users[0].Key", Pointer: "/Users/0/Key", Value: []byte(redacted)},
+		{Path: "Headers.a/b~c", Pointer: "/Headers/a~1b~0c", Value: redacted},
+	}), "WithReport should record every redaction")
+	g.Expect(report.Policy).To(gomega.BeEmpty(), "WithReport should reset the policy name")
+	g.Expect(report.PolicyVersion).To(gomega.BeEmpty(), "WithReport should reset the policy version")
+	g.Expect(report.AlreadyRedacted).To(gomega.BeEmpty(), "WithReport should reset already redacted paths")
+
+	patch, err := report.JSONPatch()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "JSONPatch should not error")
+	g.Expect(string(patch)).To(gomega.MatchJSON(`[
+		{"op": "replace", "path": "/Users/0/Password", "value": "REDACTED"},
+		{"op": "replace", "path": "/Users/0/Key", "value": "UkVEQUNURUQ="},
+		{"op": "replace", "path": "/Headers/a~1b~0c", "value": "REDACTED"}
+	]`), "JSONPatch should describe every redaction")
+}
+
+func TestReportWithPolicyVersion(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	rere.RedactWithAllowList(reportUser{Username: "alice", Password: "hunter2", Key: nil}, []string{"username"},
+		rere.WithReport(&report), rere.WithPolicyName("pci"), rere.WithPolicyVersion("v3"),
+		rere.WithPolicyStampedPlaceholders())
+
+	g.Expect(report).To(gomega.Equal(rere.Report{
+		Policy:          "pci",
+		PolicyVersion:   "v3",
+		Redactions:      []rere.Redaction{{Path: "Password", Pointer: "/Password", Value: "REDACTED[pci-v3]"}},
+		AlreadyRedacted: nil,
+	}), "WithReport should record the policy name and version")
+}
+
+func TestReportOfRedactedValue(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	report := &rere.Report{Policy: "", PolicyVersion: "", Redactions: nil, AlreadyRedacted: nil}
+
+	rere.RedactWithAllowList("password", nil, rere.WithReport(report))
+
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "", Pointer: "", Value: redacted},
+	}), "WithReport should record redacting the provided value")
+}
+
+func TestReportIsDeterministic(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := map[string]map[int]string{
+		"b": {10: "value", 9: "value"},
+		"a": {2: "value", 1: "value"},
+		"c": {3: "value"},
+	}
+
+	expectedPaths := []string{"a.1", "a.2", "b.9", "b.10", "c.3"}
+
+	for i := 0; i < 20; i++ {
+		report := &rere.Report{Policy: "", PolicyVersion: "", Redactions: nil, AlreadyRedacted: nil}
+
+		rere.RedactWithAllowList(input, nil, rere.WithReport(report))
+
+		paths := make([]string, 0, len(report.Redactions))
+		for _, redaction := range report.Redactions {
+			paths = append(paths, redaction.Path)
+		}
+
+		g.Expect(paths).To(gomega.Equal(expectedPaths), "WithReport should record map entries sorted by key")
+	}
+}
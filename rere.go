@@ -2,12 +2,10 @@
 package rere
 
 import (
+	"fmt"
 	"reflect"
 	"slices"
-	"strings"
 	"unsafe"
-
-	"github.com/qdm12/reprint"
 )
 
 type redactMode string
@@ -19,41 +17,46 @@ const (
 	deny  redactMode = "deny"
 )
 
-// RedactWithAllowList by default redacts all string and []byte field and key values found in the provided value.
-// If a field or key name is in the allow list then it will not be redacted.
+// RedactWithAllowList by default redacts all string and []byte field and key values found in the provided value. If a
+// field or key name is in the allow list then it will not be redacted. Entries also match the name a struct field has
+// in its json, yaml, and mapstructure tags, such as "user_name" for a field tagged `json:"user_name"`. Entries enclosed
+// in slashes, such as "/(?i).*secret.*/", are regular expressions matching whole names, and RedactWithAllowList panics
+// if one of them is invalid. Entries containing "*", such as "*_token", are globs matching names case insensitively.
 //
-// String fields are redacted with "REDACTED". Byte slice fields are redacted with []byte("REDACTED").
+// String fields are redacted with "REDACTED". Byte slice fields are redacted with []byte("REDACTED"). Placeholder
+// options such as WithKindAnnotatedPlaceholders change the value used to redact.
 // Empty string and byte slice fields are not redacted to make it easier to troubleshoot empty values.
 //
-// RedactWithAllowList will create a deep copy of the provided value, so the original value is not modified.
+// RedactWithAllowList will create a deep copy of the provided value, so the original value is not modified. Func and
+// chan values are carried over by reference unless WithNilFuncsAndChans is provided.
 //
-// RedactWithAllowList will loop through elements in slices and arrays to redact using above approach.
+// RedactWithAllowList will loop through elements in slices and arrays to redact using above approach. The list applies
+// at every level of nested maps, slices, and arrays, such as map[string][]Credential or [3]map[string]string, where
+// slice and array elements use the field or key name holding them.
 //
 // If RedactWithAllowList is directly provided a string or []byte value then it will redact the value with "REDACTED",
 // regardless of the allow list. If a field or key value is a []string then the slice will be redacted if the field
 // or key name does not appear in the allow list.
-func RedactWithAllowList[T any](value T, allowList []string) T {
-	// create a deep copy of the provided value, so original value is not modified
-	//nolint:forcetypeassert // the type is correct and if not then reprint is broken and will be caught by unit tests
-	deepCopy := reprint.This(value).(T)
-
-	reflectedValue := reflect.ValueOf(&deepCopy)
-
-	// redact all redacted field types
-	redact("", reflectedValue, allow, allowList)
-
-	return deepCopy
+func RedactWithAllowList[T any](value T, allowList []string, opts ...Option) T {
+	return redactValue(value, newListOptions(WithAllowList(allowList...), opts))
 }
 
 // RedactWithDenyList by default leaves all string and []byte field and key values found in the provided value as-is.
-// If a field or key name is in the deny list then it will be redacted.
+// If a field or key name is in the deny list then it will be redacted. Field and key values of other kinds, such as
+// int64, bool, and structs, are set to their zero value when their name is in the deny list. Entries also match the
+// names a struct field has in its json, yaml, and mapstructure tags, entries enclosed in slashes are regular
+// expressions, and entries containing "*" are globs, the same as RedactWithAllowList.
 //
-// String fields are redacted with "REDACTED". Byte slice fields are redacted with []byte("REDACTED").
+// String fields are redacted with "REDACTED". Byte slice fields are redacted with []byte("REDACTED"). Placeholder
+// options such as WithKindAnnotatedPlaceholders change the value used to redact.
 // Empty string and byte slice fields are not redacted to make it easier to troubleshoot empty values.
 //
-// RedactWithDenyList will create a deep copy of the provided value, so the original value is not modified.
+// RedactWithDenyList will create a deep copy of the provided value, so the original value is not modified. Func and
+// chan values are carried over by reference unless WithNilFuncsAndChans is provided.
 //
-// RedactWithDenyList will loop through elements in slices and arrays to redact using above approach.
+// RedactWithDenyList will loop through elements in slices and arrays to redact using above approach. The list applies
+// at every level of nested maps, slices, and arrays, such as map[string][]Credential or [3]map[string]string, where
+// slice and array elements use the field or key name holding them.
 //
 // If RedactWithDenyList is directly provided a string or []byte value then it will not redact the value,
 // regardless of the deny list. If a field or key value is a []string then the slice will be redacted if the field
@@ -69,24 +72,67 @@ func RedactWithAllowList[T any](value T, allowList []string) T {
 // In the above example, the "PrivateKey" field would be redacted if it is not in the allow list. If a new field like
 // "Organization" is added in v2, but forgotten in the allow list, then the worse case is that the "Organization"
 // field is not redacted, which is less severe than leaking a "PrivateKey" field.
-func RedactWithDenyList[T any](value T, denyList []string) T {
+func RedactWithDenyList[T any](value T, denyList []string, opts ...Option) T {
+	return redactValue(value, newListOptions(WithDenyList(denyList...), opts))
+}
+
+// Redact redacts value with opts, creating a deep copy so the provided value is not modified. Redact is configured
+// entirely through options, such as WithAllowList, WithDenyList, and WithPlaceholder, so new capabilities are added as
+// options instead of new functions.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided, the same as
+// RedactWithAllowList with an empty allow list.
+func Redact[T any](value T, opts ...Option) T {
+	return redactValue(value, newOptions(allow, nil, opts))
+}
+
+// RedactN redacts every value in values with opts in one call, such as the arguments of a log call. Values are
+// redacted as elements of a single slice, so redactions are recorded with the index of the value they were found in,
+// such as "[1].Password", and a single audit event is recorded for the call. The returned values have the same
+// dynamic types as the provided values.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided.
+func RedactN(opts []Option, values ...any) []any {
+	return redactValue(values, newOptions(allow, nil, opts))
+}
+
+func redactValue[T any](value T, redactOptions *options) T {
+	redactOptions.acquireScratch()
+	defer redactOptions.releaseScratch()
+
+	// flat structs are deep copied by value, so they're redacted in place without traversing them
+	if valueType := reflect.TypeFor[T](); redactOptions.redactsFlat(valueType) {
+		redactOptions.guardCollisions(reflect.ValueOf(&value))
+		redactOptions.redactFlat(redactOptions.root(), unsafe.Pointer(&value), valueType)
+		redactOptions.recordAuditEvent()
+
+		return value
+	}
+
+	if redacted, redactedFlat := redactFlatDynamic(value, redactOptions); redactedFlat {
+		return redacted
+	}
+
 	// create a deep copy of the provided value, so original value is not modified
-	//nolint:forcetypeassert // the type is correct and if not then reprint is broken and will be caught by unit tests
-	deepCopy := reprint.This(value).(T)
+	deepCopy := copyValue(value, redactOptions)
 
 	reflectedValue := reflect.ValueOf(&deepCopy)
 
+	redactOptions.guardCollisions(reflectedValue)
+
 	// redact all redacted field types
-	redact("", reflectedValue, deny, denyList)
+	redact(redactOptions.root(), reflectedValue, redactOptions)
+
+	redactOptions.recordAuditEvent()
 
 	return deepCopy
 }
 
-// If mode is allow then fieldKeyNameList is an allow list.
-// If mode is deny then fieldKeyNameList is a deny list.
+// If redactOptions.mode is allow then redactOptions.fieldKeyNameList is an allow list.
+// If redactOptions.mode is deny then redactOptions.fieldKeyNameList is a deny list.
 //
 //nolint:cyclop,funlen // I think the long switch statement is easier to read than breaking it up
-func redact(fieldKeyName string, value reflect.Value, mode redactMode, fieldKeyNameList []string) {
+func redact(valueLocation location, value reflect.Value, redactOptions *options) {
 	reflectedValueElem := value
 
 	// recurse through pointers to find actual value
@@ -94,67 +140,151 @@ func redact(fieldKeyName string, value reflect.Value, mode redactMode, fieldKeyN
 		reflectedValueElem = reflectedValueElem.Elem()
 	}
 
+	valueLocation = valueLocation.enter(reflectedValueElem, redactOptions)
+
+	if redactOptions.stringerBoundary != 0 && isStringer(reflectedValueElem) {
+		redactOptions.redactStringer(valueLocation, reflectedValueElem)
+
+		return
+	}
+
+	if isZeroable(reflectedValueElem) && !reflectedValueElem.IsZero() &&
+		redactOptions.shouldZero(valueLocation, reflectedValueElem.Kind()) {
+		original := []byte(fmt.Sprint(reflectedValueElem.Interface()))
+
+		reflectedValueElem.SetZero()
+		redactOptions.recordRedaction(valueLocation.path, original, reflectedValueElem.Interface())
+
+		return
+	}
+
 	switch reflectedValueElem.Kind() {
 	case reflect.Array, reflect.Slice:
 		// handle byte slice/array
 		if reflectedValueElem.Type().Elem().Kind() == reflect.Uint8 {
-			// only redact non-empty byte slice values
-			if reflectedValueElem.Len() != 0 && shouldRedact(fieldKeyName, mode, fieldKeyNameList) {
-				reflectedValueElem.Set(reflect.ValueOf([]byte(redactedMessage)))
+			// only redact non-empty byte slice values and non-zero byte array values
+			if isEmptyBytes(reflectedValueElem) ||
+				redactOptions.skipsAlreadyRedactedBytes(valueLocation.path, reflectedValueElem) {
+				break
+			}
+
+			if !redactOptions.shouldRedactBytesValue(valueLocation, reflectedValueElem.Bytes()) ||
+				!redactOptions.shouldRedactBytes(reflectedValueElem.Bytes()) {
+				redactOptions.scrubKnownSecretsValue(valueLocation, reflectedValueElem)
+
+				break
 			}
 
+			original := slices.Clone(reflectedValueElem.Bytes())
+
+			placeholder := redactOptions.bytesPlaceholder(valueLocation, reflectedValueElem)
+
+			setBytes(reflectedValueElem, redactOptions.placeholderBytes(placeholder))
+			redactOptions.recordRedaction(valueLocation.path, original, reflectedValueElem.Interface())
+
 			break
 		}
 
 		// otherwise loop through elements
 		for i := 0; i < reflectedValueElem.Len(); i++ {
-			redact(fieldKeyName, reflectedValueElem.Index(i), mode, fieldKeyNameList)
+			redact(valueLocation.index(i), reflectedValueElem.Index(i), redactOptions)
 		}
 	case reflect.Interface:
+		if reflectedValueElem.IsNil() {
+			break
+		}
+
 		element := reflectedValueElem.Elem()
 
 		redactedValue := reflect.New(element.Type())
 		redactedValue.Elem().Set(element)
 
-		redact(fieldKeyName, redactedValue, mode, fieldKeyNameList)
+		redact(valueLocation, redactedValue, redactOptions)
 
 		reflectedValueElem.Set(redactedValue.Elem())
 	case reflect.Map:
-		for _, key := range reflectedValueElem.MapKeys() {
-			keyName := key.String()
+		if redactOptions.shallow && valueLocation.path.hasField() {
+			break
+		}
 
-			element := reflectedValueElem.MapIndex(key)
+		// map values, such as structs stored directly in a map, are not addressable, so copy each value into an
+		// addressable value, redact the copy, and set it back. SetMapIndex copies the value, so the addressable value is
+		// reused for every key.
+		redactedValue := reflect.New(reflectedValueElem.Type().Elem())
 
-			redactedValue := reflect.New(element.Type())
-			redactedValue.Elem().Set(element)
+		for _, key := range redactOptions.mapKeys(reflectedValueElem) {
+			redactedValue.Elem().Set(reflectedValueElem.MapIndex(key))
 
-			redact(keyName, redactedValue, mode, fieldKeyNameList)
+			redact(valueLocation.key(key), redactedValue, redactOptions)
 
 			reflectedValueElem.SetMapIndex(key, redactedValue.Elem())
 		}
+
+		if redactOptions.redactStructMapKeys && reflectedValueElem.Type().Key().Kind() == reflect.Struct {
+			redactOptions.redactMapKeys(valueLocation, reflectedValueElem)
+		}
 	case reflect.String:
-		// only redact non-empty string values
-		if !reflectedValueElem.IsZero() && shouldRedact(fieldKeyName, mode, fieldKeyNameList) {
-			reflectedValueElem.SetString(redactedMessage)
+		// only redact non-empty string values, and keep numbers decoded from JSON the same as other numbers
+		if reflectedValueElem.IsZero() || reflectedValueElem.Type() == jsonNumberType ||
+			redactOptions.skipsAlreadyRedacted(valueLocation.path, reflectedValueElem.String()) {
+			break
 		}
+
+		if !redactOptions.redactsKind(Strings) ||
+			!redactOptions.shouldRedactValue(valueLocation, reflectedValueElem.String()) {
+			redactOptions.scrubKnownSecretsValue(valueLocation, reflectedValueElem)
+			redactOptions.transform(valueLocation, reflectedValueElem)
+
+			break
+		}
+
+		if embedded, ok := redactOptions.redactEmbedded(valueLocation, reflectedValueElem.String()); ok {
+			reflectedValueElem.SetString(embedded)
+
+			break
+		}
+
+		original := []byte(reflectedValueElem.String())
+
+		reflectedValueElem.SetString(redactOptions.placeholder(valueLocation, stringKind, reflectedValueElem))
+		redactOptions.recordRedaction(valueLocation.path, original, reflectedValueElem.Interface())
 	case reflect.Struct:
+		if redactOptions.shallow && valueLocation.path.hasField() {
+			break
+		}
+
+		if reflectedValueElem.Type() == syncMapType {
+			redactOptions.redactSyncMap(valueLocation, reflectedValueElem)
+
+			break
+		}
+
+		if isAtomic(reflectedValueElem.Type()) {
+			redactOptions.redactAtomic(valueLocation, reflectedValueElem)
+
+			break
+		}
+
 		for fieldIndex := 0; fieldIndex < reflectedValueElem.NumField(); fieldIndex++ {
-			fieldName := reflectedValueElem.Type().Field(fieldIndex).Name
+			structField := reflectedValueElem.Type().Field(fieldIndex)
+			if !redactOptions.visitsField(structField) {
+				continue
+			}
 
 			field := reflectedValueElem.Field(fieldIndex)
 
 			// use reflect.NewAt to handle redacted unexported fields
 			redactedValue := reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
 
-			redact(fieldName, redactedValue, mode, fieldKeyNameList)
+			redact(valueLocation.field(reflectedValueElem.Type(), structField), redactedValue, redactOptions)
 		}
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		redactOptions.handleUnsupportedKind(valueLocation, reflectedValueElem)
 	case reflect.Bool,
-		reflect.Chan,
 		reflect.Complex64,
 		reflect.Complex128,
 		reflect.Float32,
 		reflect.Float64,
-		reflect.Func,
 		reflect.Int,
 		reflect.Int8,
 		reflect.Int16,
@@ -167,28 +297,63 @@ func redact(fieldKeyName string, value reflect.Value, mode redactMode, fieldKeyN
 		reflect.Uint16,
 		reflect.Uint32,
 		reflect.Uint64,
-		reflect.Uintptr,
-		reflect.UnsafePointer:
+		reflect.Uintptr:
 		// do nothing
 		break
 	}
 }
 
-func shouldRedact(fieldKeyName string, mode redactMode, fieldKeyNameList []string) bool {
-	// redact when no field name and in allow mode, otherwise do not redact when in deny mode
-	// no field name means user provided a string or we're looping through a []string
-	if fieldKeyName == "" {
-		return mode == allow
+// isZeroable reports whether value is of a kind zeroed when explicitly redacted. See shouldZero.
+func isZeroable(value reflect.Value) bool {
+	//nolint:exhaustive // every other kind is either redacted with a placeholder or traversed
+	switch value.Kind() {
+	case reflect.Bool,
+		reflect.Complex64,
+		reflect.Complex128,
+		reflect.Float32,
+		reflect.Float64,
+		reflect.Int,
+		reflect.Int8,
+		reflect.Int16,
+		reflect.Int32,
+		reflect.Int64,
+		reflect.Uint,
+		reflect.Uint8,
+		reflect.Uint16,
+		reflect.Uint32,
+		reflect.Uint64,
+		reflect.Uintptr:
+		return true
+	case reflect.String:
+		// numbers decoded from JSON are zeroed the same as other numbers, and encoded as 0
+		return value.Type() == jsonNumberType
+	case reflect.Struct:
+		// atomic values are redacted through the value they hold, the same as pointers
+		return !isAtomic(value.Type())
+	default:
+		return false
+	}
+}
+
+func isEmptyBytes(value reflect.Value) bool {
+	if value.Kind() == reflect.Array {
+		return value.IsZero()
 	}
 
-	// skip redacting fields in the allow list when in allow mode
-	inAllowList := mode == allow && slices.ContainsFunc(fieldKeyNameList, func(allowedField string) bool {
-		return strings.EqualFold(allowedField, fieldKeyName)
-	})
-	// skip redacting fields not in the deny list when in deny mode
-	notInDenyList := mode == deny && !slices.ContainsFunc(fieldKeyNameList, func(deniedField string) bool {
-		return strings.EqualFold(deniedField, fieldKeyName)
-	})
+	return value.Len() == 0
+}
+
+// setBytes sets a byte slice or byte array value to placeholder. Named byte slice types are supported by converting
+// the placeholder to the value's type. Byte arrays cannot change length, so the placeholder is truncated or padded
+// with zero bytes to fit the array. Byte slices are set to placeholder, whose capacity is its length, so re-slicing
+// the redacted value up to its capacity can't expose bytes of the original value. See placeholderBytes.
+func setBytes(value reflect.Value, placeholder []byte) {
+	if value.Kind() == reflect.Array {
+		value.SetZero()
+		reflect.Copy(value, reflect.ValueOf(placeholder))
+
+		return
+	}
 
-	return !(inAllowList || notInDenyList)
+	value.Set(reflect.ValueOf(placeholder).Convert(value.Type()))
 }
@@ -501,13 +501,28 @@ func TestRedactWithDenyList(t *testing.T) {
 				"StringPtr",
 				"StringSlice",
 				"ByteSlice",
-				"Number",
-				"NumberPtr",
 				"username",
 				"password",
 			},
 			output: getRedactedComplexStruct(),
 		},
+		{
+			name:  "zeroes other kinds included in deny list",
+			input: getComplexStruct(),
+			denyList: []string{
+				"Number",
+				"NumberPtr",
+			},
+			output: func() complexStructHolder {
+				output := getComplexStruct()
+				output.NestedStruct.Number = 0
+				output.NestedStruct.number = 0
+				*output.NestedStruct.NumberPtr = 0
+				*output.NestedStruct.numberPtr = 0
+
+				return output
+			}(),
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -652,3 +667,291 @@ func getRedactedComplexStruct() complexStructHolder {
 		},
 	}
 }
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		opts     []rere.Option
+		expected credentialDetails
+	}{
+		{
+			name:     "redacts every string by default",
+			opts:     nil,
+			expected: credentialDetails{Name: redacted, Secret: redacted},
+		},
+		{
+			name:     "keeps names in the allow list",
+			opts:     []rere.Option{rere.WithAllowList("name")},
+			expected: credentialDetails{Name: "database", Secret: redacted},
+		},
+		{
+			name:     "only redacts names in the deny list",
+			opts:     []rere.Option{rere.WithDenyList("secret")},
+			expected: credentialDetails{Name: "database", Secret: redacted},
+		},
+		{
+			name:     "replaces redacted values with the placeholder",
+			opts:     []rere.Option{rere.WithAllowList("name"), rere.WithPlaceholder("[hidden]")},
+			expected: credentialDetails{Name: "database", Secret: "[hidden]"},
+		},
+		{
+			name: "builds other placeholder options on the placeholder",
+			opts: []rere.Option{
+				rere.WithAllowList("name"), rere.WithPlaceholder("***"), rere.WithKindAnnotatedPlaceholders(),
+				rere.WithLengthHint(),
+			},
+			expected: credentialDetails{Name: "database", Secret: "[*** string](6)"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			details := credentialDetails{Name: "database", Secret: "secret"}
+
+			g.Expect(rere.Redact(details, testCase.opts...)).To(gomega.Equal(testCase.expected),
+				"Redact should redact with the options")
+			g.Expect(details.Secret).To(gomega.Equal("secret"), "Redact should not modify the provided value")
+		})
+	}
+}
+
+func TestRedactN(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	user := structWithRedactedFields{
+		Username:  "alice",
+		username:  "",
+		Password:  "password",
+		password:  "",
+		byteSlice: nil,
+		stringPtr: nil,
+	}
+
+	var report rere.Report
+
+	output := rere.RedactN([]rere.Option{rere.WithAllowList("username"), rere.WithReport(&report)}, "login", user, 42)
+
+	g.Expect(output).To(gomega.Equal([]any{
+		redacted,
+		structWithRedactedFields{
+			Username:  "alice",
+			username:  "",
+			Password:  redacted,
+			password:  "",
+			byteSlice: nil,
+			stringPtr: nil,
+		},
+		42,
+	}), "RedactN should redact every value")
+	g.Expect(report.Redactions).To(gomega.Equal([]rere.Redaction{
+		{Path: "[0]", Pointer: "/0", Value: redacted},
+		{Path: "[1].Password", Pointer: "/1/Password", Value: redacted},
+	}), "RedactN should record redactions with the index of each value")
+	g.Expect(user.Password).To(gomega.Equal("password"), "RedactN should not modify the provided values")
+	g.Expect(rere.RedactN(nil)).To(gomega.BeEmpty(), "RedactN should support no values")
+}
+
+type credential struct {
+	Username string
+	Password string
+	key      []byte
+	Nested   map[string]credentialDetails
+}
+
+type credentialDetails struct {
+	Name   string
+	Secret string
+}
+
+func TestRedactStructValuesStoredInMaps(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := map[string]credential{
+		"database": {
+			Username: "admin",
+			Password: "password",
+			key:      []byte("key"),
+			Nested: map[string]credentialDetails{
+				"replica": {Name: "replica", Secret: "secret"},
+			},
+		},
+	}
+
+	output := rere.RedactWithAllowList(input, []string{"username", "name"})
+
+	g.Expect(output).To(gomega.Equal(map[string]credential{
+		"database": {
+			Username: "admin",
+			Password: redacted,
+			key:      []byte(redacted),
+			Nested: map[string]credentialDetails{
+				"replica": {Name: "replica", Secret: redacted},
+			},
+		},
+	}), "RedactWithAllowList should redact struct values stored in maps")
+	g.Expect(input["database"].Password).To(gomega.Equal("password"),
+		"RedactWithAllowList should not modify struct values stored in the provided map")
+	g.Expect(input["database"].Nested["replica"].Secret).To(gomega.Equal("secret"),
+		"RedactWithAllowList should not modify nested struct values stored in the provided map")
+
+	denied := rere.RedactWithDenyList(map[string]map[string]credentialDetails{
+		"primary": {"replica": {Name: "replica", Secret: "secret"}},
+	}, []string{"secret"})
+
+	g.Expect(denied).To(gomega.Equal(map[string]map[string]credentialDetails{
+		"primary": {"replica": {Name: "replica", Secret: redacted}},
+	}), "RedactWithDenyList should redact struct values stored in nested maps")
+}
+
+func TestRedactNestedContainers(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Credentials map[string][]credentialDetails
+		Replicas    map[string][2]credentialDetails
+		Shards      [3]map[string]string
+		Tokens      map[string][]string
+	}
+
+	newConfig := func() config {
+		return config{
+			Credentials: map[string][]credentialDetails{
+				"database": {{Name: "primary", Secret: "secret"}, {Name: "replica", Secret: "secret"}},
+			},
+			Replicas: map[string][2]credentialDetails{
+				"cache": {{Name: "primary", Secret: "secret"}, {Name: "", Secret: ""}},
+			},
+			Shards: [3]map[string]string{{"name": "a", "secret": "secret"}, nil, {"name": "c"}},
+			Tokens: map[string][]string{"name": {"alice"}, "secret": {"secret", "secret"}},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		redact func(input config) config
+	}{
+		{
+			name: "allow list",
+			redact: func(input config) config {
+				return rere.RedactWithAllowList(input, []string{"name"})
+			},
+		},
+		{
+			name: "deny list",
+			redact: func(input config) config {
+				return rere.RedactWithDenyList(input, []string{"secret"})
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			input := newConfig()
+
+			output := testCase.redact(input)
+
+			g.Expect(output).To(gomega.Equal(config{
+				Credentials: map[string][]credentialDetails{
+					"database": {{Name: "primary", Secret: redacted}, {Name: "replica", Secret: redacted}},
+				},
+				Replicas: map[string][2]credentialDetails{
+					"cache": {{Name: "primary", Secret: redacted}, {Name: "", Secret: ""}},
+				},
+				Shards: [3]map[string]string{{"name": "a", "secret": redacted}, nil, {"name": "c"}},
+				Tokens: map[string][]string{"name": {"alice"}, "secret": {redacted, redacted}},
+			}), "lists should apply at every level of nested containers")
+			g.Expect(input).To(gomega.Equal(newConfig()), "nested containers in the provided value should not be modified")
+		})
+	}
+
+	g := gomega.NewWithT(t)
+
+	var report rere.Report
+
+	rere.RedactWithDenyList(newConfig(), []string{"secret"}, rere.WithReport(&report))
+
+	g.Expect(report.Redactions).To(gomega.HaveLen(6), "every nested value should be recorded")
+	g.Expect(report.Redactions[0].Path).To(gomega.Equal("Credentials.database[0].Secret"),
+		"paths should include every level of nested containers")
+	g.Expect(report.Redactions[3].Path).To(gomega.Equal("Shards[0].secret"),
+		"paths should include every level of nested containers")
+}
+
+func TestRedactWithDenyListZeroesOtherKinds(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	type address struct {
+		Street string
+		Number int
+	}
+
+	type person struct {
+		Name    string
+		SSN     int64
+		Score   float64
+		Admin   bool
+		Address address
+		Scores  []int
+		Limits  map[string]uint
+		Age     int `rere:"redact"`
+		Created any
+	}
+
+	input := person{
+		Name:    "alice",
+		SSN:     123456789,
+		Score:   9.5,
+		Admin:   true,
+		Address: address{Street: "Main St", Number: 1},
+		Scores:  []int{1, 2},
+		Limits:  map[string]uint{"ssn": 1, "requests": 2},
+		Age:     42,
+		Created: 2024,
+	}
+
+	var report rere.Report
+
+	output := rere.RedactWithDenyList(input, []string{"ssn", "score", "admin", "address", "scores", "created"},
+		rere.WithReport(&report))
+
+	g.Expect(output).To(gomega.Equal(person{
+		Name:    "alice",
+		SSN:     0,
+		Score:   0,
+		Admin:   false,
+		Address: address{Street: "", Number: 0},
+		Scores:  []int{0, 0},
+		Limits:  map[string]uint{"ssn": 0, "requests": 2},
+		Age:     0,
+		Created: 0,
+	}), "RedactWithDenyList should zero other kinds included in deny list")
+	g.Expect(input.SSN).To(gomega.Equal(int64(123456789)), "RedactWithDenyList should not modify the provided input")
+	g.Expect(report.Redactions[0]).To(gomega.Equal(rere.Redaction{Path: "SSN", Pointer: "/SSN", Value: int64(0)}),
+		"RedactWithDenyList should record zeroed values")
+
+	g.Expect(rere.RedactWithAllowList(input, []string{"name"})).To(gomega.Equal(person{
+		Name:    "alice",
+		SSN:     123456789,
+		Score:   9.5,
+		Admin:   true,
+		Address: address{Street: redacted, Number: 1},
+		Scores:  []int{1, 2},
+		Limits:  map[string]uint{"ssn": 1, "requests": 2},
+		Age:     0,
+		Created: 2024,
+	}), "RedactWithAllowList should only zero other kinds explicitly redacted")
+}
@@ -0,0 +1,214 @@
+package rere
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultBodyLimit is the number of bytes of request and response bodies logged by RoundTripper when
+// RoundTripperConfig.BodyLimit is zero.
+const defaultBodyLimit = 4096
+
+// RoundTripperConfig configures how NewRoundTripper sends and logs outbound requests.
+type RoundTripperConfig struct {
+	// Inner sends the requests. http.DefaultTransport is used when Inner is nil.
+	Inner http.RoundTripper
+	// Logger receives every request and response after redaction. slog.Default is used when Logger is nil.
+	Logger *slog.Logger
+	// BodyLimit is the maximum number of bytes of request and response bodies logged. Bodies longer than BodyLimit are
+	// logged as a placeholder, since a truncated JSON or form body can't be redacted by name. 4096 is used when
+	// BodyLimit is zero, and a negative BodyLimit stops bodies from being read and logged, which should be used for
+	// streaming responses.
+	BodyLimit int
+}
+
+// RoundTripper is an http.RoundTripper logging outbound requests and responses after redacting them. See
+// NewRoundTripper.
+type RoundTripper struct {
+	inner     http.RoundTripper
+	logger    *slog.Logger
+	bodyLimit int
+	opts      []Option
+}
+
+var _ http.RoundTripper = (*RoundTripper)(nil)
+
+// NewRoundTripper creates an http.RoundTripper sending requests with config.Inner and logging every request and
+// response to config.Logger after redaction, since outbound calls to third parties carry API keys and are rarely logged
+// safely.
+//
+// Request and response headers, URL query parameters, and the fields of JSON and form bodies are redacted with opts by
+// name, so an allow list such as WithAllowList("Content-Type", "page") keeps them while every other value is
+// redacted. URL passwords are always redacted. Bodies of other content types are logged as a placeholder. Up to
+// config.BodyLimit bytes of each body are read before the request is sent and before the response is returned, and the
+// read bytes are replayed, so the full body is still sent and returned.
+//
+// Requests are logged at slog.LevelError for 5xx statuses and transport errors, slog.LevelWarn for 4xx statuses, and
+// slog.LevelInfo otherwise. Transport errors are scrubbed with QueryStringDetector.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided.
+func NewRoundTripper(config RoundTripperConfig, opts ...Option) *RoundTripper {
+	if config.Inner == nil {
+		config.Inner = http.DefaultTransport
+	}
+
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	if config.BodyLimit == 0 {
+		config.BodyLimit = defaultBodyLimit
+	}
+
+	return &RoundTripper{
+		inner:     config.Inner,
+		logger:    config.Logger,
+		bodyLimit: config.BodyLimit,
+		opts:      opts,
+	}
+}
+
+// RoundTrip sends request with the inner http.RoundTripper and logs the redacted request and response.
+func (r *RoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	attrs := []slog.Attr{
+		slog.String("method", request.Method),
+		slog.String("url", redactURL(request.URL, r.opts)),
+		slog.Any("request_headers", RedactStringSliceMap(request.Header, r.opts...)),
+	}
+
+	if request.Body != nil && request.Body != http.NoBody {
+		body, replayed := readBody(request.Body, request.Header, r.bodyLimit, r.opts)
+
+		// an http.RoundTripper must not modify request, so the replayed body is sent with a clone
+		request = request.Clone(request.Context())
+		request.Body = replayed
+
+		if body != "" {
+			attrs = append(attrs, slog.String("request_body", body))
+		}
+	}
+
+	response, err := r.inner.RoundTrip(request)
+
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", RedactText(err.Error(), QueryStringDetector())))
+		r.logger.LogAttrs(request.Context(), slog.LevelError, "outbound request", attrs...)
+
+		//nolint:wrapcheck // errors of the inner http.RoundTripper are returned as-is, like any other transport
+		return nil, err
+	}
+
+	attrs = append(attrs,
+		slog.Int("status", response.StatusCode),
+		slog.Any("response_headers", RedactStringSliceMap(response.Header, r.opts...)),
+	)
+
+	if response.Body != nil && response.Body != http.NoBody {
+		var body string
+
+		body, response.Body = readBody(response.Body, response.Header, r.bodyLimit, r.opts)
+		if body != "" {
+			attrs = append(attrs, slog.String("response_body", body))
+		}
+	}
+
+	r.logger.LogAttrs(request.Context(), statusLevel(response.StatusCode), "outbound request", attrs...)
+
+	return response, nil
+}
+
+func statusLevel(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// redactURL returns requestURL with its query parameters redacted by name and its password replaced.
+func redactURL(requestURL *url.URL, opts []Option) string {
+	redactedURL := *requestURL
+
+	if redactedURL.RawQuery != "" {
+		redactedURL.RawQuery = url.Values(RedactStringSliceMap(redactedURL.Query(), opts...)).Encode()
+	}
+
+	if _, hasPassword := redactedURL.User.Password(); hasPassword {
+		redactedURL.User = url.UserPassword(redactedURL.User.Username(), redactedMessage)
+	}
+
+	return redactedURL.String()
+}
+
+// readBody reads up to limit bytes of body and returns the redacted bytes read along with a body replaying them before
+// the rest of body. The redacted body is empty when limit is 0 or less or body is empty.
+func readBody(body io.ReadCloser, header http.Header, limit int, opts []Option) (string, io.ReadCloser) {
+	if limit <= 0 {
+		return "", body
+	}
+
+	prefix, err := io.ReadAll(io.LimitReader(body, int64(limit)+1))
+
+	replayed := replayBody{Reader: io.MultiReader(bytes.NewReader(prefix), body), Closer: body}
+	if err != nil {
+		// the read error is returned again to the reader of the replayed body
+		replayed.Reader = io.MultiReader(bytes.NewReader(prefix), errorReader{err: err})
+	}
+
+	if len(prefix) == 0 {
+		return "", replayed
+	}
+
+	if len(prefix) > limit {
+		return redactedMessage, replayed
+	}
+
+	return redactBody(prefix, header.Get("Content-Type"), opts), replayed
+}
+
+// redactBody redacts the fields of a JSON or form body by name and replaces bodies of other content types.
+func redactBody(body []byte, contentType string, opts []Option) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			return url.Values(RedactStringSliceMap(values, opts...)).Encode()
+		}
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if redacted, ok := redactJSONText(body, opts); ok {
+			return string(redacted)
+		}
+	}
+
+	return redactedMessage
+}
+
+// replayBody replays the bytes read by readBody before the rest of the original body and closes the original body.
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// errorReader returns err from every read.
+type errorReader struct {
+	err error
+}
+
+func (e errorReader) Read([]byte) (int, error) {
+	return 0, e.err
+}
@@ -0,0 +1,437 @@
+package rere
+
+import (
+	"go/token"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// RuleSource is a kind of rule deciding whether a value is redacted.
+type RuleSource int
+
+const (
+	// TagRule redacts fields tagged with `rere:"redact"` and keeps fields tagged with `rere:"keep"`. Tagged fields of
+	// kinds other than string and []byte, such as int64 and structs, are set to their zero value.
+	TagRule RuleSource = iota + 1
+	// NameRule keeps field and key names in the allow list and redacts field and key names in the deny list.
+	// Entries of the form "mypkg.User:Password" only apply to fields declared by the mypkg.User type. The type may also
+	// be fully qualified with its import path, such as "example.com/mypkg.User:Password".
+	NameRule
+	// PackageRule redacts fields declared by struct types in packages provided to WithRedactPackages.
+	PackageRule
+	// TypeRule redacts values of types provided to WithRedactTypes, including every value nested inside of them.
+	TypeRule
+	// PathRule redacts values at paths provided to WithRedactPaths and keeps values at paths provided to WithKeepPaths.
+	PathRule
+	// ValueRule redacts string and []byte values matching patterns provided to WithValuePatterns or detectors provided
+	// to WithValueDetectors. Values are matched after the other rule sources keep them, so ValueRule is ignored by
+	// WithPrecedence.
+	ValueRule
+)
+
+//nolint:gochecknoglobals // slices can't be constants
+var defaultPrecedence = []RuleSource{PathRule, TagRule, TypeRule, NameRule, PackageRule}
+
+const (
+	tagName      = "rere"
+	tagRedact    = "redact"
+	tagKeep      = "keep"
+	tagSeparator = ","
+
+	typeScopeSeparator = ":"
+
+	packageWildcard = "/..."
+)
+
+// nameTags are the struct tags providing names matching list entries in addition to field names, so entries can be
+// written in terms of wire names and configuration keys, such as "user_name" for a field tagged `json:"user_name"` or
+// "api_key" for a field tagged `mapstructure:"api_key"` by a configuration struct decoded with viper.
+//
+//nolint:gochecknoglobals // slices can't be constants
+var nameTags = []string{jsonTag, "yaml", "mapstructure"}
+
+// WithPrecedence overrides the order rule sources are consulted in to decide whether a value is redacted. The first
+// rule source with a rule applying to a value decides. When no rule applies, values are redacted for
+// RedactWithAllowList and kept for RedactWithDenyList. Rule sources not provided are ignored.
+//
+// The default precedence is PathRule, TagRule, TypeRule, NameRule, then PackageRule, so a field tagged with
+// `rere:"keep"` is not redacted even if its name is not in the allow list.
+func WithPrecedence(sources ...RuleSource) Option {
+	return func(o *options) {
+		o.precedence = sources
+	}
+}
+
+// WithRedactPackages redacts string and []byte fields declared by struct types in packages matching patterns, so
+// stricter defaults can be applied to known-sensitive domains without affecting everything else.
+//
+// A pattern matches an import path exactly or matches its trailing path elements, so "internal/payments" matches
+// "example.com/app/internal/payments". A pattern ending in "/..." also matches subpackages, so
+// "internal/payments/..." matches "example.com/app/internal/payments/cards".
+func WithRedactPackages(patterns ...string) Option {
+	return func(o *options) {
+		o.redactPackages = patterns
+	}
+}
+
+// WithRedactTypes redacts values of types matching typeNames regardless of field or key names, since type identity is
+// often a better signal than names. Every string and []byte nested inside of a matching value is redacted.
+//
+// A type name matches either reflect.Type.String(), such as "vault.Token", or the type's import path and name, such
+// as "example.com/vault.Token".
+func WithRedactTypes(typeNames ...string) Option {
+	return func(o *options) {
+		o.redactTypes = typeNames
+	}
+}
+
+// location describes where a value being redacted was found.
+type location struct {
+	// name is the field or key name the value was found in. Slice and array elements inherit the name of the field or
+	// key holding the slice or array.
+	name string
+	// tag is the tag of the struct field the value was found in.
+	tag reflect.StructTag
+	// owner is the struct type declaring the field the value was found in.
+	owner reflect.Type
+	// inRedactedType is true when the value is or is nested inside of a type provided to WithRedactTypes.
+	inRedactedType bool
+	// inEmbedded is true when the value was decoded from a redacted string, so it is redacted when no rule applies.
+	inEmbedded bool
+	path       path
+}
+
+// rootLocation is the location of the provided value.
+//
+//nolint:gochecknoglobals // structs can't be constants
+var rootLocation = location{name: "", tag: "", owner: nil, inRedactedType: false, inEmbedded: false, path: nil}
+
+func (l location) field(owner reflect.Type, structField reflect.StructField) location {
+	return location{
+		name:           structField.Name,
+		tag:            structField.Tag,
+		owner:          owner,
+		inRedactedType: l.inRedactedType,
+		inEmbedded:     l.inEmbedded,
+		path:           l.path.field(structField.Name),
+	}
+}
+
+func (l location) key(key reflect.Value) location {
+	keyPath := l.path.key(key)
+
+	return location{
+		name:           keyPath[len(keyPath)-1].name,
+		tag:            "",
+		owner:          nil,
+		inRedactedType: l.inRedactedType,
+		inEmbedded:     l.inEmbedded,
+		path:           keyPath,
+	}
+}
+
+// stringKey is the same as key for a string key without requiring a reflect.Value.
+func (l location) stringKey(key string) location {
+	return location{
+		name:           key,
+		tag:            "",
+		owner:          nil,
+		inRedactedType: l.inRedactedType,
+		inEmbedded:     l.inEmbedded,
+		path:           l.path.field(key),
+	}
+}
+
+func (l location) index(index int) location {
+	return location{
+		name:           l.name,
+		tag:            l.tag,
+		owner:          l.owner,
+		inRedactedType: l.inRedactedType,
+		inEmbedded:     l.inEmbedded,
+		path:           l.path.index(index),
+	}
+}
+
+// anyIndex is the same as index for an element at any index, such as when walking a type instead of a value.
+func (l location) anyIndex() location {
+	l.path = l.path.anyIndex()
+
+	return l
+}
+
+// enter returns the location of value, marking it as in a redacted type if value's type is provided to
+// WithRedactTypes.
+func (l location) enter(value reflect.Value, redactOptions *options) location {
+	if !value.IsValid() {
+		return l
+	}
+
+	return l.enterType(value.Type(), redactOptions)
+}
+
+// enterType is the same as enter for a value of valueType.
+func (l location) enterType(valueType reflect.Type, redactOptions *options) location {
+	if l.inRedactedType || len(redactOptions.redactTypes) == 0 {
+		return l
+	}
+
+	l.inRedactedType = slices.ContainsFunc(redactOptions.redactTypes, func(typeName string) bool {
+		return typeName == valueType.String() || typeName == valueType.PkgPath()+"."+valueType.Name()
+	})
+
+	return l
+}
+
+// shouldRedact decides whether the value at valueLocation is redacted by consulting rule sources in precedence order.
+func (o *options) shouldRedact(valueLocation location) bool {
+	redact, source := o.decide(valueLocation)
+
+	o.recordDecision(valueLocation, redact, source)
+
+	return redact
+}
+
+// shouldZero decides whether a value of kind at valueLocation, other than string and []byte, is set to its zero
+// value. Values are only zeroed when a tag or name rule explicitly redacts them, such as a deny list including "SSN"
+// for an SSN int64 field, since other rules and the default only apply to strings and byte slices. Path rules zero
+// values too, except structs, which are traversed since path rules already apply to every value nested inside of them.
+// WithNonStringValues changes which values are zeroed.
+func (o *options) shouldZero(valueLocation location, kind reflect.Kind) bool {
+	if o.nonStringValues == KeepNonStrings {
+		return false
+	}
+
+	redact, source := o.decide(valueLocation)
+
+	zero := false
+
+	switch {
+	case o.nonStringValues == ZeroNonStrings:
+		zero = redact && kind != reflect.Struct
+	case source == TagRule, source == NameRule:
+		zero = redact
+	case source == PathRule:
+		zero = redact && kind != reflect.Struct
+	}
+
+	if zero {
+		o.recordDecision(valueLocation, true, source)
+	}
+
+	return zero
+}
+
+// decide returns whether the value at valueLocation is redacted and the rule source deciding it. The rule source is
+// zero when no rule applies.
+func (o *options) decide(valueLocation location) (bool, RuleSource) {
+	for _, source := range o.precedence {
+		if redact, ok := o.applyRule(source, valueLocation); ok {
+			return redact, source
+		}
+	}
+
+	if o.keepBareValues && !valueLocation.path.hasField() {
+		return false, 0
+	}
+
+	// redact by default in allow mode and inside of redacted strings, otherwise do not redact in deny mode
+	return o.mode == allow || valueLocation.inEmbedded, 0
+}
+
+// applyRule returns whether source redacts the value at valueLocation and whether source has a rule applying to it.
+func (o *options) applyRule(source RuleSource, valueLocation location) (bool, bool) {
+	switch source {
+	case TagRule:
+		tagValue, found := valueLocation.tag.Lookup(tagName)
+		if !found {
+			return false, false
+		}
+
+		tagOptions := strings.Split(tagValue, tagSeparator)
+
+		switch {
+		case slices.Contains(tagOptions, tagRedact):
+			return true, true
+		case slices.Contains(tagOptions, tagKeep):
+			return false, true
+		}
+	case NameRule:
+		// no field name means user provided a string or we're looping through a []string
+		if valueLocation.name == "" {
+			return false, false
+		}
+
+		if _, redact, inList := o.findNameEntry(valueLocation); inList {
+			return redact, true
+		}
+	case PathRule:
+		return o.applyPathRule(valueLocation.path)
+	case TypeRule:
+		if valueLocation.inRedactedType {
+			return true, true
+		}
+	case ValueRule:
+		// value patterns are matched against values by shouldRedactValue, since rules only know where values are found
+	case PackageRule:
+		if valueLocation.owner == nil {
+			return false, false
+		}
+
+		inPackage := slices.ContainsFunc(o.redactPackages, func(pattern string) bool {
+			return matchesPackage(pattern, valueLocation.owner.PkgPath())
+		})
+		if inPackage {
+			return true, true
+		}
+	}
+
+	return false, false
+}
+
+// matchesEntry reports whether a list entry matches the field or key name at valueLocation case insensitively, unless
+// WithCaseSensitiveNames is provided.
+func (o *options) matchesEntry(entry string, valueLocation location) bool {
+	if isRegexpEntry(entry) {
+		return o.matchesPattern(entryPattern(entry, false), valueLocation)
+	}
+
+	typeName, fieldKeyName, typeScoped := typeScope(entry)
+	if !typeScoped || valueLocation.owner == nil {
+		// map keys, such as "aws:kms", may hold the separator, so entries only scope struct fields by type
+		return o.matchesName(entry, valueLocation)
+	}
+
+	if !o.matchesName(fieldKeyName, valueLocation) {
+		return false
+	}
+
+	return strings.EqualFold(typeName, valueLocation.owner.String()) ||
+		strings.EqualFold(typeName, valueLocation.owner.PkgPath()+"."+valueLocation.owner.Name())
+}
+
+// typeScope returns the type name and field name of a type-scoped entry, such as "mypkg.User" and "Password" for
+// "mypkg.User:Password". Entries are only scoped to a type when the part before the separator is a package-qualified
+// type name, so other entries holding the separator are matched as a whole.
+func typeScope(entry string) (string, string, bool) {
+	typeName, fieldKeyName, found := strings.Cut(entry, typeScopeSeparator)
+	if !found || fieldKeyName == "" || !isQualifiedTypeName(typeName) {
+		return "", "", false
+	}
+
+	return typeName, fieldKeyName, true
+}
+
+// isQualifiedTypeName reports whether typeName is a type name qualified by its package name or import path, such as
+// "mypkg.User" or "example.com/mypkg.User".
+func isQualifiedTypeName(typeName string) bool {
+	importPath, packageType := "", typeName
+	if separatorIndex := strings.LastIndex(typeName, "/"); separatorIndex != -1 {
+		importPath, packageType = typeName[:separatorIndex], typeName[separatorIndex+1:]
+	}
+
+	packageName, name, found := strings.Cut(packageType, ".")
+	if !found || !token.IsIdentifier(packageName) || !token.IsIdentifier(name) {
+		return false
+	}
+
+	if importPath == "" {
+		return true
+	}
+
+	for _, element := range strings.Split(importPath, "/") {
+		if element == "" || strings.ContainsFunc(element, unicode.IsSpace) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesName reports whether fieldKeyName matches the field or key name at valueLocation, or the name of the struct
+// field in one of nameTags. Names that are globs, such as "*_token", match names with the glob.
+func (o *options) matchesName(fieldKeyName string, valueLocation location) bool {
+	if isGlobName(fieldKeyName) {
+		return o.matchesPattern(entryPattern(fieldKeyName, o.nameFolding.caseSensitive), valueLocation)
+	}
+
+	return o.matchesAnyName(valueLocation, func(name string) bool {
+		return o.equalNames(fieldKeyName, name)
+	})
+}
+
+// matchesPattern reports whether pattern matches the field or key name at valueLocation, or the name of the struct
+// field in one of nameTags.
+func (o *options) matchesPattern(pattern *regexp.Regexp, valueLocation location) bool {
+	return o.matchesAnyName(valueLocation, pattern.MatchString)
+}
+
+// matchesAnyName reports whether matches reports true for the field or key name at valueLocation or the name of the
+// struct field in one of nameTags. Only the serialized name of struct fields is matched with WithJSONSemantics.
+func (o *options) matchesAnyName(valueLocation location, matches func(name string) bool) bool {
+	if o.jsonSemantics && valueLocation.owner != nil {
+		return matches(serializedName(valueLocation))
+	}
+
+	if matches(valueLocation.name) {
+		return true
+	}
+
+	for _, key := range nameTags {
+		if taggedName, found := tagFieldName(valueLocation.tag, key); found && matches(taggedName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tagFieldName returns the field name provided by the tag key of structTag, such as "user_name" for
+// `json:"user_name,omitempty"`. Tags without a name and tags skipping the field with "-" don't provide a name.
+func tagFieldName(structTag reflect.StructTag, key string) (string, bool) {
+	tagValue, found := structTag.Lookup(key)
+	if !found {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tagValue, tagSeparator)
+	if name == "" || name == "-" {
+		return "", false
+	}
+
+	return name, true
+}
+
+// matchesPackage reports whether pattern matches importPath. See WithRedactPackages.
+func matchesPackage(pattern, importPath string) bool {
+	if importPath == "" {
+		return false
+	}
+
+	base, recursive := strings.CutSuffix(pattern, packageWildcard)
+
+	matchesBase := func(candidate string) bool {
+		return candidate == base || strings.HasSuffix(candidate, "/"+base)
+	}
+
+	if !recursive {
+		return matchesBase(importPath)
+	}
+
+	// check the import path and every parent path for a match
+	for candidate := importPath; ; {
+		if matchesBase(candidate) {
+			return true
+		}
+
+		separatorIndex := strings.LastIndex(candidate, "/")
+		if separatorIndex == -1 {
+			return false
+		}
+
+		candidate = candidate[:separatorIndex]
+	}
+}
@@ -0,0 +1,420 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type structWithTags struct {
+	Username string `rere:"redact"`
+	Password string `rere:"keep"`
+	Token    string `json:"token"`
+}
+
+//nolint:funlen // I'm okay with test functions with several statements of test data
+func TestRedactWithRules(t *testing.T) {
+	t.Parallel()
+
+	input := structWithTags{
+		Username: "username",
+		Password: "password",
+		Token:    "token",
+	}
+
+	testCases := []struct {
+		name   string
+		redact func() structWithTags
+		output structWithTags
+	}{
+		{
+			name: "tags take precedence over the allow list by default",
+			redact: func() structWithTags {
+				return rere.RedactWithAllowList(input, []string{"username"})
+			},
+			output: structWithTags{
+				Username: redacted,
+				Password: "password",
+				Token:    redacted,
+			},
+		},
+		{
+			name: "tags take precedence over the deny list by default",
+			redact: func() structWithTags {
+				return rere.RedactWithDenyList(input, []string{"password", "token"})
+			},
+			output: structWithTags{
+				Username: redacted,
+				Password: "password",
+				Token:    redacted,
+			},
+		},
+		{
+			name: "names can take precedence over tags",
+			redact: func() structWithTags {
+				return rere.RedactWithAllowList(input, []string{"username"}, rere.WithPrecedence(rere.NameRule, rere.TagRule))
+			},
+			output: structWithTags{
+				Username: "username",
+				Password: "password",
+				Token:    redacted,
+			},
+		},
+		{
+			name: "ignores rule sources not in precedence",
+			redact: func() structWithTags {
+				return rere.RedactWithDenyList(input, []string{"token"}, rere.WithPrecedence(rere.NameRule))
+			},
+			output: structWithTags{
+				Username: "username",
+				Password: "password",
+				Token:    redacted,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output), "rules should be applied in precedence order")
+		})
+	}
+}
+
+type nationalIdentity struct {
+	ID   string
+	Name string
+}
+
+type order struct {
+	ID     string
+	Holder nationalIdentity
+	Labels map[string]string
+}
+
+func TestRedactWithTypeScopedEntries(t *testing.T) {
+	t.Parallel()
+
+	input := order{
+		ID: "order-1",
+		Holder: nationalIdentity{
+			ID:   "123-45-6789",
+			Name: "alice",
+		},
+		Labels: map[string]string{"ID": "label"},
+	}
+
+	testCases := []struct {
+		name   string
+		redact func() order
+		output order
+	}{
+		{
+			name: "deny list entries only apply to fields of the scoped type",
+			redact: func() order {
+				return rere.RedactWithDenyList(input, []string{"rere_test.nationalIdentity:id"})
+			},
+			output: order{
+				ID:     "order-1",
+				Holder: nationalIdentity{ID: redacted, Name: "alice"},
+				Labels: map[string]string{"ID": "label"},
+			},
+		},
+		{
+			name: "allow list entries only apply to fields of the scoped type",
+			redact: func() order {
+				return rere.RedactWithAllowList(input, []string{"github.com/dustinspecker/rere_test.order:ID", "Name"})
+			},
+			output: order{
+				ID:     "order-1",
+				Holder: nationalIdentity{ID: redacted, Name: "alice"},
+				Labels: map[string]string{"ID": redacted},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output), "type-scoped entries should match fields of the type")
+		})
+	}
+}
+
+func TestRedactMatchesMapKeysHoldingTypeScopeSeparator(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]string{"aws:kms": "keyid", "region": "us-east-1"}
+	largeList := append(generatedEntries("Field%d", 20), "AWS:KMS")
+
+	testCases := []struct {
+		name   string
+		redact func() map[string]string
+		output map[string]string
+	}{
+		{
+			name: "deny list entries match map keys as a whole",
+			redact: func() map[string]string {
+				return rere.RedactWithDenyList(input, []string{"aws:kms"})
+			},
+			output: map[string]string{"aws:kms": redacted, "region": "us-east-1"},
+		},
+		{
+			name: "allow list entries match map keys as a whole",
+			redact: func() map[string]string {
+				return rere.RedactWithAllowList(input, []string{"aws:kms"})
+			},
+			output: map[string]string{"aws:kms": "keyid", "region": redacted},
+		},
+		{
+			name: "policies indexing large deny lists match map keys as a whole",
+			redact: func() map[string]string {
+				//nolint:forcetypeassert // Redact returns a value of the provided type
+				return rere.NewPolicy(rere.WithDenyList(largeList...)).Redact(input).(map[string]string)
+			},
+			output: map[string]string{"aws:kms": redacted, "region": "us-east-1"},
+		},
+		{
+			name: "field placeholders match map keys as a whole",
+			redact: func() map[string]string {
+				return rere.RedactWithDenyList(input, []string{"aws:kms"},
+					rere.WithFieldPlaceholders(map[string]string{"aws:kms": "<key>"}))
+			},
+			output: map[string]string{"aws:kms": "<key>", "region": "us-east-1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output),
+				"entries holding a separator without a type name should match map keys literally")
+		})
+	}
+}
+
+type wireUser struct {
+	UserName string `json:"user_name"`
+	Password string `json:"pass,omitempty"`
+	Email    string `json:",omitempty"`
+	Internal string `json:"-"`
+}
+
+func TestRedactMatchesJSONTags(t *testing.T) {
+	t.Parallel()
+
+	input := wireUser{UserName: "alice", Password: "hunter2", Email: "alice@example.com", Internal: "internal"}
+
+	testCases := []struct {
+		name   string
+		redact func() wireUser
+		output wireUser
+	}{
+		{
+			name: "allow list entries match json tag names",
+			redact: func() wireUser {
+				return rere.RedactWithAllowList(input, []string{"USER_NAME", "email", "-"})
+			},
+			output: wireUser{UserName: "alice", Password: redacted, Email: "alice@example.com", Internal: redacted},
+		},
+		{
+			name: "deny list entries match json tag names",
+			redact: func() wireUser {
+				return rere.RedactWithDenyList(input, []string{"pass"})
+			},
+			output: wireUser{UserName: "alice", Password: redacted, Email: "alice@example.com", Internal: "internal"},
+		},
+		{
+			name: "type-scoped entries match json tag names",
+			redact: func() wireUser {
+				return rere.RedactWithDenyList(input, []string{"rere_test.wireUser:pass"})
+			},
+			output: wireUser{UserName: "alice", Password: redacted, Email: "alice@example.com", Internal: "internal"},
+		},
+		{
+			name: "entries in large lists match json tag names",
+			redact: func() wireUser {
+				policy := rere.NewAllowListPolicy(append(generatedEntries("field%d", 100), "user_name"))
+
+				redactedUser, _ := policy.Redact(input).(wireUser)
+
+				return redactedUser
+			},
+			output: wireUser{UserName: "alice", Password: redacted, Email: redacted, Internal: redacted},
+		},
+		{
+			name: "field names still match fields with json tags",
+			redact: func() wireUser {
+				return rere.RedactWithDenyList(input, []string{"Password"})
+			},
+			output: wireUser{UserName: "alice", Password: redacted, Email: "alice@example.com", Internal: "internal"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output), "entries should match json tag names")
+		})
+	}
+}
+
+type serviceConfig struct {
+	Endpoint string `yaml:"endpoint"`
+	APIKey   string `mapstructure:"api_key"`
+	Password string `json:"password" yaml:"db_password"`
+}
+
+func TestRedactMatchesConfigurationTags(t *testing.T) {
+	t.Parallel()
+
+	input := serviceConfig{Endpoint: "https://example.com", APIKey: "abc123", Password: "hunter2"}
+
+	testCases := []struct {
+		name   string
+		redact func() serviceConfig
+		output serviceConfig
+	}{
+		{
+			name: "allow list entries match yaml tag names",
+			redact: func() serviceConfig {
+				return rere.RedactWithAllowList(input, []string{"endpoint"})
+			},
+			output: serviceConfig{Endpoint: "https://example.com", APIKey: redacted, Password: redacted},
+		},
+		{
+			name: "deny list entries match mapstructure tag names",
+			redact: func() serviceConfig {
+				return rere.RedactWithDenyList(input, []string{"api_key"})
+			},
+			output: serviceConfig{Endpoint: "https://example.com", APIKey: redacted, Password: "hunter2"},
+		},
+		{
+			name: "entries match every tag name of a field",
+			redact: func() serviceConfig {
+				return rere.RedactWithDenyList(input, []string{"db_password"})
+			},
+			output: serviceConfig{Endpoint: "https://example.com", APIKey: "abc123", Password: redacted},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output), "entries should match configuration tag names")
+		})
+	}
+}
+
+func TestRedactWithRedactPackages(t *testing.T) {
+	t.Parallel()
+
+	input := nationalIdentity{
+		ID:   "123-45-6789",
+		Name: "alice",
+	}
+
+	testCases := []struct {
+		pattern string
+		output  nationalIdentity
+	}{
+		{pattern: "github.com/dustinspecker/rere_test", output: nationalIdentity{ID: redacted, Name: redacted}},
+		{pattern: "rere_test", output: nationalIdentity{ID: redacted, Name: redacted}},
+		{pattern: "dustinspecker/...", output: nationalIdentity{ID: redacted, Name: redacted}},
+		{pattern: "github.com/...", output: nationalIdentity{ID: redacted, Name: redacted}},
+		{pattern: "dustinspecker", output: nationalIdentity{ID: "123-45-6789", Name: "alice"}},
+		{pattern: "test", output: nationalIdentity{ID: "123-45-6789", Name: "alice"}},
+		{pattern: "github.com/dustinspecker/rere_test/nested/...", output: nationalIdentity{ID: "123-45-6789", Name: "alice"}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.pattern, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			redacted := rere.RedactWithDenyList(input, nil, rere.WithRedactPackages(testCase.pattern))
+
+			g.Expect(redacted).To(gomega.Equal(testCase.output), "WithRedactPackages should redact fields of matching packages")
+		})
+	}
+}
+
+func TestRedactPackagesPrecedence(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := nationalIdentity{
+		ID:   "123-45-6789",
+		Name: "alice",
+	}
+
+	defaultPrecedence := rere.RedactWithAllowList(input, []string{"name"}, rere.WithRedactPackages("rere_test"))
+	packageFirst := rere.RedactWithAllowList(input, []string{"name"},
+		rere.WithRedactPackages("rere_test"), rere.WithPrecedence(rere.PackageRule, rere.NameRule))
+
+	g.Expect(defaultPrecedence).To(gomega.Equal(nationalIdentity{ID: redacted, Name: "alice"}),
+		"the allow list should take precedence over packages by default")
+	g.Expect(packageFirst).To(gomega.Equal(nationalIdentity{ID: redacted, Name: redacted}),
+		"packages can take precedence over the allow list")
+}
+
+type token struct {
+	Value  string
+	Scopes []string
+}
+
+type tokenHolder struct {
+	Primary   token
+	Secondary *token
+	Dynamic   any
+	Key       apiKey
+	Name      string
+}
+
+func TestRedactWithRedactTypes(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := tokenHolder{
+		Primary:   token{Value: "primary", Scopes: []string{"read"}},
+		Secondary: &token{Value: "secondary", Scopes: nil},
+		Dynamic:   token{Value: "dynamic", Scopes: nil},
+		Key:       "key",
+		Name:      "name",
+	}
+
+	redactedInput := rere.RedactWithDenyList(input, nil,
+		rere.WithRedactTypes("rere_test.token", "github.com/dustinspecker/rere_test.apiKey"))
+
+	g.Expect(redactedInput).To(gomega.Equal(tokenHolder{
+		Primary:   token{Value: redacted, Scopes: []string{redacted}},
+		Secondary: &token{Value: redacted, Scopes: nil},
+		Dynamic:   token{Value: redacted, Scopes: nil},
+		Key:       redacted,
+		Name:      "name",
+	}), "WithRedactTypes should redact values of matching types")
+
+	allowedByName := rere.RedactWithAllowList(input, []string{"value", "scopes", "key", "name"},
+		rere.WithRedactTypes("rere_test.token"))
+
+	g.Expect(allowedByName.Primary).To(gomega.Equal(token{Value: redacted, Scopes: []string{redacted}}),
+		"types should take precedence over the allow list by default")
+	g.Expect(allowedByName.Key).To(gomega.Equal(apiKey("key")), "types not provided should not be redacted")
+}
@@ -0,0 +1,53 @@
+package rere
+
+import (
+	"regexp"
+	"strings"
+)
+
+//nolint:gochecknoglobals // regular expressions are compiled once
+var (
+	// secretFormats match credentials with well-known formats, which are recognizable without knowing where they were
+	// found.
+	secretFormats = []*regexp.Regexp{
+		// AWS access key IDs
+		regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA|AGPA|AIDA|AIPA|ANPA|ANVA|APKA|AROA|ASCA)[0-9A-Z]{16}\b`),
+		// GitHub personal access, OAuth, user-to-server, server-to-server, and refresh tokens
+		regexp.MustCompile(`\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36,255}\b`),
+		// GitHub fine-grained personal access tokens
+		regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,255}\b`),
+		// GitLab personal access, deploy, runner, and pipeline trigger tokens
+		regexp.MustCompile(`\b(?:glpat|gldt|glrt|glptt)-[A-Za-z0-9_-]{20,}`),
+		// Slack bot, user, app-level, and refresh tokens
+		regexp.MustCompile(`\bxox[abeoprs]-[A-Za-z0-9-]{10,}`),
+		// Slack incoming webhook URLs
+		regexp.MustCompile(`https://hooks\.slack\.com/services/[A-Za-z0-9_/]+`),
+		// Google API keys
+		regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`),
+	}
+	// secretFormatJWT matches JSON Web Tokens, whose header is checked to be JSON before matching them.
+	secretFormatJWT = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+)
+
+// SecretFormatDetector creates a Detector matching credentials with well-known formats regardless of where they are
+// found: AWS access key IDs, GitHub and GitLab tokens, Slack tokens and webhook URLs, JSON Web Tokens, and Google API
+// keys. Provide it to WithValueDetectors to redact values holding such credentials as defense in depth beyond field
+// and key names, or use it with RedactText, NewWriter, and other functions accepting detectors.
+func SecretFormatDetector() Detector {
+	return DetectorFunc(func(text string) [][]int {
+		var matches [][]int
+
+		for _, format := range secretFormats {
+			matches = append(matches, format.FindAllStringIndex(text, -1)...)
+		}
+
+		for _, match := range secretFormatJWT.FindAllStringIndex(text, -1) {
+			header, _, _ := strings.Cut(text[match[0]:match[1]], jwtSeparator)
+			if isJWTHeader(header) {
+				matches = append(matches, match)
+			}
+		}
+
+		return matches
+	})
+}
@@ -0,0 +1,18 @@
+package rere
+
+import "iter"
+
+// RedactSeq returns an iterator yielding a redacted deep copy of every value yielded by seq. Values are redacted
+// lazily as they are yielded, so seq is not collected into a slice first.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided. Each value is redacted
+// separately, so a Report provided with WithReport only holds the redactions of the latest value.
+func RedactSeq[T any](seq iter.Seq[T], opts ...Option) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range seq {
+			if !yield(redactValue(value, newOptions(allow, nil, opts))) {
+				return
+			}
+		}
+	}
+}
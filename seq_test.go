@@ -0,0 +1,46 @@
+package rere_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactSeq(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := []credentialDetails{
+		{Name: "primary", Secret: "secret"},
+		{Name: "replica", Secret: "secret"},
+		{Name: "backup", Secret: "secret"},
+	}
+
+	g.Expect(slices.Collect(rere.RedactSeq(slices.Values(input), rere.WithAllowList("name")))).To(gomega.Equal(
+		[]credentialDetails{
+			{Name: "primary", Secret: redacted},
+			{Name: "replica", Secret: redacted},
+			{Name: "backup", Secret: redacted},
+		}), "RedactSeq should yield redacted values")
+	g.Expect(input[0].Secret).To(gomega.Equal("secret"), "RedactSeq should not modify yielded values")
+
+	yielded := 0
+
+	for value := range rere.RedactSeq(func(yield func(credentialDetails) bool) {
+		for _, value := range input {
+			yielded++
+
+			if !yield(value) {
+				return
+			}
+		}
+	}) {
+		g.Expect(value.Secret).To(gomega.Equal(redacted), "RedactSeq should yield redacted values")
+
+		break
+	}
+
+	g.Expect(yielded).To(gomega.Equal(1), "RedactSeq should stop when the caller stops iterating")
+}
@@ -0,0 +1,195 @@
+package rere
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	sseFieldSeparator = ":"
+	sseDataField      = "data"
+)
+
+// WithTextDetectors sets detectors used to scrub free-form text that can't be redacted by field or key name, such as
+// the non-JSON data of server-sent events read through SSEReader. SecretFormatDetector, QueryStringDetector, and
+// URLCredentialsDetector are used when no detectors are provided.
+func WithTextDetectors(detectors ...Detector) Option {
+	return func(o *options) {
+		o.textDetectors = detectors
+	}
+}
+
+// freeTextDetectors returns the detectors provided to WithTextDetectors, or the default detectors when none are
+// provided, so free-form text is never passed through unscrubbed.
+func (o *options) freeTextDetectors() []Detector {
+	if len(o.textDetectors) == 0 {
+		return defaultTextDetectors()
+	}
+
+	return o.textDetectors
+}
+
+// SSEReader is an io.Reader scrubbing a server-sent events stream, such as a streamed LLM API response, so proxies and
+// debug taps can log it safely. Each event is made available as soon as the blank line ending it is read.
+//
+// The data lines of an event are joined and redacted together the same as RedactWebSocketText: data holding JSON is
+// redacted by key name with opts, and other data is scrubbed with detectors provided to WithTextDetectors, or with
+// credential detectors by default. Comments are scrubbed with the same detectors, and the event, id, and retry fields
+// are kept as-is. Lines end with "\n" after scrubbing. Fields longer than 64 KiB are replaced with a placeholder, so
+// memory use is bounded.
+type SSEReader struct {
+	reader        *bufio.Reader
+	opts          []Option
+	textDetectors []Detector
+	pending       []byte
+	err           error
+}
+
+// NewSSEReader creates an SSEReader scrubbing the server-sent events stream read from reader.
+//
+// Every string in JSON data is redacted unless WithAllowList or WithDenyList is provided.
+func NewSSEReader(reader io.Reader, opts ...Option) *SSEReader {
+	return &SSEReader{
+		reader:        bufio.NewReaderSize(reader, maxLineLength),
+		opts:          opts,
+		textDetectors: newOptions(allow, nil, opts).freeTextDetectors(),
+		pending:       nil,
+		err:           nil,
+	}
+}
+
+// Read reads scrubbed events into p.
+func (r *SSEReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		r.readEvent()
+	}
+
+	read := copy(p, r.pending)
+	r.pending = r.pending[read:]
+
+	return read, nil
+}
+
+// readEvent reads and scrubs the lines of the next event, up to and including the blank line ending it, into pending.
+func (r *SSEReader) readEvent() {
+	var (
+		event        bytes.Buffer
+		data         []string
+		dataIndex    = -1
+		oversized    bool
+		terminated   bool
+		readAnything bool
+	)
+
+	for {
+		line, lineOversized, err := r.readLine()
+		if err != nil {
+			r.err = err
+		}
+
+		if line == "" && err != nil {
+			break
+		}
+
+		readAnything = true
+
+		if line == "" {
+			terminated = true
+
+			break
+		}
+
+		field, value := cutSSEField(line)
+
+		switch {
+		case field == sseDataField:
+			if dataIndex == -1 {
+				dataIndex = event.Len()
+			}
+
+			data = append(data, value)
+			oversized = oversized || lineOversized
+		case lineOversized:
+			event.WriteString(field + sseFieldSeparator + " " + redactedMessage + "\n")
+		case field == "":
+			event.WriteString(RedactText(line, r.textDetectors...) + "\n")
+		default:
+			event.WriteString(line + "\n")
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	if !readAnything {
+		return
+	}
+
+	scrubbed := event.Bytes()
+
+	if dataIndex != -1 {
+		// data lines are written where the first data line was read
+		scrubbed = append(bytes.Clone(scrubbed[:dataIndex]), r.scrubData(data, oversized)...)
+		scrubbed = append(scrubbed, event.Bytes()[dataIndex:]...)
+	}
+
+	if terminated {
+		scrubbed = append(scrubbed, '\n')
+	}
+
+	r.pending = scrubbed
+}
+
+// readLine returns the next line without its line ending and whether it was longer than maxLineLength, in which case
+// only its first maxLineLength bytes are returned and the rest is discarded.
+func (r *SSEReader) readLine() (string, bool, error) {
+	line, err := r.reader.ReadSlice('\n')
+
+	content := strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r")
+	if !errors.Is(err, bufio.ErrBufferFull) {
+		return content, false, err
+	}
+
+	for errors.Is(err, bufio.ErrBufferFull) {
+		_, err = r.reader.ReadSlice('\n')
+	}
+
+	return content, true, err
+}
+
+// scrubData redacts the joined data lines of an event and returns them as data lines.
+func (r *SSEReader) scrubData(data []string, oversized bool) string {
+	joined := strings.Join(data, "\n")
+
+	if oversized {
+		joined = redactedMessage
+	} else if redacted, ok := redactJSONText([]byte(joined), r.opts); ok {
+		joined = string(redacted)
+	} else {
+		joined = RedactText(joined, r.textDetectors...)
+	}
+
+	var scrubbed strings.Builder
+
+	for _, line := range strings.Split(joined, "\n") {
+		scrubbed.WriteString(sseDataField + sseFieldSeparator + " " + line + "\n")
+	}
+
+	return scrubbed.String()
+}
+
+// cutSSEField returns the field name and value of an event line. The value of a line without a colon is empty and the
+// field name of a comment is empty.
+func cutSSEField(line string) (string, string) {
+	field, value, _ := strings.Cut(line, sseFieldSeparator)
+
+	return field, strings.TrimPrefix(value, " ")
+}
@@ -0,0 +1,41 @@
+package rere
+
+import (
+	"regexp"
+)
+
+//nolint:gochecknoglobals // regular expressions are compiled once
+var (
+	// stackFrame matches a function call line of a Go panic or goroutine dump, such as
+	// "main.(*server).handle(0xc0000a6000, {0x4c1f20, 0xc0000b4000})", capturing its arguments.
+	stackFrame = regexp.MustCompile(`(?m)^[^\s()]*\.\S*\(([^()\n]*)\)$`)
+	hexValue   = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+)
+
+// GoStackTraceDetector creates a Detector matching the hex values of function arguments in Go panic output and
+// goroutine dumps, since arguments printed by a panic sometimes include secrets. Frames, file paths, and line numbers
+// are not matched, so stack traces remain useful after redaction.
+//
+// GoStackTraceDetector works with RedactText on a whole stack trace as well as with Writer and Reader on individual
+// lines.
+func GoStackTraceDetector() Detector {
+	return DetectorFunc(func(text string) [][]int {
+		var matches [][]int
+
+		for _, frame := range stackFrame.FindAllStringSubmatchIndex(text, -1) {
+			argumentsStart, argumentsEnd := frame[2], frame[3]
+
+			for _, match := range hexValue.FindAllStringIndex(text[argumentsStart:argumentsEnd], -1) {
+				matches = append(matches, []int{argumentsStart + match[0], argumentsStart + match[1]})
+			}
+		}
+
+		return matches
+	})
+}
+
+// RedactStackTrace redacts function argument values in Go panic output and goroutine dumps with GoStackTraceDetector
+// and any matches of detectors, while preserving frames.
+func RedactStackTrace(stackTrace string, detectors ...Detector) string {
+	return RedactText(stackTrace, append([]Detector{GoStackTraceDetector()}, detectors...)...)
+}
@@ -0,0 +1,57 @@
+package rere_test
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+const panicOutput = `panic: connecting with password=hunter2
+
+goroutine 1 [running]:
+main.(*server).handle(0xc0000a6000, {0x4c1f20, 0xc0000b4000}, 0x5)
+	/app/server.go:42 +0x1d
+main.connect(...)
+	/app/db.go:12
+main.main()
+	/app/main.go:10 +0x25
+created by main.start in goroutine 1
+	/app/main.go:5 +0x3a
+exit status 2`
+
+func TestRedactStackTrace(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	redactedOutput := rere.RedactStackTrace(panicOutput, rere.RegexpDetector(regexp.MustCompile(`password=\S+`)))
+
+	g.Expect(redactedOutput).To(gomega.Equal(`panic: connecting with REDACTED
+
+goroutine 1 [running]:
+main.(*server).handle(REDACTED, {REDACTED, REDACTED}, REDACTED)
+	/app/server.go:42 +0x1d
+main.connect(...)
+	/app/db.go:12
+main.main()
+	/app/main.go:10 +0x25
+created by main.start in goroutine 1
+	/app/main.go:5 +0x3a
+exit status 2`), "RedactStackTrace should redact argument values and matched patterns while preserving frames")
+}
+
+func TestGoStackTraceDetectorWithReader(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	scrubbed, err := io.ReadAll(rere.NewReader(strings.NewReader(panicOutput), rere.GoStackTraceDetector()))
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "Reader should not error")
+	g.Expect(string(scrubbed)).To(gomega.ContainSubstring("main.(*server).handle(REDACTED, {REDACTED, REDACTED}, REDACTED)\n"),
+		"GoStackTraceDetector should redact argument values line by line")
+	g.Expect(string(scrubbed)).To(gomega.ContainSubstring("\t/app/server.go:42 +0x1d\n"),
+		"GoStackTraceDetector should preserve file lines")
+}
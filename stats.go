@@ -0,0 +1,146 @@
+package rere
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Stats aggregates redaction statistics across every redaction call it is provided to with WithStats, which helps
+// tune policies and spot rules that never fire. Stats is safe for concurrent use.
+type Stats struct {
+	mutex    sync.Mutex
+	visited  int
+	redacted int
+	sources  map[RuleSource]int
+	entries  map[string]int
+}
+
+// StatsSnapshot is a copy of the statistics aggregated by Stats.
+type StatsSnapshot struct {
+	// Visited is the number of values a redaction decision was made for.
+	Visited int
+	// Redacted is the number of values decided to be redacted.
+	Redacted int
+	// Sources is the number of values decided to be redacted by each rule source. The zero RuleSource counts values
+	// redacted because no rule applied to them.
+	Sources map[RuleSource]int
+	// Entries is the number of values each allow list, deny list, and path entry applied to, including entries that
+	// never applied.
+	Entries map[string]int
+}
+
+// WithStats aggregates redaction statistics into stats. The same Stats can be provided to many redaction calls, such
+// as through a Policy, to aggregate statistics over time.
+func WithStats(stats *Stats) Option {
+	return func(o *options) {
+		o.stats = stats
+	}
+}
+
+// Snapshot returns a copy of the statistics aggregated so far.
+func (s *Stats) Snapshot() StatsSnapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := StatsSnapshot{
+		Visited:  s.visited,
+		Redacted: s.redacted,
+		Sources:  maps.Clone(s.sources),
+		Entries:  maps.Clone(s.entries),
+	}
+
+	if snapshot.Sources == nil {
+		snapshot.Sources = map[RuleSource]int{}
+	}
+
+	if snapshot.Entries == nil {
+		snapshot.Entries = map[string]int{}
+	}
+
+	return snapshot
+}
+
+// UnusedEntries returns the sorted allow list, deny list, and path entries that never applied to a value.
+func (s *Stats) UnusedEntries() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var unused []string
+
+	for entry, count := range s.entries {
+		if count == 0 {
+			unused = append(unused, entry)
+		}
+	}
+
+	slices.Sort(unused)
+
+	return unused
+}
+
+// registerEntries records the entries of redactOptions, so entries that never apply are reported.
+func (s *Stats) registerEntries(redactOptions *options) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.entries == nil {
+		s.entries = map[string]int{}
+	}
+
+	for _, entry := range redactOptions.fieldKeyNameList {
+		s.entries[entry] += 0
+	}
+
+	for _, entry := range redactOptions.overrideDenyList {
+		s.entries[entry] += 0
+	}
+
+	for _, ruleSet := range redactOptions.pathRules {
+		for _, rule := range ruleSet.rules {
+			s.entries[rule.raw] += 0
+		}
+	}
+}
+
+func (s *Stats) record(redact bool, source RuleSource, entry string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.visited++
+
+	if redact {
+		s.redacted++
+
+		if s.sources == nil {
+			s.sources = map[RuleSource]int{}
+		}
+
+		s.sources[source]++
+	}
+
+	if entry != "" {
+		s.entries[entry]++
+	}
+}
+
+// recordDecision records the decision to redact or keep the value at valueLocation by source into stats.
+func (o *options) recordDecision(valueLocation location, redact bool, source RuleSource) {
+	if o.stats == nil {
+		return
+	}
+
+	entry := ""
+
+	//nolint:exhaustive // only list and path entries are counted
+	switch source {
+	case NameRule:
+		entry, _, _ = o.findNameEntry(valueLocation)
+	case PathRule:
+		if rule, matched := o.matchPathRule(valueLocation.path); matched {
+			entry = rule.raw
+		}
+	}
+
+	o.stats.record(redact, source, entry)
+}
@@ -0,0 +1,83 @@
+package rere_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type statsUser struct {
+	Name     string
+	Email    string
+	Password string `rere:"redact"`
+	Age      int
+}
+
+func TestWithStats(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var stats rere.Stats
+
+	policy := rere.NewAllowListPolicy([]string{"name", "phone"}, rere.WithStats(&stats),
+		rere.WithKeepPaths("Users[0].Email"))
+
+	var waitGroup sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			policy.Redact(map[string][]statsUser{
+				"Users": {
+					{Name: "alice", Email: "alice@example.com", Password: "password", Age: 1},
+					{Name: "bob", Email: "bob@example.com", Password: "", Age: 2},
+				},
+			})
+		}()
+	}
+
+	waitGroup.Wait()
+
+	g.Expect(stats.Snapshot()).To(gomega.Equal(rere.StatsSnapshot{
+		Visited:  50,
+		Redacted: 20,
+		Sources: map[rere.RuleSource]int{
+			0:            10,
+			rere.TagRule: 10,
+		},
+		Entries: map[string]int{
+			"name":           20,
+			"phone":          0,
+			"Users[0].Email": 10,
+		},
+	}), "Stats should aggregate statistics across calls")
+	g.Expect(stats.UnusedEntries()).To(gomega.Equal([]string{"phone"}), "Stats should report entries that never applied")
+}
+
+func TestWithStatsZeroedValues(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var stats rere.Stats
+
+	rere.RedactWithDenyList(statsUser{Name: "alice", Email: "", Password: "", Age: 42}, []string{"age"},
+		rere.WithStats(&stats))
+
+	g.Expect(stats.Snapshot()).To(gomega.Equal(rere.StatsSnapshot{
+		Visited:  2,
+		Redacted: 1,
+		Sources:  map[rere.RuleSource]int{rere.NameRule: 1},
+		Entries:  map[string]int{"age": 1},
+	}), "Stats should count zeroed values")
+	g.Expect(new(rere.Stats).Snapshot()).To(gomega.Equal(rere.StatsSnapshot{
+		Visited:  0,
+		Redacted: 0,
+		Sources:  map[rere.RuleSource]int{},
+		Entries:  map[string]int{},
+	}), "an empty Stats should have an empty snapshot")
+}
@@ -0,0 +1,100 @@
+package rere
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StringerBoundary configures how values implementing fmt.Stringer are handled by WithStringerBoundary.
+type StringerBoundary int
+
+const (
+	// KeepStringers leaves values implementing fmt.Stringer intact, regardless of the allow or deny list.
+	KeepStringers StringerBoundary = iota + 1
+	// RedactStringers redacts values implementing fmt.Stringer wholesale when their field or key name would be
+	// redacted. String and byte slice values are replaced with a placeholder and all other values are set to their
+	// zero value.
+	RedactStringers
+	// DetectStringers calls String on values implementing fmt.Stringer and redacts them wholesale when detectors
+	// find sensitive text in the output, regardless of the allow or deny list. Values without sensitive output are
+	// left intact. Use WithStringerDetection to configure detectors.
+	DetectStringers
+)
+
+//nolint:gochecknoglobals // reflect.Type values can't be constants
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// WithStringerBoundary stops recursing into values implementing fmt.Stringer and treats them as leaves, since many
+// value types such as IDs and versions are semantically atomic and reflecting inside them damages them.
+func WithStringerBoundary(boundary StringerBoundary) Option {
+	return func(o *options) {
+		o.stringerBoundary = boundary
+	}
+}
+
+// WithStringerDetection stops recursing into values implementing fmt.Stringer and redacts them wholesale when detectors
+// find sensitive text in the output of String. This catches types whose String method leaks their contents.
+func WithStringerDetection(detectors ...Detector) Option {
+	return func(o *options) {
+		o.stringerBoundary = DetectStringers
+		o.stringerDetectors = detectors
+	}
+}
+
+func isStringer(value reflect.Value) bool {
+	if !value.IsValid() {
+		return false
+	}
+
+	return value.Type().Implements(stringerType) || reflect.PointerTo(value.Type()).Implements(stringerType)
+}
+
+func stringerOf(value reflect.Value) fmt.Stringer {
+	if value.Type().Implements(stringerType) {
+		//nolint:forcetypeassert // the type is checked to implement fmt.Stringer
+		return value.Interface().(fmt.Stringer)
+	}
+
+	//nolint:forcetypeassert // isStringer checks the pointer type implements fmt.Stringer
+	return value.Addr().Interface().(fmt.Stringer)
+}
+
+func (o *options) redactStringer(valueLocation location, value reflect.Value) {
+	if value.IsZero() {
+		return
+	}
+
+	//nolint:exhaustive // KeepStringers leaves the value intact
+	switch o.stringerBoundary {
+	case RedactStringers:
+		if o.shouldRedact(valueLocation) {
+			o.redactWholesale(valueLocation, value, []byte(stringerOf(value).String()))
+		}
+	case DetectStringers:
+		if output := stringerOf(value).String(); len(detect(output, o.stringerDetectors)) != 0 {
+			o.redactWholesale(valueLocation, value, []byte(output))
+		}
+	}
+}
+
+// redactWholesale replaces a string or byte slice value with a placeholder and sets every other value to its zero
+// value.
+func (o *options) redactWholesale(valueLocation location, value reflect.Value, original []byte) {
+	//nolint:exhaustive // every other kind is set to its zero value
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(o.placeholder(valueLocation, stringKind, value))
+	case reflect.Array, reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			setBytes(value, o.placeholderBytes(o.placeholder(valueLocation, bytesKind, value)))
+
+			break
+		}
+
+		value.SetZero()
+	default:
+		value.SetZero()
+	}
+
+	o.recordRedaction(valueLocation.path, original, value.Interface())
+}
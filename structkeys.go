@@ -0,0 +1,118 @@
+package rere
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var errStructMapKeys = errors.New("maps are keyed by structs holding strings or byte slices")
+
+// WithRedactStructMapKeys redacts the string and []byte fields of struct map keys, such as the keys of a
+// map[Credential]int, the same as the fields of a struct found at the map's location, and replaces each key with its
+// redacted copy. Without WithRedactStructMapKeys, keys are kept as-is, since they are usually names rather than values.
+// Entries whose keys become equal once redacted are merged into a single entry, keeping the value of one of them.
+//
+// CheckStructMapKeys finds maps keyed by structs holding strings or byte slices, so they can be refused instead.
+func WithRedactStructMapKeys() Option {
+	return func(o *options) {
+		o.redactStructMapKeys = true
+	}
+}
+
+// CheckStructMapKeys returns an error listing the paths of maps in T keyed by structs holding strings or byte slices,
+// such as "Logins", which keep their keys as-is unless WithRedactStructMapKeys is provided. CheckStructMapKeys is
+// intended for a unit test refusing types whose map keys might carry secrets:
+//
+//	func TestUserHasNoStructMapKeys(t *testing.T) {
+//		if err := rere.CheckStructMapKeys[User](); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+//
+// Values held by interfaces are only known at runtime, so maps found through interfaces are not checked.
+func CheckStructMapKeys[T any]() error {
+	var found []string
+
+	findStructMapKeys(reflect.TypeFor[T](), nil, nil, &found)
+
+	if len(found) != 0 {
+		return fmt.Errorf("%w: %s", errStructMapKeys, strings.Join(found, ", "))
+	}
+
+	return nil
+}
+
+// findStructMapKeys appends the paths of maps keyed by structs holding strings or byte slices in valueType to found.
+//
+//nolint:exhaustive // every other kind can't hold a map
+func findStructMapKeys(valueType reflect.Type, valuePath path, walking []reflect.Type, found *[]string) {
+	switch valueType.Kind() {
+	case reflect.Pointer:
+		findStructMapKeys(valueType.Elem(), valuePath, walking, found)
+	case reflect.Array, reflect.Slice:
+		findStructMapKeys(valueType.Elem(), valuePath.anyIndex(), walking, found)
+	case reflect.Map:
+		if valueType.Key().Kind() == reflect.Struct && holdsStrings(valueType.Key(), nil) {
+			*found = append(*found, valuePath.String())
+		}
+
+		findStructMapKeys(valueType.Elem(), valuePath.anyIndex(), walking, found)
+	case reflect.Struct:
+		if slices.Contains(walking, valueType) {
+			return
+		}
+
+		walking = append(walking, valueType)
+
+		for fieldIndex := 0; fieldIndex < valueType.NumField(); fieldIndex++ {
+			structField := valueType.Field(fieldIndex)
+
+			findStructMapKeys(structField.Type, valuePath.field(structField.Name), walking, found)
+		}
+	}
+}
+
+// holdsStrings reports whether values of valueType can hold strings or byte slices, such as the fields of a struct.
+//
+//nolint:exhaustive // every other kind can't hold strings
+func holdsStrings(valueType reflect.Type, walking []reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.String, reflect.Interface:
+		return true
+	case reflect.Array, reflect.Slice, reflect.Pointer:
+		return valueType.Elem().Kind() == reflect.Uint8 || holdsStrings(valueType.Elem(), walking)
+	case reflect.Struct:
+		if slices.Contains(walking, valueType) {
+			return false
+		}
+
+		walking = append(walking, valueType)
+
+		for fieldIndex := 0; fieldIndex < valueType.NumField(); fieldIndex++ {
+			if holdsStrings(valueType.Field(fieldIndex).Type, walking) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// redactMapKeys replaces mapValue with a map holding the same values keyed by redacted copies of its struct keys.
+func (o *options) redactMapKeys(valueLocation location, mapValue reflect.Value) {
+	redactedMap := reflect.MakeMapWithSize(mapValue.Type(), mapValue.Len())
+	redactedKey := reflect.New(mapValue.Type().Key())
+
+	for _, key := range o.mapKeys(mapValue) {
+		redactedKey.Elem().Set(key)
+
+		redact(valueLocation, redactedKey, o)
+
+		redactedMap.SetMapIndex(redactedKey.Elem(), mapValue.MapIndex(key))
+	}
+
+	mapValue.Set(redactedMap)
+}
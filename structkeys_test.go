@@ -0,0 +1,112 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type loginCredential struct {
+	Username string
+	Password string
+}
+
+type loginAttempts struct {
+	Attempts map[loginCredential]int
+}
+
+type loginHistory struct {
+	Users   []loginAttempts
+	Regions map[string]loginAttempts
+	Counts  map[struct{ ID int }]int
+}
+
+func TestWithRedactStructMapKeys(t *testing.T) {
+	t.Parallel()
+
+	input := loginAttempts{
+		Attempts: map[loginCredential]int{
+			{Username: "alice", Password: "hunter2"}: 3,
+			{Username: "bob", Password: "letmein"}:   1,
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		redact func() loginAttempts
+		output loginAttempts
+	}{
+		{
+			name: "keeps struct map keys by default",
+			redact: func() loginAttempts {
+				return rere.RedactWithAllowList(input, []string{"Username"})
+			},
+			output: input,
+		},
+		{
+			name: "redacts struct map keys with an allow list",
+			redact: func() loginAttempts {
+				return rere.RedactWithAllowList(input, []string{"Username"}, rere.WithRedactStructMapKeys())
+			},
+			output: loginAttempts{
+				Attempts: map[loginCredential]int{
+					{Username: "alice", Password: redacted}: 3,
+					{Username: "bob", Password: redacted}:   1,
+				},
+			},
+		},
+		{
+			name: "redacts struct map keys with a deny list",
+			redact: func() loginAttempts {
+				return rere.RedactWithDenyList(input, []string{"Password"}, rere.WithRedactStructMapKeys())
+			},
+			output: loginAttempts{
+				Attempts: map[loginCredential]int{
+					{Username: "alice", Password: redacted}: 3,
+					{Username: "bob", Password: redacted}:   1,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact()).To(gomega.Equal(testCase.output), "struct map keys should be redacted when configured")
+			g.Expect(input.Attempts).To(gomega.HaveKey(loginCredential{Username: "alice", Password: "hunter2"}),
+				"the provided input should not be modified")
+		})
+	}
+}
+
+func TestWithRedactStructMapKeysMergesEqualKeys(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := map[loginCredential]int{
+		{Username: "alice", Password: "hunter2"}: 3,
+		{Username: "bob", Password: "letmein"}:   3,
+	}
+
+	g.Expect(rere.RedactWithAllowList(input, nil, rere.WithRedactStructMapKeys())).To(
+		gomega.Equal(map[loginCredential]int{{Username: redacted, Password: redacted}: 3}),
+		"entries whose keys become equal once redacted should be merged")
+}
+
+func TestCheckStructMapKeys(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(rere.CheckStructMapKeys[loginHistory]()).To(
+		gomega.MatchError("maps are keyed by structs holding strings or byte slices: Users[*].Attempts, Regions[*].Attempts"),
+		"CheckStructMapKeys should list maps keyed by structs holding strings")
+	g.Expect(rere.CheckStructMapKeys[credentialDetails]()).To(gomega.Succeed(),
+		"CheckStructMapKeys should succeed for types without maps keyed by structs holding strings")
+	g.Expect(rere.CheckStructMapKeys[map[string]int]()).To(gomega.Succeed(),
+		"CheckStructMapKeys should succeed for maps keyed by strings")
+}
@@ -0,0 +1,66 @@
+package rere
+
+import (
+	"reflect"
+	"slices"
+)
+
+// SuggestAllowList returns the sorted field names of every string and []byte field T can hold, including fields of
+// nested structs, pointers, slices, and arrays. Starting from "everything allowed" and deleting entries, or diffing the
+// suggestion against a current allow list when T changes, keeps allow lists in sync with evolving structs.
+//
+// Values held by maps and interfaces aren't known from T, so their fields aren't suggested.
+func SuggestAllowList[T any]() []string {
+	var names []string
+
+	walkType(rootLocation, reflect.TypeFor[T](), newOptions(allow, nil, nil), nil, func(leafLocation location) {
+		if leafLocation.name != "" && !slices.Contains(names, leafLocation.name) {
+			names = append(names, leafLocation.name)
+		}
+	})
+
+	slices.Sort(names)
+
+	return names
+}
+
+// walkType calls visit with the location of every string and []byte value a value of valueType can hold at a location
+// known from valueType, such as struct fields and slice elements. Slice and array elements are at any index. Struct
+// types already being walked are skipped, so recursive types are walked once.
+//
+//nolint:exhaustive // other kinds can't hold strings or byte slices at known locations
+func walkType(
+	valueLocation location, valueType reflect.Type, redactOptions *options, walking []reflect.Type, visit func(location),
+) {
+	valueLocation = valueLocation.enterType(valueType, redactOptions)
+
+	switch valueType.Kind() {
+	case reflect.Pointer:
+		walkType(valueLocation, valueType.Elem(), redactOptions, walking, visit)
+	case reflect.Array, reflect.Slice:
+		if valueType.Elem().Kind() == reflect.Uint8 {
+			visit(valueLocation)
+
+			return
+		}
+
+		walkType(valueLocation.anyIndex(), valueType.Elem(), redactOptions, walking, visit)
+	case reflect.String:
+		visit(valueLocation)
+	case reflect.Struct:
+		if slices.Contains(walking, valueType) {
+			return
+		}
+
+		walking = append(walking, valueType)
+
+		for fieldIndex := 0; fieldIndex < valueType.NumField(); fieldIndex++ {
+			structField := valueType.Field(fieldIndex)
+			if !redactOptions.visitsField(structField) {
+				continue
+			}
+
+			walkType(valueLocation.field(valueType, structField), structField.Type, redactOptions, walking, visit)
+		}
+	}
+}
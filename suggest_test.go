@@ -0,0 +1,45 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type suggestedNode struct {
+	Label    string
+	Children []*suggestedNode
+}
+
+type suggestedUser struct {
+	Username    string
+	Age         int
+	PrivateKey  []byte
+	Emails      []string
+	Credentials *credentialDetails
+	Tree        suggestedNode
+	Labels      map[string]string
+	Extra       any
+	nickname    string //nolint:unused // unexported fields are suggested too
+}
+
+func TestSuggestAllowList(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(rere.SuggestAllowList[suggestedUser]()).To(gomega.Equal([]string{
+		"Emails",
+		"Label",
+		"Name",
+		"PrivateKey",
+		"Secret",
+		"Username",
+		"nickname",
+	}), "SuggestAllowList should return every string and []byte field name")
+
+	g.Expect(rere.SuggestAllowList[[]credentialDetails]()).To(gomega.Equal([]string{"Name", "Secret"}),
+		"SuggestAllowList should walk slice elements")
+	g.Expect(rere.SuggestAllowList[string]()).To(gomega.BeEmpty(),
+		"SuggestAllowList should not suggest values without a field name")
+}
@@ -0,0 +1,84 @@
+package rere
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+//nolint:gochecknoglobals // types can't be constants
+var syncMapType = reflect.TypeFor[sync.Map]()
+
+// WithSyncMapContents copies the entries of sync.Map values into the redacted copy and redacts their values the same
+// as the values of a map, such as a sync.Map holding session tokens keyed by user ID. Entries are copied with Range, so
+// entries stored concurrently might be missed. sync.Map values that aren't addressable, such as values stored directly
+// in a map, are still replaced with an empty sync.Map, since ranging over them requires copying them.
+//
+// Without WithSyncMapContents, sync.Map values are replaced with an empty sync.Map in the redacted copy, since copying
+// their internal state is unsafe and their entries would otherwise be carried over without being redacted.
+func WithSyncMapContents() Option {
+	return func(o *options) {
+		o.syncMapContents = true
+	}
+}
+
+// copySyncMap returns a new sync.Map holding deep copies of the entries of original when configured by
+// WithSyncMapContents, otherwise an empty sync.Map.
+func copySyncMap(original reflect.Value, redactOptions *options) reflect.Value {
+	deepCopy := reflect.New(syncMapType)
+
+	if !redactOptions.syncMapContents {
+		return deepCopy.Elem()
+	}
+
+	// sync.Map values that aren't addressable, such as values stored directly in a map, can't be ranged over without
+	// copying their internal state, so they are replaced with an empty sync.Map the same as without
+	// WithSyncMapContents
+	if !original.CanAddr() {
+		return deepCopy.Elem()
+	}
+
+	//nolint:forcetypeassert // the types are checked by the caller
+	copiedMap := deepCopy.Interface().(*sync.Map)
+
+	//nolint:forcetypeassert // the types are checked by the caller
+	original.Addr().Interface().(*sync.Map).Range(func(key, value any) bool {
+		copiedMap.Store(
+			deepCopyValue(reflect.ValueOf(&key).Elem(), redactOptions).Interface(),
+			deepCopyValue(reflect.ValueOf(&value).Elem(), redactOptions).Interface(),
+		)
+
+		return true
+	})
+
+	return deepCopy.Elem()
+}
+
+// redactSyncMap redacts the values of a sync.Map in the redacted copy, the same as the values of a map.
+func (o *options) redactSyncMap(valueLocation location, syncMap reflect.Value) {
+	//nolint:forcetypeassert // the types are checked by the caller
+	redactedMap := syncMap.Addr().Interface().(*sync.Map)
+
+	var keys []reflect.Value
+
+	redactedMap.Range(func(key, _ any) bool {
+		keys = append(keys, reflect.ValueOf(key))
+
+		return true
+	})
+
+	if o.recording() {
+		slices.SortFunc(keys, compareMapKeys)
+	}
+
+	for _, key := range keys {
+		value, _ := redactedMap.Load(key.Interface())
+
+		redactedValue := reflect.New(reflect.TypeFor[any]())
+		redactedValue.Elem().Set(reflect.ValueOf(&value).Elem())
+
+		redact(valueLocation.key(key), redactedValue, o)
+
+		redactedMap.Store(key.Interface(), redactedValue.Elem().Interface())
+	}
+}
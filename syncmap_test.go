@@ -0,0 +1,104 @@
+package rere_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type sessionStore struct {
+	Name     string
+	Sessions sync.Map
+	Users    *sync.Map
+}
+
+// syncMapEntries returns the entries of syncMap as a map, so they can be compared.
+func syncMapEntries(syncMap *sync.Map) map[any]any {
+	entries := map[any]any{}
+
+	syncMap.Range(func(key, value any) bool {
+		entries[key] = value
+
+		return true
+	})
+
+	return entries
+}
+
+func newSessionStore() *sessionStore {
+	store := &sessionStore{Name: "sessions", Sessions: sync.Map{}, Users: &sync.Map{}}
+
+	store.Sessions.Store("alice", "session-token")
+	store.Sessions.Store(42, credentialDetails{Name: "bob", Secret: "hunter2"})
+	store.Users.Store("carol", []byte("password"))
+
+	return store
+}
+
+func TestRedactSyncMaps(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := newSessionStore()
+
+	redactedStore := rere.RedactWithAllowList(input, []string{"Name"})
+
+	g.Expect(redactedStore.Name).To(gomega.Equal("sessions"), "RedactWithAllowList should keep allowed fields")
+	g.Expect(syncMapEntries(&redactedStore.Sessions)).To(gomega.BeEmpty(),
+		"RedactWithAllowList should replace sync.Map values with an empty sync.Map by default")
+	g.Expect(syncMapEntries(redactedStore.Users)).To(gomega.BeEmpty(),
+		"RedactWithAllowList should replace sync.Map pointers with an empty sync.Map by default")
+	g.Expect(syncMapEntries(&input.Sessions)).To(gomega.HaveLen(2), "RedactWithAllowList should not modify the provided input")
+}
+
+func TestRedactWithSyncMapContents(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		redact   func(input *sessionStore) *sessionStore
+		sessions map[any]any
+		users    map[any]any
+	}{
+		{
+			name: "redacts sync.Map values with an allow list",
+			redact: func(input *sessionStore) *sessionStore {
+				return rere.RedactWithAllowList(input, []string{"Name"}, rere.WithSyncMapContents())
+			},
+			sessions: map[any]any{"alice": redacted, 42: credentialDetails{Name: "bob", Secret: redacted}},
+			users:    map[any]any{"carol": []byte(redacted)},
+		},
+		{
+			name: "redacts sync.Map values by key with a deny list",
+			redact: func(input *sessionStore) *sessionStore {
+				return rere.RedactWithDenyList(input, []string{"alice", "Secret"}, rere.WithSyncMapContents())
+			},
+			sessions: map[any]any{"alice": redacted, 42: credentialDetails{Name: "bob", Secret: redacted}},
+			users:    map[any]any{"carol": []byte("password")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			input := newSessionStore()
+
+			redactedStore := testCase.redact(input)
+
+			g.Expect(syncMapEntries(&redactedStore.Sessions)).To(gomega.Equal(testCase.sessions),
+				"sync.Map values should be redacted")
+			g.Expect(syncMapEntries(redactedStore.Users)).To(gomega.Equal(testCase.users),
+				"sync.Map pointers should be redacted")
+			g.Expect(redactedStore.Users).NotTo(gomega.BeIdenticalTo(input.Users), "sync.Map pointers should be copied")
+			g.Expect(syncMapEntries(&input.Sessions)).To(gomega.Equal(map[any]any{
+				"alice": "session-token",
+				42:      credentialDetails{Name: "bob", Secret: "hunter2"},
+			}), "the provided input should not be modified")
+		})
+	}
+}
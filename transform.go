@@ -0,0 +1,125 @@
+package rere
+
+import (
+	"encoding/hex"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Transform transforms a string value that is not redacted, such as truncating or normalizing it.
+type Transform func(value string) string
+
+type fieldTransform struct {
+	stage         Transform
+	fieldKeyNames []string
+	// hashes is true when stage is returned by Hash, so values are hashed with the correlation key of the call.
+	hashes bool
+}
+
+// WithTransform applies stage to every non-empty string value that is not redacted and is found in a field or key
+// named in fieldKeyNames. If no fieldKeyNames are provided, stage is applied to every non-empty string value that is
+// not redacted. fieldKeyNames are matched the same as allow and deny list entries.
+//
+// WithTransform may be provided multiple times and stages are applied in the order they are provided during the same
+// traversal as redaction.
+func WithTransform(stage Transform, fieldKeyNames ...string) Option {
+	return func(o *options) {
+		o.transforms = append(o.transforms, fieldTransform{
+			stage:         stage,
+			fieldKeyNames: fieldKeyNames,
+			hashes:        stage != nil && reflect.ValueOf(stage).Pointer() == reflect.ValueOf(hashTransform).Pointer(),
+		})
+	}
+}
+
+// Truncate creates a Transform truncating values to at most maxLength bytes without splitting a UTF-8 encoded rune.
+// A maxLength of 0 or less truncates values to an empty string.
+func Truncate(maxLength int) Transform {
+	maxLength = max(maxLength, 0)
+
+	return func(value string) string {
+		if len(value) <= maxLength {
+			return value
+		}
+
+		end := maxLength
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+
+		return value[:end]
+	}
+}
+
+// WithMaxStringLength truncates non-redacted string values longer than maxLength bytes and appends a marker with the
+// number of bytes removed, such as "…(+1024 bytes)". Truncation is applied in the same traversal as redaction after
+// any stages provided to WithTransform before it.
+func WithMaxStringLength(maxLength int) Option {
+	truncate := Truncate(maxLength)
+
+	return WithTransform(func(value string) string {
+		truncated := truncate(value)
+		if len(truncated) == len(value) {
+			return value
+		}
+
+		return truncated + "…(+" + strconv.Itoa(len(value)-len(truncated)) + " bytes)"
+	})
+}
+
+// NormalizeWhitespace creates a Transform trimming leading and trailing whitespace and replacing every other run of
+// whitespace with a single space.
+func NormalizeWhitespace() Transform {
+	return func(value string) string {
+		return strings.Join(strings.Fields(value), " ")
+	}
+}
+
+// Hash creates a Transform replacing values with their hex encoded HMAC-SHA256, so equal values can be correlated
+// without logging them. Values are hashed with the correlation key, which is random per process unless
+// WithCorrelationKey or WithCorrelationKeyring is provided, since an unkeyed hash of guessable values such as PINs and
+// email addresses is reversed by hashing every candidate. Hashed values must still be treated as sensitive when the
+// correlation key is shared with whoever reads them.
+func Hash() Transform {
+	return hashTransform
+}
+
+// hashTransform is the Transform returned by Hash. WithTransform recognizes it, so values are hashed with the
+// correlation key of each call instead of the key of the process.
+func hashTransform(value string) string {
+	return hashString(processCorrelationKey, value)
+}
+
+// hashString returns the hex encoded HMAC-SHA256 of value with key.
+func hashString(key []byte, value string) string {
+	return hex.EncodeToString(keyedHash(key, []byte(value)))
+}
+
+func (o *options) transform(valueLocation location, value reflect.Value) {
+	if len(o.transforms) == 0 {
+		return
+	}
+
+	value.SetString(o.transformString(valueLocation, value.String()))
+}
+
+func (o *options) transformString(valueLocation location, value string) string {
+	for _, transform := range o.transforms {
+		matched := len(transform.fieldKeyNames) == 0 || slices.ContainsFunc(transform.fieldKeyNames, func(entry string) bool {
+			return o.matchesEntry(entry, valueLocation)
+		})
+
+		switch {
+		case !matched:
+		case transform.hashes:
+			value = hashString(o.correlationKey, value)
+		default:
+			value = transform.stage(value)
+		}
+	}
+
+	return value
+}
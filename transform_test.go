@@ -0,0 +1,109 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type logEntry struct {
+	Message string
+	Body    string
+	Token   string
+}
+
+func TestRedactWithTransform(t *testing.T) {
+	t.Parallel()
+
+	input := logEntry{
+		Message: "  request\t failed \n ",
+		Body:    "héllo world",
+		Token:   "token",
+	}
+
+	testCases := []struct {
+		name   string
+		opts   []rere.Option
+		output logEntry
+	}{
+		{
+			name: "applies stages to values that are not redacted",
+			opts: []rere.Option{rere.WithTransform(rere.NormalizeWhitespace())},
+			output: logEntry{
+				Message: "request failed",
+				Body:    "héllo world",
+				Token:   redacted,
+			},
+		},
+		{
+			name: "applies stages to matched fields in order",
+			opts: []rere.Option{
+				rere.WithTransform(rere.Truncate(3), "body"),
+				rere.WithTransform(rere.Hash(), "body"),
+				rere.WithCorrelationKey([]byte("correlation-key")),
+			},
+			output: logEntry{
+				Message: "  request\t failed \n ",
+				// HMAC-SHA256 of "hé" with the correlation key
+				Body:  "172303c282ef51cec5e6cc2a4fc32a0f9851198b0151f9e276442b46e6b69167",
+				Token: redacted,
+			},
+		},
+		{
+			name: "truncates to an empty string with a negative length",
+			opts: []rere.Option{rere.WithTransform(rere.Truncate(-1), "body")},
+			output: logEntry{
+				Message: "  request\t failed \n ",
+				Body:    "",
+				Token:   redacted,
+			},
+		},
+		{
+			name: "truncates without splitting runes",
+			opts: []rere.Option{rere.WithTransform(rere.Truncate(2), "body")},
+			output: logEntry{
+				Message: "  request\t failed \n ",
+				Body:    "h",
+				Token:   redacted,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			redacted := rere.RedactWithAllowList(input, []string{"message", "body"}, testCase.opts...)
+
+			g.Expect(redacted).To(gomega.Equal(testCase.output), "RedactWithAllowList should apply transforms")
+		})
+	}
+}
+
+func TestRedactWithMaxStringLength(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	input := logEntry{
+		Message: "short",
+		Body:    "a very long body",
+		Token:   "a very long token",
+	}
+
+	redactedInput := rere.RedactWithAllowList(input, []string{"message", "body"}, rere.WithMaxStringLength(6))
+
+	g.Expect(redactedInput).To(gomega.Equal(logEntry{
+		Message: "short",
+		Body:    "a very…(+10 bytes)",
+		Token:   redacted,
+	}), "WithMaxStringLength should truncate long values that are not redacted")
+
+	g.Expect(rere.RedactWithAllowList(input, []string{"message"}, rere.WithMaxStringLength(-1))).To(gomega.Equal(logEntry{
+		Message: "…(+5 bytes)",
+		Body:    redacted,
+		Token:   redacted,
+	}), "WithMaxStringLength should drop every byte with a negative length")
+}
@@ -0,0 +1,146 @@
+package rere
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	errUnknownEnvelopeKey       = errors.New("envelope key ID is not in keyring")
+	errUnsupportedEnvelope      = errors.New("envelope algorithm is not supported")
+	errInvalidEnvelopeData      = errors.New("envelope data is invalid")
+	errUnexpectedTrailingValues = errors.New("unexpected data after JSON value")
+)
+
+// Unredact restores the values encrypted by WithEncryptionEnvelope in data, JSON produced by MarshalJSON, but only at
+// paths, leaving every other Envelope in place. Break-glass tooling rarely needs a whole record, so authorized callers
+// restore only the fields they need, such as Unredact(event, keyring, "Customer.Email"). Paths use the same form as
+// WithRedactPaths, including "[*]" for every index, and restore every Envelope nested inside of them.
+//
+// Envelopes are decrypted with the key of their key ID, so keyring must hold every key used since values were
+// encrypted. Unredact returns an error when a path is invalid, data isn't JSON, or an Envelope at paths can't be
+// decrypted. Keys of the returned JSON are sorted.
+func Unredact(data []byte, keyring *Keyring, paths ...string) ([]byte, error) {
+	rules, err := parsePathRules(paths, false)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := unredactValue(nil, decoded, keyring, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(restored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal unredacted value: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// unredactValue returns value at valuePath with every Envelope at a path matching rules replaced with its original
+// value.
+func unredactValue(valuePath path, value any, keyring *Keyring, rules *pathRuleSet) (any, error) {
+	switch typedValue := value.(type) {
+	case []any:
+		for index, element := range typedValue {
+			restored, err := unredactValue(valuePath.index(index), element, keyring, rules)
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[index] = restored
+		}
+	case map[string]any:
+		if envelope, isEnvelope := asEnvelope(typedValue); isEnvelope {
+			var best pathMatch
+
+			rules.trie.match(valuePath, 0, &best)
+
+			if best.rule == nil {
+				return value, nil
+			}
+
+			original, err := keyring.openEnvelope(envelope)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unredact %s: %w", valuePath, err)
+			}
+
+			return original, nil
+		}
+
+		for key, element := range typedValue {
+			restored, err := unredactValue(valuePath.field(key), element, keyring, rules)
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[key] = restored
+		}
+	}
+
+	return value, nil
+}
+
+// asEnvelope returns the Envelope held by object and whether object is of the form {"$rere": {...}}.
+func asEnvelope(object map[string]any) (Envelope, bool) {
+	inner, found := object[envelopeField]
+	if !found || len(object) != 1 {
+		return Envelope{Algorithm: "", KeyID: "", Data: "", Kind: ""}, false
+	}
+
+	fields, isObject := inner.(map[string]any)
+	if !isObject {
+		return Envelope{Algorithm: "", KeyID: "", Data: "", Kind: ""}, false
+	}
+
+	field := func(name string) string {
+		text, _ := fields[name].(string)
+
+		return text
+	}
+
+	return Envelope{Algorithm: field("alg"), KeyID: field("kid"), Data: field("data"), Kind: field("kind")}, true
+}
+
+// openEnvelope returns the original value of envelope, as base64 for byte slices, the same as it would have been
+// marshaled to JSON.
+func (k *Keyring) openEnvelope(envelope Envelope) (string, error) {
+	if envelope.Algorithm != envelopeAlgorithm {
+		return "", fmt.Errorf("%w: %q", errUnsupportedEnvelope, envelope.Algorithm)
+	}
+
+	key, found := k.Key(envelope.KeyID)
+	if !found {
+		return "", fmt.Errorf("%w: %q", errUnknownEnvelopeKey, envelope.KeyID)
+	}
+
+	aead, err := newEnvelopeCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(envelope.Data)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errInvalidEnvelopeData
+	}
+
+	original, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errInvalidEnvelopeData, err)
+	}
+
+	if envelope.Kind == bytesKind {
+		return base64.StdEncoding.EncodeToString(original), nil
+	}
+
+	return string(original), nil
+}
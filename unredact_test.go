@@ -0,0 +1,193 @@
+package rere_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+// jsonAt returns the value of decoded JSON at keys, which are object keys and array indexes.
+func jsonAt(value any, keys ...any) any {
+	for _, key := range keys {
+		switch typedKey := key.(type) {
+		case string:
+			object, _ := value.(map[string]any)
+			value = object[typedKey]
+		case int:
+			array, _ := value.([]any)
+			value = array[typedKey]
+		}
+	}
+
+	return value
+}
+
+func TestUnredact(t *testing.T) {
+	t.Parallel()
+
+	previousKeyring, err := rere.NewKeyring("k1", map[string][]byte{"k1": []byte("0123456789abcdef")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyring, err := rere.NewKeyring("k2", map[string][]byte{
+		"k1": []byte("0123456789abcdef"),
+		"k2": []byte("fedcba9876543210fedcba9876543210"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the customer is encrypted with the previous key to check envelopes of rotated keys are restored
+	customer, err := rere.MarshalJSON(reportUser{Username: "dustin", Password: "hunter2", Key: []byte("secret")},
+		rere.WithAllowList("username"), rere.WithEncryptionEnvelope(previousKeyring))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cards, err := rere.MarshalJSON([]credentialDetails{
+		{Name: "visa", Secret: "4111111111111111"},
+		{Name: "amex", Secret: "378282246310005"},
+	}, rere.WithAllowList("name"), rere.WithEncryptionEnvelope(keyring))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte(`{"ID":"order-1","Total":12345678901234567890.12,"Customer":` + string(customer) +
+		`,"Cards":` + string(cards) + `}`)
+
+	testCases := []struct {
+		name     string
+		paths    []string
+		restored map[string]any
+	}{
+		{
+			name:     "restores only the requested paths",
+			paths:    []string{"Customer.Password"},
+			restored: map[string]any{"Customer.Password": "hunter2"},
+		},
+		{
+			name:  "restores byte slices and values nested inside of paths",
+			paths: []string{"customer"},
+			restored: map[string]any{
+				"Customer.Password": "hunter2",
+				"Customer.Key":      base64.StdEncoding.EncodeToString([]byte("secret")),
+			},
+		},
+		{
+			name:  "restores every index",
+			paths: []string{"Cards[*].Secret"},
+			restored: map[string]any{
+				"Cards[0].Secret": "4111111111111111",
+				"Cards[1].Secret": "378282246310005",
+			},
+		},
+	}
+
+	locations := map[string][]any{
+		"Customer.Password": {"Customer", "Password"},
+		"Customer.Key":      {"Customer", "Key"},
+		"Cards[0].Secret":   {"Cards", 0, "Secret"},
+		"Cards[1].Secret":   {"Cards", 1, "Secret"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			restored, err := rere.Unredact(data, keyring, testCase.paths...)
+			g.Expect(err).NotTo(gomega.HaveOccurred(), "Unredact should restore envelopes")
+
+			var decoded any
+
+			g.Expect(json.Unmarshal(restored, &decoded)).To(gomega.Succeed(), "Unredact should return JSON")
+			g.Expect(string(restored)).To(gomega.ContainSubstring(`"Total":12345678901234567890.12`),
+				"Unredact should keep numbers as-is")
+			g.Expect(jsonAt(decoded, "ID")).To(gomega.Equal("order-1"), "Unredact should keep other values")
+			g.Expect(jsonAt(decoded, "Customer", "Username")).To(gomega.Equal("dustin"),
+				"Unredact should keep other values")
+
+			for location, keys := range locations {
+				if expected, found := testCase.restored[location]; found {
+					g.Expect(jsonAt(decoded, keys...)).To(gomega.Equal(expected), "Unredact should restore "+location)
+				} else {
+					g.Expect(jsonAt(decoded, append(keys, "$rere", "alg")...)).To(gomega.Equal("aes-gcm"),
+						"Unredact should leave the envelope of "+location)
+				}
+			}
+		})
+	}
+}
+
+func TestUnredactErrors(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := rere.NewKeyring("k1", map[string][]byte{"k1": []byte("0123456789abcdef")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKeyring, err := rere.NewKeyring("k2", map[string][]byte{"k2": []byte("fedcba9876543210")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enveloped, err := rere.MarshalJSON(map[string]string{"token": "abc123"}, rere.WithEncryptionEnvelope(keyring))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		data    []byte
+		keyring *rere.Keyring
+		paths   []string
+		err     string
+	}{
+		{
+			name:    "invalid path",
+			data:    enveloped,
+			keyring: keyring,
+			paths:   []string{"token["},
+			err:     `invalid path "token[": index is missing closing bracket`,
+		},
+		{
+			name:    "invalid JSON",
+			data:    []byte("{"),
+			keyring: keyring,
+			paths:   []string{"token"},
+			err:     "failed to decode JSON: unexpected EOF",
+		},
+		{
+			name:    "unknown key ID",
+			data:    enveloped,
+			keyring: otherKeyring,
+			paths:   []string{"token"},
+			err:     `failed to unredact token: envelope key ID is not in keyring: "k1"`,
+		},
+		{
+			name:    "tampered data",
+			data:    []byte(`{"token":{"$rere":{"alg":"aes-gcm","kid":"k1","data":"AAAA"}}}`),
+			keyring: keyring,
+			paths:   []string{"token"},
+			err:     "failed to unredact token: envelope data is invalid",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			_, err := rere.Unredact(testCase.data, testCase.keyring, testCase.paths...)
+
+			g.Expect(err).To(gomega.MatchError(testCase.err), "Unredact should return an error")
+		})
+	}
+}
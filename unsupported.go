@@ -0,0 +1,25 @@
+package rere
+
+import (
+	"reflect"
+)
+
+// UnsupportedKindHandler handles a value of a kind rere can't redact, such as a func, chan, or unsafe.Pointer, found
+// at path, such as "Callbacks[0]". value belongs to the redacted copy and can be set, such as with value.SetZero.
+type UnsupportedKindHandler func(path string, value reflect.Value)
+
+// WithUnsupportedKindHandler calls handler for every func, chan, and unsafe.Pointer value instead of silently carrying
+// it over to the redacted copy, including nil values, so deployments can log them, clear them, or panic to fail fast.
+// Func and chan values set to nil by WithNilFuncsAndChans are provided to handler as nil values.
+func WithUnsupportedKindHandler(handler UnsupportedKindHandler) Option {
+	return func(o *options) {
+		o.unsupportedKindHandler = handler
+	}
+}
+
+// handleUnsupportedKind provides the value at valueLocation to the handler provided to WithUnsupportedKindHandler.
+func (o *options) handleUnsupportedKind(valueLocation location, value reflect.Value) {
+	if o.unsupportedKindHandler != nil {
+		o.unsupportedKindHandler(valueLocation.path.String(), value)
+	}
+}
@@ -0,0 +1,73 @@
+package rere_test
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type jobHooks struct {
+	Name     string
+	OnDone   func()
+	Events   chan string
+	Raw      unsafe.Pointer
+	Handlers map[string]func()
+}
+
+func TestRedactWithUnsupportedKindHandler(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	events := make(chan string)
+	input := jobHooks{
+		Name:     "nightly",
+		OnDone:   func() {},
+		Events:   events,
+		Raw:      unsafe.Pointer(&events),
+		Handlers: map[string]func(){"retry": func() {}, "skip": nil},
+	}
+
+	var paths []string
+
+	redactedInput := rere.RedactWithAllowList(input, []string{"Name"},
+		rere.WithUnsupportedKindHandler(func(path string, value reflect.Value) {
+			paths = append(paths, path)
+
+			value.SetZero()
+		}))
+
+	g.Expect(paths).To(gomega.ConsistOf("OnDone", "Events", "Raw", "Handlers.retry", "Handlers.skip"),
+		"WithUnsupportedKindHandler should be called for every func, chan, and unsafe.Pointer value")
+	g.Expect(redactedInput.Name).To(gomega.Equal("nightly"), "WithUnsupportedKindHandler should not affect other values")
+	g.Expect(redactedInput.OnDone).To(gomega.BeNil(), "WithUnsupportedKindHandler should be able to set funcs")
+	g.Expect(redactedInput.Events).To(gomega.BeNil(), "WithUnsupportedKindHandler should be able to set chans")
+	g.Expect(redactedInput.Raw).To(gomega.Equal(unsafe.Pointer(nil)),
+		"WithUnsupportedKindHandler should be able to set unsafe.Pointers")
+	g.Expect(redactedInput.Handlers).To(gomega.HaveKeyWithValue("retry", gomega.BeNil()),
+		"WithUnsupportedKindHandler should be able to set map values")
+	g.Expect(input.OnDone).NotTo(gomega.BeNil(), "WithUnsupportedKindHandler should not modify the provided value")
+	g.Expect(input.Events).To(gomega.Equal(events), "WithUnsupportedKindHandler should not modify the provided value")
+}
+
+func TestRedactCarriesOverUnsupportedKindsWithoutHandler(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	events := make(chan string)
+	input := jobHooks{
+		Name:     "nightly",
+		OnDone:   nil,
+		Events:   events,
+		Raw:      unsafe.Pointer(&events),
+		Handlers: nil,
+	}
+
+	redactedInput := rere.RedactWithAllowList(input, []string{"Name"})
+
+	g.Expect(redactedInput.Events).To(gomega.Equal(events), "RedactWithAllowList should carry over chans by default")
+	g.Expect(redactedInput.Raw).To(gomega.Equal(input.Raw),
+		"RedactWithAllowList should carry over unsafe.Pointers by default")
+}
@@ -0,0 +1,27 @@
+package rere
+
+import (
+	"regexp"
+)
+
+// urlPassword matches the password of the user information of URLs, such as "hunter2" in
+// "postgres://admin:hunter2@db/prod". Passwords end at the last @ before the host, since unescaped @ appear in
+// passwords more often than in hosts.
+//
+//nolint:gochecknoglobals // regular expressions are compiled once
+var urlPassword = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s/?#@:"'<>]*:([^\s/?#"'<>]*)@`)
+
+// URLCredentialsDetector creates a Detector matching the passwords of URLs found anywhere in text, such as "hunter2"
+// in an error message quoting "postgres://admin:hunter2@db/prod". Only passwords are matched, so the scheme, user, and
+// host remain useful after redaction.
+func URLCredentialsDetector() Detector {
+	return DetectorFunc(func(text string) [][]int {
+		var matches [][]int
+
+		for _, match := range urlPassword.FindAllStringSubmatchIndex(text, -1) {
+			matches = append(matches, match[2:4])
+		}
+
+		return matches
+	})
+}
@@ -0,0 +1,79 @@
+package rere
+
+import (
+	"regexp"
+)
+
+// WithValuePatterns redacts string and []byte values matching any of patterns regardless of their field or key name,
+// such as a GitHub token matching `ghp_[A-Za-z0-9]+` in an allowed Message or Description field. A value matches when
+// a pattern matches any part of it, and the whole value is replaced with the placeholder.
+//
+// Values are only matched against patterns when rules keep them, including values kept by `rere:"keep"` tags, path
+// rules, and the allow list, so values matching patterns are never kept. Statistics record them as redacted by
+// ValueRule.
+func WithValuePatterns(patterns ...*regexp.Regexp) Option {
+	return func(o *options) {
+		o.valuePatterns = patterns
+	}
+}
+
+// WithValueDetectors redacts string and []byte values in which any of detectors finds a match regardless of their field
+// or key name, the same as WithValuePatterns. SecretFormatDetector detects credentials with well-known formats:
+//
+//	rere.RedactWithAllowList(event, allowList, rere.WithValueDetectors(rere.SecretFormatDetector()))
+func WithValueDetectors(detectors ...Detector) Option {
+	return func(o *options) {
+		o.valueDetectors = detectors
+	}
+}
+
+// shouldRedactValue is the same as shouldRedact for a string or byte slice value holding text, also redacting values
+// matching patterns provided to WithValuePatterns or detectors provided to WithValueDetectors.
+func (o *options) shouldRedactValue(valueLocation location, text string) bool {
+	if !o.matchesValues() {
+		return o.shouldRedact(valueLocation)
+	}
+
+	redact, source := o.decide(valueLocation)
+
+	if !redact && o.matchesValue(text) {
+		redact, source = true, ValueRule
+	}
+
+	o.recordDecision(valueLocation, redact, source)
+
+	return redact
+}
+
+// shouldRedactBytesValue is the same as shouldRedactValue for a byte slice value, only converting it to a string when
+// values are matched.
+func (o *options) shouldRedactBytesValue(valueLocation location, value []byte) bool {
+	if !o.matchesValues() {
+		return o.shouldRedact(valueLocation)
+	}
+
+	return o.shouldRedactValue(valueLocation, string(value))
+}
+
+// matchesValues reports whether values are matched by WithValuePatterns or WithValueDetectors.
+func (o *options) matchesValues() bool {
+	return len(o.valuePatterns) != 0 || len(o.valueDetectors) != 0
+}
+
+// matchesValue reports whether text matches a pattern provided to WithValuePatterns or a detector provided to
+// WithValueDetectors finds a match in it.
+func (o *options) matchesValue(text string) bool {
+	for _, pattern := range o.valuePatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+
+	for _, detector := range o.valueDetectors {
+		if len(detect(text, []Detector{detector})) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+package rere_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type supportTicket struct {
+	ID          string
+	Message     string
+	Description string `rere:"keep"`
+	Attachment  []byte
+	Labels      map[string]string
+}
+
+func TestRedactWithValuePatterns(t *testing.T) {
+	t.Parallel()
+
+	githubToken := regexp.MustCompile(`ghp_[A-Za-z0-9]+`)
+
+	input := supportTicket{
+		ID:          "T-1",
+		Message:     "my token is ghp_abc123, please rotate it",
+		Description: "leaked ghp_def456",
+		Attachment:  []byte("token=ghp_ghi789"),
+		Labels:      map[string]string{"team": "platform", "note": "ghp_jkl012"},
+	}
+
+	testCases := []struct {
+		name   string
+		redact func(supportTicket) supportTicket
+		output supportTicket
+	}{
+		{
+			name: "redacts values matching patterns outside of the deny list",
+			redact: func(value supportTicket) supportTicket {
+				return rere.RedactWithDenyList(value, nil, rere.WithValuePatterns(githubToken))
+			},
+			output: supportTicket{
+				ID:          "T-1",
+				Message:     redacted,
+				Description: redacted,
+				Attachment:  []byte(redacted),
+				Labels:      map[string]string{"team": "platform", "note": redacted},
+			},
+		},
+		{
+			name: "redacts values matching patterns in the allow list",
+			redact: func(value supportTicket) supportTicket {
+				return rere.RedactWithAllowList(value, []string{"ID", "Message", "Attachment", "team", "note"},
+					rere.WithValuePatterns(githubToken))
+			},
+			output: supportTicket{
+				ID:          "T-1",
+				Message:     redacted,
+				Description: redacted,
+				Attachment:  []byte(redacted),
+				Labels:      map[string]string{"team": "platform", "note": redacted},
+			},
+		},
+		{
+			name: "keeps values not matching patterns",
+			redact: func(value supportTicket) supportTicket {
+				return rere.RedactWithDenyList(value, nil, rere.WithValuePatterns(regexp.MustCompile(`AKIA[0-9A-Z]{16}`)))
+			},
+			output: input,
+		},
+		{
+			name: "redacts values matching patterns with fast paths",
+			redact: func(value supportTicket) supportTicket {
+				value.Labels = rere.RedactStringMap(value.Labels, rere.WithDenyList(), rere.WithValuePatterns(githubToken))
+
+				return value
+			},
+			output: supportTicket{
+				ID:          "T-1",
+				Message:     input.Message,
+				Description: input.Description,
+				Attachment:  input.Attachment,
+				Labels:      map[string]string{"team": "platform", "note": redacted},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			g.Expect(testCase.redact(input)).To(gomega.Equal(testCase.output),
+				"WithValuePatterns should redact values matching patterns regardless of their names")
+		})
+	}
+}
+
+func TestRedactWithValuePatternsStats(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var stats rere.Stats
+
+	rere.RedactWithDenyList(map[string]string{"message": "ghp_abc123", "password": "hunter2", "team": "platform"},
+		[]string{"password"}, rere.WithValuePatterns(regexp.MustCompile(`ghp_`)), rere.WithStats(&stats))
+
+	snapshot := stats.Snapshot()
+
+	g.Expect(snapshot.Redacted).To(gomega.Equal(2), "WithStats should count values redacted by value patterns")
+	g.Expect(snapshot.Sources).To(gomega.Equal(map[rere.RuleSource]int{rere.NameRule: 1, rere.ValueRule: 1}),
+		"WithStats should attribute values redacted by value patterns to ValueRule")
+}
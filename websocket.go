@@ -0,0 +1,76 @@
+package rere
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WebSocketDirection is the direction a WebSocket frame was sent in. See WebSocketLogger.
+type WebSocketDirection string
+
+const (
+	// WebSocketInbound is a frame received from the peer.
+	WebSocketInbound WebSocketDirection = "inbound"
+	// WebSocketOutbound is a frame sent to the peer.
+	WebSocketOutbound WebSocketDirection = "outbound"
+)
+
+// RedactWebSocketText redacts the payload of a WebSocket text frame. Payloads holding JSON are redacted by key name
+// the same as MarshalJSON, since socket protocols usually exchange JSON messages, and other payloads are redacted as
+// a single string. The provided payload is not modified.
+//
+// Every string is redacted unless WithAllowList or WithDenyList is provided.
+func RedactWebSocketText(payload []byte, opts ...Option) []byte {
+	if redacted, ok := redactJSONText(payload, opts); ok {
+		return redacted
+	}
+
+	return []byte(redactValue(string(payload), newOptions(allow, nil, opts)))
+}
+
+// WebSocketLogger logs redacted WebSocket frames for connection debugging, since long-lived socket traffic bypasses
+// HTTP middleware redaction. It doesn't depend on a WebSocket library, so it is called with the payload of every frame
+// read from or written to a connection.
+type WebSocketLogger struct {
+	logger *slog.Logger
+	opts   []Option
+}
+
+// NewWebSocketLogger creates a WebSocketLogger logging frames to logger at slog.LevelDebug after redacting them with
+// opts. When logger is nil, slog.Default is used.
+//
+// Every string is redacted unless WithAllowList or WithDenyList is provided.
+func NewWebSocketLogger(logger *slog.Logger, opts ...Option) *WebSocketLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WebSocketLogger{
+		logger: logger,
+		opts:   opts,
+	}
+}
+
+// LogText logs the payload of a text frame redacted with RedactWebSocketText. The payload is only redacted when the
+// logger is enabled for slog.LevelDebug.
+func (l *WebSocketLogger) LogText(ctx context.Context, direction WebSocketDirection, payload []byte) {
+	if !l.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "websocket frame",
+		slog.String("direction", string(direction)),
+		slog.String("type", "text"),
+		slog.Int("size", len(payload)),
+		slog.String("payload", string(RedactWebSocketText(payload, l.opts...))),
+	)
+}
+
+// LogBinary logs the size of a binary frame. Binary payloads are never logged, since their encoding isn't known.
+func (l *WebSocketLogger) LogBinary(ctx context.Context, direction WebSocketDirection, payload []byte) {
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "websocket frame",
+		slog.String("direction", string(direction)),
+		slog.String("type", "binary"),
+		slog.Int("size", len(payload)),
+	)
+}
@@ -0,0 +1,108 @@
+package rere_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactWebSocketText(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		payload  string
+		opts     []rere.Option
+		expected string
+	}{
+		{
+			name:     "redacts JSON payloads by key name",
+			payload:  `{"type":"auth","token":"abc123","count":2}`,
+			opts:     []rere.Option{rere.WithAllowList("type")},
+			expected: `{"count":2,"token":"REDACTED","type":"auth"}`,
+		},
+		{
+			name:     "redacts nested JSON payloads",
+			payload:  `[{"type":"message","data":{"text":"hello","session":"abc123"}}]`,
+			opts:     []rere.Option{rere.WithAllowList("type", "text")},
+			expected: `[{"data":{"session":"REDACTED","text":"hello"},"type":"message"}]`,
+		},
+		{
+			name:     "keeps JSON payloads outside of the deny list",
+			payload:  `{"type":"auth","token":"abc123"}`,
+			opts:     []rere.Option{rere.WithDenyList("token")},
+			expected: `{"token":"REDACTED","type":"auth"}`,
+		},
+		{
+			name:     "keeps integers too large for a float64 as-is",
+			payload:  `{"id":12345678901234567891,"token":"abc123"}`,
+			opts:     []rere.Option{rere.WithAllowList("id")},
+			expected: `{"id":12345678901234567891,"token":"REDACTED"}`,
+		},
+		{
+			name:     "redacts other payloads as a single string",
+			payload:  "AUTH abc123",
+			opts:     nil,
+			expected: redacted,
+		},
+		{
+			name:     "keeps other payloads in deny mode",
+			payload:  "PING",
+			opts:     []rere.Option{rere.WithDenyList("token")},
+			expected: "PING",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			payload := []byte(testCase.payload)
+
+			redactedPayload := rere.RedactWebSocketText(payload, testCase.opts...)
+
+			g.Expect(string(redactedPayload)).To(gomega.Equal(testCase.expected), "RedactWebSocketText should redact the payload")
+			g.Expect(string(payload)).To(gomega.Equal(testCase.payload), "RedactWebSocketText should not modify the payload")
+		})
+	}
+}
+
+func TestWebSocketLogger(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var output bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	webSocketLogger := rere.NewWebSocketLogger(logger, rere.WithAllowList("type"))
+
+	webSocketLogger.LogText(context.Background(), rere.WebSocketInbound, []byte(`{"type":"auth","token":"abc123"}`))
+	webSocketLogger.LogBinary(context.Background(), rere.WebSocketOutbound, []byte{0x01, 0x02, 0x03})
+
+	decoder := json.NewDecoder(&output)
+
+	var text, binary map[string]any
+
+	g.Expect(decoder.Decode(&text)).To(gomega.Succeed(), "LogText should log JSON")
+	g.Expect(decoder.Decode(&binary)).To(gomega.Succeed(), "LogBinary should log JSON")
+
+	g.Expect(text).To(gomega.HaveKeyWithValue("level", "DEBUG"), "LogText should log at debug")
+	g.Expect(text).To(gomega.HaveKeyWithValue("msg", "websocket frame"), "LogText should log the frame")
+	g.Expect(text).To(gomega.HaveKeyWithValue("direction", "inbound"), "LogText should log the direction")
+	g.Expect(text).To(gomega.HaveKeyWithValue("type", "text"), "LogText should log the frame type")
+	g.Expect(text).To(gomega.HaveKeyWithValue("size", float64(32)), "LogText should log the payload size")
+	g.Expect(text).To(gomega.HaveKeyWithValue("payload", `{"token":"REDACTED","type":"auth"}`),
+		"LogText should redact the payload")
+
+	g.Expect(binary).To(gomega.HaveKeyWithValue("direction", "outbound"), "LogBinary should log the direction")
+	g.Expect(binary).To(gomega.HaveKeyWithValue("type", "binary"), "LogBinary should log the frame type")
+	g.Expect(binary).To(gomega.HaveKeyWithValue("size", float64(3)), "LogBinary should log the payload size")
+	g.Expect(binary).NotTo(gomega.HaveKey("payload"), "LogBinary should not log the payload")
+}
@@ -0,0 +1,141 @@
+package rere
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Writer is an io.Writer scrubbing each written line with detectors before writing it to an underlying io.Writer.
+// Writer can be used with log.SetOutput or exec.Cmd.Stdout to catch leaks from code bypassing structured logging.
+//
+// Writer buffers partial lines until a newline is written, so Close should be called to write a final partial line.
+// Lines may end with "\n" or "\r\n" and line endings are kept as-is. A partial line longer than 64 KiB, such as binary
+// output, is replaced with a placeholder as soon as it grows past the limit and the rest of it is dropped, so memory
+// use is bounded.
+type Writer struct {
+	mutex     sync.Mutex
+	writer    io.Writer
+	detectors []Detector
+	buffer    []byte
+	// discarding is true while the rest of an oversized line is dropped, up to and including its newline.
+	discarding bool
+}
+
+// NewWriter creates a Writer scrubbing lines with detectors before writing them to writer.
+func NewWriter(writer io.Writer, detectors ...Detector) *Writer {
+	return &Writer{
+		mutex:      sync.Mutex{},
+		writer:     writer,
+		detectors:  detectors,
+		buffer:     nil,
+		discarding: false,
+	}
+}
+
+// Write scrubs and writes every complete line in p and buffers any remaining partial line. Write returns len(p) when
+// every complete line is written successfully. When writing fails, none of p is buffered, so p can be written again
+// without duplicating output.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	previousLength := len(w.buffer)
+	w.buffer = append(w.buffer, p...)
+
+	text := w.buffer
+	discarding := w.discarding
+
+	var scrubbed bytes.Buffer
+
+	if discarding {
+		newlineIndex := bytes.IndexByte(text, '\n')
+		if newlineIndex == -1 {
+			return len(p), nil
+		}
+
+		scrubbed.WriteString(lineEnding(text[:newlineIndex+1]))
+
+		text = text[newlineIndex+1:]
+		discarding = false
+	}
+
+	newlineIndex := bytes.LastIndexByte(text, '\n')
+	w.scrubLines(&scrubbed, text[:newlineIndex+1])
+
+	partial := text[newlineIndex+1:]
+	if len(partial) > maxLineLength {
+		scrubbed.WriteString(redactedMessage)
+
+		partial = nil
+		discarding = true
+	}
+
+	if scrubbed.Len() != 0 {
+		if err := w.write(scrubbed.Bytes()); err != nil {
+			// p is dropped from the buffer, so writing p again doesn't duplicate output
+			w.buffer = w.buffer[:previousLength]
+
+			return 0, err
+		}
+	}
+
+	w.buffer = append(w.buffer[:0], partial...)
+	w.discarding = discarding
+
+	return len(p), nil
+}
+
+// Close scrubs and writes any buffered partial line. Close does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.discarding = false
+
+	if len(w.buffer) == 0 {
+		return nil
+	}
+
+	var scrubbed bytes.Buffer
+
+	w.scrubLines(&scrubbed, w.buffer)
+
+	if err := w.write(scrubbed.Bytes()); err != nil {
+		return err
+	}
+
+	w.buffer = w.buffer[:0]
+
+	return nil
+}
+
+// scrubLines scrubs each line of text, which detectors are run against individually, into scrubbed.
+func (w *Writer) scrubLines(scrubbed *bytes.Buffer, text []byte) {
+	if len(text) == 0 {
+		return
+	}
+
+	for _, line := range bytes.SplitAfter(text, []byte("\n")) {
+		scrubbed.WriteString(scrubLine(string(line), w.detectors))
+	}
+}
+
+// write writes scrubbed lines to the underlying io.Writer.
+func (w *Writer) write(scrubbed []byte) error {
+	if _, err := w.writer.Write(scrubbed); err != nil {
+		return fmt.Errorf("failed to write scrubbed lines: %w", err)
+	}
+
+	return nil
+}
+
+// lineEnding returns the line ending of line, which is "\r\n" or "\n".
+func lineEnding(line []byte) string {
+	if bytes.HasSuffix(line, []byte("\r\n")) {
+		return "\r\n"
+	}
+
+	return "\n"
+}
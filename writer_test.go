@@ -0,0 +1,118 @@
+package rere_test
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var buffer bytes.Buffer
+
+	writer := rere.NewWriter(&buffer, rere.RegexpDetector(regexp.MustCompile(`password=\S+`)))
+
+	written, err := writer.Write([]byte("first password=hunter2\nsecond pass"))
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "Write should not error")
+	g.Expect(written).To(gomega.Equal(34), "Write should report every byte as written")
+	g.Expect(buffer.String()).To(gomega.Equal("first REDACTED\n"), "Write should only write complete lines")
+
+	_, err = writer.Write([]byte("word=hunter2\nthird"))
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "Write should not error")
+	g.Expect(buffer.String()).To(gomega.Equal("first REDACTED\nsecond REDACTED\n"),
+		"Write should scrub lines split across writes")
+
+	g.Expect(writer.Close()).To(gomega.Succeed(), "Close should not error")
+	g.Expect(buffer.String()).To(gomega.Equal("first REDACTED\nsecond REDACTED\nthird"),
+		"Close should write the final partial line")
+}
+
+func TestWriterWithLogger(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var buffer bytes.Buffer
+
+	logger := log.New(rere.NewWriter(&buffer, rere.RegexpDetector(regexp.MustCompile(`ghp_\w+`))), "", 0)
+	logger.Printf("token %s", "ghp_abc123")
+
+	g.Expect(buffer.String()).To(gomega.Equal("token REDACTED\n"), "Writer should scrub log output")
+}
+
+func TestWriterWriteError(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	writer := rere.NewWriter(failingWriter{})
+
+	_, err := writer.Write([]byte("line\n"))
+
+	g.Expect(err).To(gomega.MatchError(errWriteFailed), "Write should return write errors")
+}
+
+// failOnceWriter fails the first write and writes every other write to buffer.
+type failOnceWriter struct {
+	buffer bytes.Buffer
+	failed bool
+}
+
+func (f *failOnceWriter) Write(p []byte) (int, error) {
+	if !f.failed {
+		f.failed = true
+
+		return 0, errWriteFailed
+	}
+
+	return f.buffer.Write(p)
+}
+
+func TestWriterRetryAfterWriteError(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	output := &failOnceWriter{buffer: bytes.Buffer{}, failed: false}
+	writer := rere.NewWriter(output)
+
+	_, err := writer.Write([]byte("first\nsec"))
+	g.Expect(err).To(gomega.MatchError(errWriteFailed), "Write should return write errors")
+
+	_, err = writer.Write([]byte("first\nsec"))
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "Write should succeed when written again")
+
+	g.Expect(writer.Close()).To(gomega.Succeed(), "Close should not error")
+	g.Expect(output.buffer.String()).To(gomega.Equal("first\nsec"), "Write should not buffer bytes of failed writes")
+}
+
+func TestWriterReplacesOversizedLines(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	var buffer bytes.Buffer
+
+	writer := rere.NewWriter(&buffer)
+
+	chunk := []byte(strings.Repeat("a", 40*1024))
+
+	for i := 0; i < 3; i++ {
+		_, err := writer.Write(chunk)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), "Write should not error")
+	}
+
+	g.Expect(buffer.String()).To(gomega.Equal(redacted),
+		"Write should replace a partial line once it grows past the limit")
+
+	_, err := writer.Write([]byte("aaa\r\nnext\n"))
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "Write should not error")
+
+	g.Expect(buffer.String()).To(gomega.Equal(redacted+"\r\nnext\n"),
+		"Write should drop the rest of an oversized line and keep its line ending")
+}
@@ -0,0 +1,20 @@
+package rere
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML redacts value with opts and returns the YAML encoding of the redacted value. The provided value is not
+// modified.
+//
+// Every string and []byte is redacted unless WithAllowList or WithDenyList is provided.
+func MarshalYAML(value any, opts ...Option) ([]byte, error) {
+	redacted, err := yaml.Marshal(redactValue(value, newOptions(allow, nil, opts)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal redacted value: %w", err)
+	}
+
+	return redacted, nil
+}
@@ -0,0 +1,42 @@
+package rere_test
+
+import (
+	"testing"
+
+	"github.com/dustinspecker/rere"
+	"github.com/onsi/gomega"
+)
+
+type yamlConfig struct {
+	Host     string `yaml:"host"`
+	Password string `yaml:"password"`
+}
+
+func TestMarshalYAML(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	config := yamlConfig{
+		Host:     "db.internal",
+		Password: "hunter2",
+	}
+
+	marshaled, err := rere.MarshalYAML(config, rere.WithAllowList("host"))
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "MarshalYAML should marshal")
+	g.Expect(string(marshaled)).To(gomega.MatchYAML("host: db.internal\npassword: REDACTED\n"),
+		"MarshalYAML should marshal the redacted value")
+	g.Expect(config.Password).To(gomega.Equal("hunter2"), "MarshalYAML should not modify the provided value")
+
+	marshaled, err = rere.MarshalYAML(config, rere.WithDenyList("password"))
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "MarshalYAML should marshal")
+	g.Expect(string(marshaled)).To(gomega.MatchYAML("host: db.internal\npassword: REDACTED\n"),
+		"MarshalYAML should support deny lists")
+
+	marshaled, err = rere.MarshalYAML(config)
+
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "MarshalYAML should marshal")
+	g.Expect(string(marshaled)).To(gomega.MatchYAML("host: REDACTED\npassword: REDACTED\n"),
+		"MarshalYAML should redact every string by default")
+}